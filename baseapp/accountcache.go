@@ -0,0 +1,198 @@
+package baseapp
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// account store cache growth when it is adaptive, see SetAccountCacheMaxCapacity
+const (
+	accountCacheGrowthFactor = 2
+	accountCacheMaxMissRatio = 0.1
+)
+
+// AccountCacheMetrics are the metrics of the account store cache, updated on
+// every commit.
+type AccountCacheMetrics struct {
+	Hits      metrics.Counter
+	Misses    metrics.Counter
+	Evictions metrics.Counter
+	Size      metrics.Gauge
+	Capacity  metrics.Gauge
+}
+
+// PrometheusAccountCacheMetrics returns AccountCacheMetrics registered in the
+// default Prometheus registry, it can only be called once per process.
+func PrometheusAccountCacheMetrics() *AccountCacheMetrics {
+	return &AccountCacheMetrics{
+		Hits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "account_cache",
+			Name:      "hits",
+			Help:      "Number of accounts read from the account store cache",
+		}, nil),
+		Misses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "account_cache",
+			Name:      "misses",
+			Help:      "Number of accounts read from the account store as they were not cached",
+		}, nil),
+		Evictions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "account_cache",
+			Name:      "evictions",
+			Help:      "Number of accounts evicted from the account store cache",
+		}, nil),
+		Size: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Subsystem: "account_cache",
+			Name:      "size",
+			Help:      "Number of accounts in the account store cache",
+		}, nil),
+		Capacity: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Subsystem: "account_cache",
+			Name:      "capacity",
+			Help:      "Max number of accounts in the account store cache",
+		}, nil),
+	}
+}
+
+// NopAccountCacheMetrics returns no-op AccountCacheMetrics.
+func NopAccountCacheMetrics() *AccountCacheMetrics {
+	return &AccountCacheMetrics{
+		Hits:      discard.NewCounter(),
+		Misses:    discard.NewCounter(),
+		Evictions: discard.NewCounter(),
+		Size:      discard.NewGauge(),
+		Capacity:  discard.NewGauge(),
+	}
+}
+
+var errAccountCacheNotInstrumented = errors.New("the account store cache does not support stats, resizing nor prefetching")
+
+func (app *BaseApp) instrumentedAccountStoreCache() (sdk.InstrumentedAccountStoreCache, bool) {
+	cache, ok := app.AccountStoreCache.(sdk.InstrumentedAccountStoreCache)
+	return cache, ok
+}
+
+// AccountStoreCacheStats returns the stats of the account store cache
+func (app *BaseApp) AccountStoreCacheStats() (sdk.AccountStoreCacheStats, error) {
+	cache, ok := app.instrumentedAccountStoreCache()
+	if !ok {
+		return sdk.AccountStoreCacheStats{}, errAccountCacheNotInstrumented
+	}
+	return cache.Stats(), nil
+}
+
+// ResizeAccountStoreCache changes the capacity of the account store cache, it
+// can be called at any time.
+func (app *BaseApp) ResizeAccountStoreCache(cap int) error {
+	cache, ok := app.instrumentedAccountStoreCache()
+	if !ok {
+		return errAccountCacheNotInstrumented
+	}
+	if cap <= 0 {
+		return errors.New("account store cache capacity must be positive")
+	}
+	cache.Resize(cap)
+	app.Logger.Info("Resized the account store cache", "capacity", cap)
+	return nil
+}
+
+// PrefetchAccounts loads the accounts into the account store cache, it must
+// not be called while the account store is written.
+func (app *BaseApp) PrefetchAccounts(addrs []sdk.AccAddress) {
+	if cache, ok := app.instrumentedAccountStoreCache(); ok {
+		cache.Prefetch(addrs)
+	}
+}
+
+// mempoolSigners collects the signers of the txs accepted by CheckTx and
+// ReCheckTx since the last block, i.e. of the txs in the mempool
+type mempoolSigners struct {
+	mtx   sync.Mutex
+	addrs map[string]sdk.AccAddress
+}
+
+func newMempoolSigners() *mempoolSigners {
+	return &mempoolSigners{addrs: make(map[string]sdk.AccAddress)}
+}
+
+func (s *mempoolSigners) add(tx sdk.Tx) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, msg := range tx.GetMsgs() {
+		for _, signer := range msg.GetSigners() {
+			s.addrs[string(signer)] = signer
+		}
+	}
+}
+
+func (s *mempoolSigners) drain() []sdk.AccAddress {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	addrs := make([]sdk.AccAddress, 0, len(s.addrs))
+	for _, addr := range s.addrs {
+		addrs = append(addrs, addr)
+	}
+	s.addrs = make(map[string]sdk.AccAddress)
+	return addrs
+}
+
+// recordMempoolTx records the signers of a tx accepted in the mempool, to be
+// prefetched in the next BeginBlock
+func (app *BaseApp) recordMempoolTx(tx sdk.Tx, result sdk.Result) {
+	if app.prefetchMempoolSigners && result.IsOK() && tx != nil {
+		app.mempoolSigners.add(tx)
+	}
+}
+
+// prefetchMempoolAccounts warms the account store cache with the signers of
+// the txs in the mempool, which are likely to be delivered in the block
+func (app *BaseApp) prefetchMempoolAccounts() {
+	if !app.prefetchMempoolSigners {
+		return
+	}
+	app.PrefetchAccounts(app.mempoolSigners.drain())
+}
+
+// updateAccountCache reports the stats of the account store cache of the
+// committed block, and grows the cache if it is adaptive and missed too many
+// accounts while evicting others
+func (app *BaseApp) updateAccountCache() {
+	cache, ok := app.instrumentedAccountStoreCache()
+	if !ok {
+		return
+	}
+	stats := cache.Stats()
+	last := app.lastAccountCacheStats
+	app.lastAccountCacheStats = stats
+
+	// the counters of a new cache restart from 0
+	if stats.Hits < last.Hits || stats.Misses < last.Misses || stats.Evictions < last.Evictions {
+		last = sdk.AccountStoreCacheStats{}
+	}
+	hits, misses, evictions := stats.Hits-last.Hits, stats.Misses-last.Misses, stats.Evictions-last.Evictions
+	app.accountCacheMetrics.Hits.Add(float64(hits))
+	app.accountCacheMetrics.Misses.Add(float64(misses))
+	app.accountCacheMetrics.Evictions.Add(float64(evictions))
+	app.accountCacheMetrics.Size.Set(float64(stats.Size))
+	app.accountCacheMetrics.Capacity.Set(float64(stats.Capacity))
+
+	if stats.Capacity >= app.accountCacheMaxCapacity || evictions == 0 ||
+		float64(misses) <= accountCacheMaxMissRatio*float64(hits+misses) {
+		return
+	}
+	capacity := stats.Capacity * accountCacheGrowthFactor
+	if capacity > app.accountCacheMaxCapacity {
+		capacity = app.accountCacheMaxCapacity
+	}
+	if err := app.ResizeAccountStoreCache(capacity); err != nil {
+		app.Logger.Error("Failed to grow the account store cache", "err", err)
+		return
+	}
+	app.lastAccountCacheStats = cache.Stats()
+}
@@ -0,0 +1,71 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestAccountStoreCachePrefetchAndGrowth(t *testing.T) {
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	app := setupBaseApp(t,
+		SetPrefetchMempoolAccounts(true),
+		SetAccountCacheMaxCapacity(3),
+		func(bapp *BaseApp) {
+			bapp.Router().AddRoute("TestMsg", func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+		})
+	accountStore := app.cms.GetKVStore(capKey2)
+	app.SetAccountStoreCache(cdc, accountStore, 2)
+
+	// the txs of the mempool are signed by 2 accounts, the 3rd is not used
+	var addrs []sdk.AccAddress
+	for i := int64(0); i < 3; i++ {
+		acc := auth.NewBaseAccountWithAddress(testAccAddress(i))
+		accountStore.Set(auth.AddressStoreKey(acc.Address), cdc.MustMarshalBinaryBare(&acc))
+		addrs = append(addrs, acc.Address)
+	}
+	txs := map[string]sdk.Tx{
+		"tx0": txTest{Msgs: []sdk.Msg{sdk.NewTestMsg(addrs[0])}},
+		"tx1": txTest{Msgs: []sdk.Msg{sdk.NewTestMsg(addrs[1])}},
+	}
+	app.TxDecoder = func(txBytes []byte) (sdk.Tx, sdk.Error) {
+		return txs[string(txBytes)], nil
+	}
+	app.InitChain(abci.RequestInitChain{})
+
+	require.True(t, app.CheckTx(abci.RequestCheckTx{Tx: []byte("tx0")}).IsOK())
+	require.True(t, app.ReCheckTx(abci.RequestCheckTx{Tx: []byte("tx1")}).IsOK())
+	stats, err := app.AccountStoreCacheStats()
+	require.Nil(t, err)
+	require.Equal(t, 0, stats.Size)
+
+	// the signers of the mempool txs are cached by BeginBlock
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	stats, _ = app.AccountStoreCacheStats()
+	require.Equal(t, sdk.AccountStoreCacheStats{Size: 2, Capacity: 2}, stats)
+	for _, addr := range addrs {
+		require.NotNil(t, app.AccountStoreCache.GetAccount(addr))
+	}
+	stats, _ = app.AccountStoreCacheStats()
+	require.Equal(t, sdk.AccountStoreCacheStats{Hits: 2, Misses: 1, Evictions: 1, Size: 2, Capacity: 2}, stats)
+
+	// the cache grows up to the max capacity when it misses too many accounts
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+	stats, _ = app.AccountStoreCacheStats()
+	require.Equal(t, 3, stats.Capacity)
+
+	require.Nil(t, app.ResizeAccountStoreCache(10))
+	require.NotNil(t, app.ResizeAccountStoreCache(0))
+	stats, _ = app.AccountStoreCacheStats()
+	require.Equal(t, 10, stats.Capacity)
+}
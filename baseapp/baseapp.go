@@ -40,6 +40,8 @@ const (
 	TxSourceKey = "txSrc"
 	//this number should be around the size of the transactions in a block, TODO: configurable
 	TxMsgCacheSize = 4000
+	// number of accounts cached by a query at a past height
+	historicalAccountCacheCap = 100
 )
 
 // BaseApp reflects the ABCI application implementation.
@@ -66,6 +68,18 @@ type BaseApp struct {
 	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
 	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
 
+	parallelDeliverTx bool            // speculatively deliver the txs of parallelRoutes in parallel
+	parallelRoutes    map[string]bool // routes whose handlers only depend on the stores and accounts
+
+	streamingListener StreamingListener // notified of the state changes of the delivered blocks, may be nil
+	stateChanges      *stateChanges     // changes of the deliver state not streamed yet
+
+	txLanes *txLanes // lanes of the txs checked by CheckTx
+
+	// reads the max gas used by the txs of a block once gas is metered from the
+	// state, 0 if not limited
+	blockGasLimitGetter func(ctx sdk.Context) uint64
+
 	//--------------------
 	// Volatile
 	// CheckState is set on initialization and reset on Commit.
@@ -75,11 +89,22 @@ type BaseApp struct {
 	DeliverState *state // for DeliverTx
 
 	AccountStoreCache sdk.AccountStoreCache
+	accountStoreKey   sdk.StoreKey // key of the store of AccountStoreCache if it is mounted
+	accountCodec      *codec.Codec
 	txMsgCache        *lru.Cache
-	Pool              *sdk.Pool
+
+	accountCacheMetrics     *AccountCacheMetrics
+	lastAccountCacheStats   sdk.AccountStoreCacheStats // stats reported to the metrics
+	accountCacheMaxCapacity int                        // the account store cache grows up to it, see SetAccountCacheMaxCapacity
+	prefetchMempoolSigners  bool                       // prefetch the accounts of the mempool txs in BeginBlock
+	mempoolSigners          *mempoolSigners
+	Pool                    *sdk.Pool
+
+	blockStore BlockMetaStore // headers of the committed blocks, for the queries at a height
 
 	// Snapshot for state sync related fields
 	StateSyncHelper *store.StateSyncHelper // manage state sync related status
+	snapshotOptions store.SnapshotOptions  // options of the snapshots taken by StateSyncHelper
 
 	// flag for sealing
 	sealed bool
@@ -113,6 +138,13 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		collect:     collectConfig,
 		txMsgCache:  cache,
 		Pool:        new(sdk.Pool),
+
+		parallelRoutes: make(map[string]bool),
+		stateChanges:   new(stateChanges),
+		txLanes:        newTxLanes(0, nil),
+
+		accountCacheMetrics: NopAccountCacheMetrics(),
+		mempoolSigners:      newMempoolSigners(),
 	}
 
 	sdk.UpgradeMgr.AddConfig(sdk.MainNetConfig) // TODO: make this configurable
@@ -304,18 +336,27 @@ func (app *BaseApp) SetCheckState(header abci.Header) {
 }
 
 func (app *BaseApp) SetDeliverState(header abci.Header) {
-	accountCache := auth.NewAccountCache(app.AccountStoreCache)
-
-	ms := app.cms.CacheMultiStore()
+	ms, accountCache := app.listenDeliverState(app.cms.CacheMultiStore(), auth.NewAccountCache(app.AccountStoreCache))
 	app.DeliverState = &state{
 		ms:           ms,
 		AccountCache: accountCache,
-		Ctx:          sdk.NewContext(ms, header, sdk.RunTxModeDeliver, app.Logger).WithAccountCache(accountCache),
+		Ctx: sdk.NewContext(ms, header, sdk.RunTxModeDeliver, app.Logger).
+			WithAccountCache(accountCache),
 	}
+	app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockGasMeter(app.newBlockGasMeter(app.DeliverState.Ctx))
 }
 
 func (app *BaseApp) SetAccountStoreCache(cdc *codec.Codec, accountStore sdk.KVStore, cap int) {
 	app.AccountStoreCache = auth.NewAccountStoreCache(cdc, accountStore, cap)
+	app.lastAccountCacheStats = sdk.AccountStoreCacheStats{}
+
+	// the account changes are streamed as changes of the account store
+	app.accountCodec = cdc
+	for key, store := range app.cms.GetCommitKVStores() {
+		if sdk.KVStore(store) == accountStore {
+			app.accountStoreKey = key
+		}
+	}
 }
 
 //______________________________________________________________________________
@@ -486,8 +527,10 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		return sdk.ErrUnknownRequest("no custom querier found for route " + path[1]).QueryResult()
 	}
 
-	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
-	ctx = ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache))
+	ctx, err := app.NewQueryContext(req.Height)
+	if err != nil {
+		return err.QueryResult()
+	}
 
 	// Passes the rest of the path as an argument to the querier.
 	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
@@ -504,6 +547,60 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 	}
 }
 
+// BlockMetaStore loads the metadata of the committed blocks, it is implemented
+// by the block store of tendermint
+type BlockMetaStore interface {
+	LoadBlockMeta(height int64) *tmtypes.BlockMeta
+}
+
+// SetBlockStore sets the store the headers of the queries at a height are
+// loaded from, such queries fail until it is set
+func (app *BaseApp) SetBlockStore(blockStore BlockMetaStore) {
+	app.blockStore = blockStore
+}
+
+// NewQueryContext returns the context of the custom queries, on the latest
+// committed state if height is 0, or on the state committed at height with the
+// header loaded from the block store set by SetBlockStore. Apps serving queries
+// outside of the query router should use it as well.
+func (app *BaseApp) NewQueryContext(height int64) (sdk.Context, sdk.Error) {
+	lastHeight := app.LastBlockHeight()
+	if height < 0 || height > lastHeight {
+		return sdk.Context{}, sdk.ErrUnknownRequest(
+			fmt.Sprintf("cannot query with height %d, the latest height is %d", height, lastHeight))
+	}
+	if height == 0 || height == lastHeight {
+		ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
+		return ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache)), nil
+	}
+
+	ms, err := app.cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.Context{}, sdk.ErrUnknownRequest(
+			fmt.Sprintf("failed to load state at height %d: %v (latest height: %d)", height, err, lastHeight))
+	}
+	// the accounts are read from the account store of the height
+	var accountStoreCache sdk.AccountStoreCache
+	if app.AccountStoreCache != nil {
+		if app.accountStoreKey == nil {
+			return sdk.Context{}, sdk.ErrUnknownRequest("cannot query accounts at a height, the account store is not mounted in the multistore")
+		}
+		accountStoreCache = auth.NewAccountStoreCache(app.accountCodec, ms.GetKVStore(app.accountStoreKey), historicalAccountCacheCap)
+	}
+
+	if app.blockStore == nil {
+		return sdk.Context{}, sdk.ErrUnknownRequest(
+			fmt.Sprintf("cannot query with height %d, the block store is not available", height))
+	}
+	meta := app.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return sdk.Context{}, sdk.ErrUnknownRequest(
+			fmt.Sprintf("failed to load the header at height %d (latest height: %d)", height, lastHeight))
+	}
+	ctx := sdk.NewContext(ms, tmtypes.TM2PB.Header(&meta.Header), sdk.RunTxModeCheck, app.Logger)
+	return ctx.WithAccountCache(auth.NewAccountCache(accountStoreCache)), nil
+}
+
 // BeginBlock implements the ABCI application interface.
 func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
 	if app.cms.TracingEnabled() {
@@ -527,10 +624,16 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockHash(req.Hash).WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	app.prefetchMempoolAccounts()
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.DeliverState.Ctx, req)
 	}
+	// the block gas limit set by the begin blockers, e.g. at the GasMetering
+	// upgrade height, applies to the txs of the block
+	app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockGasMeter(app.newBlockGasMeter(app.DeliverState.Ctx))
 
+	app.streamBeginBlock(req, res)
 	return
 }
 
@@ -560,37 +663,59 @@ func (app *BaseApp) RemoveTxFromCache(txBytes []byte) {
 func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
 	var result sdk.Result
 	var tx sdk.Tx
+	var priority int64
 	txBytes := req.Tx
 	// try to get the Tx first from cache, if succeed, it means it is PreChecked.
 	tx, ok := app.GetTxFromCache(txBytes)
 	if ok {
 		txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
 		app.Logger.Debug("Handle CheckTx", "Tx", txHash)
-		result = app.RunTx(sdk.RunTxModeCheckAfterPre, tx, txHash)
+		result, priority = app.checkTxInLane(sdk.RunTxModeCheckAfterPre, tx, txHash)
 	} else {
-		tx, err := app.TxDecoder(txBytes)
+		var err sdk.Error
+		tx, err = app.TxDecoder(txBytes)
 		if err != nil {
 			result = err.Result()
 		} else {
 			app.txMsgCache.Add(string(txBytes), tx) // for recheck
 			txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
 			app.Logger.Debug("Handle CheckTx", "Tx", txHash)
-			result = app.RunTx(sdk.RunTxModeCheck, tx, txHash)
+			result, priority = app.checkTxInLane(sdk.RunTxModeCheck, tx, txHash)
 		}
 	}
 
 	if !result.IsOK() {
 		app.txMsgCache.Remove(string(req.Tx)) //not usable by DeliverTx
 	}
+	app.recordMempoolTx(tx, result)
 
 	return abci.ResponseCheckTx{
 		Code:   uint32(result.Code),
 		Data:   result.Data,
 		Log:    result.Log,
+		Info:   txPriorityInfo(result, priority),
 		Events: result.GetEvents(),
 	}
 }
 
+// checkTxInLane runs a tx in CheckTx if its lane has space left until the next
+// block, it returns the priority of the tx if it is accepted
+func (app *BaseApp) checkTxInLane(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (sdk.Result, int64) {
+	lane := app.txLanes.laneOf(tx)
+	if !app.txLanes.admit(lane, false) {
+		return sdk.ErrTxLaneFull(fmt.Sprintf("tx lane %s is full until the next block", lane.Name)).Result(), 0
+	}
+
+	result := app.RunTx(mode, tx, txHash)
+	if !result.IsOK() {
+		app.txLanes.release(lane)
+		return result, 0
+	}
+	priority := txPriority(lane, tx)
+	result.Events = append(result.Events, txPriorityEvent(lane, priority))
+	return result, priority
+}
+
 func (app *BaseApp) preCheck(txBytes []byte, mode sdk.RunTxMode) sdk.Result {
 	var res sdk.Result
 	if app.preChecker != nil && !app.txMsgCache.Contains(string(txBytes)) {
@@ -611,10 +736,17 @@ func (app *BaseApp) preCheck(txBytes []byte, mode sdk.RunTxMode) sdk.Result {
 // PreCheckTx would perform decoding, signture and other basic verification
 func (app *BaseApp) PreCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
 	result := app.preCheck(req.Tx, sdk.RunTxModeCheck)
+	var priority int64
+	if tx, ok := app.GetTxFromCache(req.Tx); ok && result.IsOK() {
+		lane := app.txLanes.laneOf(tx)
+		priority = txPriority(lane, tx)
+		result.Events = append(result.Events, txPriorityEvent(lane, priority))
+	}
 	return abci.ResponseCheckTx{
 		Code:   uint32(result.Code),
 		Data:   result.Data,
 		Log:    result.Log,
+		Info:   txPriorityInfo(result, priority),
 		Events: result.GetEvents(),
 	}
 }
@@ -630,7 +762,8 @@ func (app *BaseApp) ReCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx
 	if ok {
 		result = app.ReRunTx(txBytes, tx)
 	} else { // not suppose to enter here actually
-		var tx, err = app.TxDecoder(txBytes)
+		var err sdk.Error
+		tx, err = app.TxDecoder(txBytes)
 		if err != nil {
 			result = err.Result()
 		} else {
@@ -638,6 +771,13 @@ func (app *BaseApp) ReCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx
 		}
 	}
 
+	// the txs still in the mempool take the space of their lane in the next
+	// block first
+	if result.IsOK() {
+		app.txLanes.admit(app.txLanes.laneOf(tx), true)
+	}
+	app.recordMempoolTx(tx, result)
+
 	return abci.ResponseCheckTx{
 		Code:   uint32(result.Code),
 		Data:   result.Data,
@@ -648,36 +788,59 @@ func (app *BaseApp) ReCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx
 
 // Implements ABCI
 func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
-	// Decode the Tx.
-	var result sdk.Result
-	txBytes := req.Tx
+	result := app.deliverTx(app.decodeDeliverTx(req.Tx))
+
+	// Even though the Result.Code is not OK, there are still effects,
+	// namely fee deductions and sequence incrementing.
+
+	// Tell the blockchain engine (i.e. Tendermint).
+	res = toResponseDeliverTx(result)
+	app.streamDeliverTx(req, res)
+	return res
+}
+
+// deliverTxRequest is a tx to deliver, err is set if it can not be decoded
+type deliverTxRequest struct {
+	tx     sdk.Tx
+	txHash string
+	mode   sdk.RunTxMode
+	err    sdk.Error
+}
+
+// Decode the Tx.
+func (app *BaseApp) decodeDeliverTx(txBytes []byte) deliverTxRequest {
 	tx, ok := app.GetTxFromCache(txBytes) //from checkTx
 	if ok {
 		// here means either the tx has passed PreDeliverTx or CheckTx,
 		// no need to verify signature
 		txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
-		app.Logger.Debug("Handle DeliverTx", "Tx", txHash)
-		result = app.RunTx(sdk.RunTxModeDeliverAfterPre, tx, txHash)
-	} else {
-		var tx, err = app.TxDecoder(txBytes)
-		if err != nil {
-			result = err.Result()
-		} else {
-			txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
-			app.Logger.Debug("Handle DeliverTx", "Tx", txHash)
-			result = app.RunTx(sdk.RunTxModeDeliver, tx, txHash)
-		}
+		return deliverTxRequest{tx: tx, txHash: txHash, mode: sdk.RunTxModeDeliverAfterPre}
 	}
 
-	// Even though the Result.Code is not OK, there are still effects,
-	// namely fee deductions and sequence incrementing.
+	tx, err := app.TxDecoder(txBytes)
+	if err != nil {
+		return deliverTxRequest{err: err}
+	}
+	txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
+	return deliverTxRequest{tx: tx, txHash: txHash, mode: sdk.RunTxModeDeliver}
+}
 
-	// Tell the blockchain engine (i.e. Tendermint).
+func (app *BaseApp) deliverTx(req deliverTxRequest) sdk.Result {
+	if req.err != nil {
+		return req.err.Result()
+	}
+	app.Logger.Debug("Handle DeliverTx", "Tx", req.txHash)
+	return app.RunTx(req.mode, req.tx, req.txHash)
+}
+
+func toResponseDeliverTx(result sdk.Result) abci.ResponseDeliverTx {
 	return abci.ResponseDeliverTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:      uint32(result.Code),
+		Data:      result.Data,
+		Log:       result.Log,
+		GasWanted: int64(result.GasWanted),
+		GasUsed:   int64(result.GasUsed),
+		Events:    result.GetEvents(),
 	}
 }
 
@@ -735,8 +898,9 @@ func (app *BaseApp) getContextWithCache(mode sdk.RunTxMode, tx sdk.Tx, txHash st
 		)).(sdk.CacheMultiStore)
 	}
 	accountCache := getAccountCache(app, mode).Cache()
+	ctx, msCache = app.withTxGasMeter(ctx.WithMultiStore(msCache), mode, msCache)
 
-	return ctx.WithMultiStore(msCache).WithAccountCache(accountCache), msCache, accountCache
+	return ctx.WithAccountCache(accountCache), msCache, accountCache
 }
 
 // Iterates through msgs and executes them
@@ -822,17 +986,53 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 	// meter so we initialize upfront.
 	ctx, msCache, accountCache := app.getContextWithCache(mode, tx, txHash)
 
+	result, write, gasFee := app.runTx(ctx, mode, tx, txHash)
+
+	if mode == sdk.RunTxModeSimulate {
+		return
+	}
+	if isDeliverMode(mode) {
+		app.consumeBlockGas(&result)
+		write = write && result.IsOK()
+	}
+
+	// only update state if all messages pass
+	if write {
+		app.collectTx(mode, tx, txHash)
+		collectGasFee(mode, txHash, gasFee)
+		accountCache.Write()
+		msCache.Write()
+	}
+
+	return
+}
+
+// runTx runs the ante handler and the msgs of tx on ctx, write reports
+// whether the caches of ctx should be written, i.e. all messages passed and the
+// gas fee, charged on ctx once gas is metered, could be paid.
+func (app *BaseApp) runTx(ctx sdk.Context, mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result sdk.Result, write bool, gasFee sdk.Fee) {
+	gasMeter := ctx.GasMeter()
 	defer func() {
 		if r := recover(); r != nil {
-			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
-			result = sdk.ErrInternal(log).Result()
+			if outOfGas, ok := r.(sdk.ErrorOutOfGas); ok {
+				log := fmt.Sprintf("out of gas in location: %v, gas limit: %d", outOfGas.Descriptor, gasMeter.Limit())
+				result = sdk.ErrOutOfGas(log).Result()
+			} else {
+				log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
+				result = sdk.ErrInternal(log).Result()
+			}
+			write = false
 		}
 
+		if gasMeteringEnabled() {
+			result.GasWanted = gasMeter.Limit()
+			result.GasUsed = gasMeter.GasConsumedToLimit()
+		}
 	}()
 
 	var msgs = tx.GetMsgs()
 	if err := validateBasicTxMsgs(msgs); err != nil {
-		return err.Result()
+		return err.Result(), false, gasFee
 	}
 
 	// run the ante handler
@@ -844,7 +1044,7 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 		}
 
 		if abort {
-			return result
+			return result, false, gasFee
 		}
 	}
 
@@ -857,26 +1057,26 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 		msgs,
 		mode)
 
-	if mode == sdk.RunTxModeSimulate {
-		return
+	if result.IsOK() && gasMeteringEnabled() {
+		var err sdk.Error
+		if gasFee, err = chargeGasFee(ctx, tx, gasMeter.GasConsumedToLimit()); err != nil {
+			return err.Result(), false, gasFee
+		}
 	}
+	return result, result.IsOK(), gasFee
+}
 
-	// only update state if all messages pass
-	if result.IsOK() {
-		if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
-			if app.collect.CollectAccountBalance {
-				app.Pool.AddAddrs(msgs[0].GetInvolvedAddresses())
-			}
-			if app.collect.CollectTxs {
-				// Should we add all msg here with no distinction ？
-				app.Pool.AddTx(tx, txHash)
-			}
+// collectTx adds the addresses and the tx to the pool when a tx is delivered
+func (app *BaseApp) collectTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) {
+	if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
+		if app.collect.CollectAccountBalance {
+			app.Pool.AddAddrs(tx.GetMsgs()[0].GetInvolvedAddresses())
+		}
+		if app.collect.CollectTxs {
+			// Should we add all msg here with no distinction ？
+			app.Pool.AddTx(tx, txHash)
 		}
-		accountCache.Write()
-		msCache.Write()
 	}
-
-	return
 }
 
 // RunTx processes a transaction. The transactions is proccessed via an
@@ -934,6 +1134,7 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 		res = app.endBlocker(app.DeliverState.Ctx, req)
 	}
 
+	app.streamEndBlock(req, res)
 	return
 }
 
@@ -957,19 +1158,32 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.Logger.Debug("Commit synced",
 		"commit", commitID,
 	)
+	app.updateAccountCache()
 
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
 	app.SetCheckState(header)
+	app.txLanes.reset()
 
 	// Empty the Deliver state
 	app.DeliverState = nil
 	app.Pool.Clear()
 
-	return abci.ResponseCommit{
+	res = abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
+	app.streamCommit(res)
+	return res
+}
+
+// InitStateSyncHelper sets the StateSyncHelper of the app, taking the snapshots
+// with the options set by SetSnapshotOptions, and starts it from the snapshot
+// of lastBreatheBlockHeight
+func (app *BaseApp) InitStateSyncHelper(cdc *codec.Codec, lastBreatheBlockHeight int64) {
+	app.StateSyncHelper = store.NewStateSyncHelper(app.Logger, app.db, app.cms, cdc)
+	app.StateSyncHelper.SetSnapshotOptions(app.snapshotOptions)
+	app.StateSyncHelper.Init(lastBreatheBlockHeight)
 }
 
 func (app *BaseApp) StartRecovery(manifest *abci.Manifest) error {
@@ -1002,6 +1216,6 @@ func (app *BaseApp) GetDB() dbm.DB {
 	return app.db
 }
 
-func (app *BaseApp) SetPruning(strategy sdk.PruningStrategy) {
-	app.cms.SetPruning(strategy)
+func (app *BaseApp) SetPruning(opts sdk.PruningOptions) {
+	app.cms.SetPruning(opts)
 }
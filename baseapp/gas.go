@@ -0,0 +1,143 @@
+package baseapp
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// gasMeteringEnabled returns true once the gas of the txs is metered, from the
+// GasMetering upgrade height
+func gasMeteringEnabled() bool {
+	return sdk.IsUpgrade(sdk.GasMetering)
+}
+
+// blockGasLimit returns the gas limit of the blocks in the state of ctx, 0 if
+// the gas of the blocks is not limited
+func (app *BaseApp) blockGasLimit(ctx sdk.Context) uint64 {
+	if app.blockGasLimitGetter == nil || !gasMeteringEnabled() {
+		return 0
+	}
+	return app.blockGasLimitGetter(ctx)
+}
+
+func (app *BaseApp) newBlockGasMeter(ctx sdk.Context) sdk.GasMeter {
+	limit := app.blockGasLimit(ctx)
+	if limit == 0 {
+		return sdk.NewInfiniteGasMeter()
+	}
+	return sdk.NewGasMeter(limit)
+}
+
+// newTxGasMeter returns the gas meter of a tx run on ctx: a delivered tx can use
+// the gas left in the block, a checked tx the gas of a whole block
+func (app *BaseApp) newTxGasMeter(ctx sdk.Context, mode sdk.RunTxMode) sdk.GasMeter {
+	if isDeliverMode(mode) {
+		blockGasMeter := app.DeliverState.Ctx.BlockGasMeter()
+		if blockGasMeter.Limit() == 0 {
+			return sdk.NewInfiniteGasMeter()
+		}
+		return sdk.NewGasMeter(blockGasMeter.Limit() - blockGasMeter.GasConsumedToLimit())
+	}
+	limit := app.blockGasLimit(ctx)
+	if limit == 0 {
+		return sdk.NewInfiniteGasMeter()
+	}
+	return sdk.NewGasMeter(limit)
+}
+
+// withTxGasMeter makes the stores of msCache consume the gas of the tx run on
+// ctx, if gas metering is enabled
+func (app *BaseApp) withTxGasMeter(ctx sdk.Context, mode sdk.RunTxMode, msCache sdk.CacheMultiStore) (sdk.Context, sdk.CacheMultiStore) {
+	if !gasMeteringEnabled() {
+		return ctx, msCache
+	}
+	gasMeter := app.newTxGasMeter(ctx, mode)
+	msCache = store.NewGasCacheMultiStore(msCache, gasMeter, sdk.KVGasConfig())
+	return ctx.WithGasMeter(gasMeter).WithMultiStore(msCache), msCache
+}
+
+// consumeBlockGas consumes the gas used by a delivered tx from the block gas
+// meter. The tx fails if the block does not have enough gas left, which can
+// only happen to a tx executed in parallel as the gas left is the limit of the
+// others.
+func (app *BaseApp) consumeBlockGas(result *sdk.Result) {
+	if !gasMeteringEnabled() {
+		return
+	}
+	blockGasMeter := app.DeliverState.Ctx.BlockGasMeter()
+	gasLeft := blockGasMeter.Limit() - blockGasMeter.GasConsumedToLimit()
+	if blockGasMeter.Limit() != 0 && result.GasUsed > gasLeft {
+		*result = sdk.ErrOutOfGas(fmt.Sprintf("block gas limit %d reached", blockGasMeter.Limit())).Result()
+		result.GasWanted = gasLeft
+		result.GasUsed = gasLeft
+	}
+	blockGasMeter.ConsumeGas(result.GasUsed, "block gas")
+}
+
+// chargeGasFee deducts the fee of the gas used by a tx from its fee payer. As
+// for the fee charged by the ante handler, the fee of a tx is the fee of its
+// first msg, the only one the ante handler accepts, and it is paid by the first
+// signer of the tx. The ante handler charges the fee of the fee calculator of
+// the msg before the gas used is known, so only the difference with the fee of
+// its gas calculator is deducted here.
+func chargeGasFee(ctx sdk.Context, tx sdk.Tx, gasUsed sdk.Gas) (sdk.Fee, sdk.Error) {
+	msgs := tx.GetMsgs()
+	payer := txFeePayer(tx)
+	if len(msgs) == 0 || payer == nil {
+		return sdk.Fee{}, nil
+	}
+	calculator := fees.GetCalculator(msgs[0].Type())
+	fee, ok := fees.CalculateFee(msgs[0], gasUsed)
+	if !ok || calculator == nil || fee.Type == sdk.FeeFree {
+		return sdk.Fee{}, nil
+	}
+	gasFee := fee.Tokens.Minus(calculator(msgs[0]).Tokens)
+	if gasFee.IsZero() {
+		return sdk.Fee{}, nil
+	}
+
+	acc := ctx.AccountCache().GetAccount(payer)
+	if acc == nil {
+		return sdk.Fee{}, sdk.ErrUnknownAddress(payer.String())
+	}
+	coins := acc.GetCoins().Minus(gasFee)
+	if !coins.IsNotNegative() {
+		return sdk.Fee{}, sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins to pay the gas fee %s", gasFee))
+	}
+	if err := acc.SetCoins(coins); err != nil {
+		return sdk.Fee{}, sdk.ErrInternal(err.Error())
+	}
+	ctx.AccountCache().SetAccount(payer, acc)
+	return sdk.NewFee(gasFee, fee.Type), nil
+}
+
+// txFeePayer returns the first signer of a tx, in the order of auth.StdTx.GetSigners
+func txFeePayer(tx sdk.Tx) sdk.AccAddress {
+	for _, msg := range tx.GetMsgs() {
+		if signers := msg.GetSigners(); len(signers) > 0 {
+			return signers[0]
+		}
+	}
+	return nil
+}
+
+// collectGasFee adds the gas fee of a delivered tx to its fee in the fee pool,
+// so that it is distributed with it
+func collectGasFee(mode sdk.RunTxMode, txHash string, gasFee sdk.Fee) {
+	if !isDeliverMode(mode) || gasFee.IsEmpty() {
+		return
+	}
+	var fee sdk.Fee
+	if txFee := fees.Pool.GetFee(txHash); txFee != nil {
+		fee = *txFee
+	}
+	fee.AddFee(gasFee)
+	fees.Pool.AddFee(txHash, fee)
+}
+
+func isDeliverMode(mode sdk.RunTxMode) bool {
+	return mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre
+}
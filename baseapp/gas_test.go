@@ -0,0 +1,265 @@
+package baseapp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func setBlockGasLimit(limit uint64) func(*BaseApp) {
+	return func(bapp *BaseApp) {
+		bapp.SetBlockGasLimitGetter(func(sdk.Context) uint64 { return limit })
+	}
+}
+
+func TestBlockGasLimit(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GasMetering, 2)
+	defer sdk.UpgradeMgr.Reset()
+
+	// each tx consumes the gas of writing a 1 byte value
+	txGas := sdk.KVGasConfig().WriteCostFlat + sdk.KVGasConfig().WriteCostPerByte
+	blockGasLimit := 2*txGas + txGas/2
+
+	setupGasApp := func(options ...func(*BaseApp)) *BaseApp {
+		options = append(options,
+			setBlockGasLimit(blockGasLimit),
+			func(bapp *BaseApp) {
+				bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+					counter := msg.(*msgCounter).Counter
+					ctx.KVStore(capKey1).Set(i2b(counter), i2b(counter))
+					return sdk.Result{}
+				})
+				bapp.SetParallelRoutes(routeMsgCounter)
+			})
+		app := setupBaseApp(t, options...)
+		app.InitChain(abci.RequestInitChain{})
+		return app
+	}
+	serialApp := setupGasApp()
+	parallelApp := setupGasApp(SetParallelDeliverTx(true))
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	deliverBlock := func(height int64) (serial []abci.ResponseDeliverTx, parallel []abci.ResponseDeliverTx) {
+		var reqs []abci.RequestDeliverTx
+		for i := int64(0); i < 3; i++ {
+			reqs = append(reqs, abci.RequestDeliverTx{Tx: cdc.MustMarshalBinaryLengthPrefixed(newTxCounter(height*10+i, height*10+i))})
+		}
+		for _, app := range []*BaseApp{serialApp, parallelApp} {
+			app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		}
+		for _, req := range reqs {
+			serial = append(serial, serialApp.DeliverTx(req))
+		}
+		parallel = parallelApp.DeliverTxs(reqs)
+		for _, app := range []*BaseApp{serialApp, parallelApp} {
+			app.EndBlock(abci.RequestEndBlock{Height: height})
+			app.Commit()
+		}
+		return serial, parallel
+	}
+
+	// the gas is not metered before the upgrade height
+	serial, parallel := deliverBlock(1)
+	for i := range serial {
+		require.True(t, serial[i].IsOK(), serial[i].Log)
+		require.Equal(t, int64(0), serial[i].GasUsed)
+	}
+	require.Equal(t, serial, parallel)
+
+	// the txs of a block share its gas limit
+	serial, parallel = deliverBlock(2)
+	for i := 0; i < 2; i++ {
+		require.True(t, serial[i].IsOK(), serial[i].Log)
+		require.Equal(t, int64(txGas), serial[i].GasUsed)
+		require.True(t, parallel[i].IsOK(), parallel[i].Log)
+		require.Equal(t, int64(txGas), parallel[i].GasUsed)
+	}
+	outOfGas := uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas))
+	for _, res := range []abci.ResponseDeliverTx{serial[2], parallel[2]} {
+		require.Equal(t, outOfGas, res.Code, res.Log)
+		require.Equal(t, int64(blockGasLimit-2*txGas), res.GasUsed)
+	}
+	for _, app := range []*BaseApp{serialApp, parallelApp} {
+		store := app.cms.GetKVStore(capKey1)
+		require.Equal(t, i2b(21), store.Get(i2b(21)))
+		require.Nil(t, store.Get(i2b(22)))
+	}
+
+	// the gas limit is reset in every block
+	serial, _ = deliverBlock(3)
+	require.True(t, serial[0].IsOK(), serial[0].Log)
+}
+
+func TestBlockGasLimitFromState(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GasMetering, 2)
+	defer sdk.UpgradeMgr.Reset()
+
+	txGas := sdk.KVGasConfig().WriteCostFlat + sdk.KVGasConfig().WriteCostPerByte
+	limitKey := []byte("blockGasLimit")
+	app := setupBaseApp(t, func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			counter := msg.(*msgCounter).Counter
+			ctx.KVStore(capKey1).Set(i2b(counter), i2b(counter))
+			return sdk.Result{}
+		})
+		// the limit is set by the begin blocker of the upgrade height
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			if req.Header.Height == 2 {
+				bz := make([]byte, 8)
+				binary.BigEndian.PutUint64(bz, txGas/2)
+				ctx.KVStore(capKey2).Set(limitKey, bz)
+			}
+			return abci.ResponseBeginBlock{}
+		})
+		bapp.SetBlockGasLimitGetter(func(ctx sdk.Context) uint64 {
+			if bz := ctx.KVStore(capKey2).Get(limitKey); bz != nil {
+				return binary.BigEndian.Uint64(bz)
+			}
+			return 0
+		})
+	})
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	outOfGas := uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	res := app.DeliverTx(abci.RequestDeliverTx{Tx: cdc.MustMarshalBinaryLengthPrefixed(newTxCounter(1, 1))})
+	require.Equal(t, outOfGas, res.Code, res.Log)
+	app.EndBlock(abci.RequestEndBlock{Height: 2})
+	app.Commit()
+
+	// CheckTx uses the limit of the committed state
+	checkRes := app.CheckTx(abci.RequestCheckTx{Tx: cdc.MustMarshalBinaryLengthPrefixed(newTxCounter(2, 2))})
+	require.Equal(t, outOfGas, checkRes.Code, checkRes.Log)
+}
+
+// msgSignedCounter is a msgCounter signed by Signer
+type msgSignedCounter struct {
+	msgCounter
+	Signer sdk.AccAddress
+}
+
+func (msg msgSignedCounter) Type() string                 { return "signedCounter" }
+func (msg msgSignedCounter) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+
+// msgCoSignedCounter is a msgSignedCounter also signed by CoSigner
+type msgCoSignedCounter struct {
+	msgSignedCounter
+	CoSigner sdk.AccAddress
+}
+
+func (msg msgCoSignedCounter) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Signer, msg.CoSigner}
+}
+
+func TestGasFee(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GasMetering, 2)
+	defer sdk.UpgradeMgr.Reset()
+	defer fees.Pool.Clear()
+
+	msgType := msgSignedCounter{}.Type()
+	fixedFee := fees.FixedFeeCalculator(10, sdk.FeeForProposer)
+	fees.RegisterCalculator(msgType, fixedFee)
+	fees.RegisterGasCalculator(msgType, fees.FixedAndGasFeeCalculator(fixedFee, 2))
+	defer fees.UnsetAllCalculators()
+
+	// the ante handler charges the fixed fee
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			msg := tx.GetMsgs()[0]
+			fee := fees.GetCalculator(msg.Type())(msg)
+			acc := ctx.AccountCache().GetAccount(msg.GetSigners()[0])
+			acc.SetCoins(acc.GetCoins().Minus(fee.Tokens))
+			ctx.AccountCache().SetAccount(acc.GetAddress(), acc)
+			if isDeliverMode(mode) {
+				fees.Pool.AddFee(ctx.Value(TxHashKey).(string), fee)
+			}
+			return
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			var counter int64
+			switch msg := msg.(type) {
+			case msgSignedCounter:
+				counter = msg.Counter
+			case msgCoSignedCounter:
+				counter = msg.Counter
+			}
+			ctx.KVStore(capKey1).Set(i2b(counter), i2b(counter))
+			return sdk.Result{}
+		})
+	}
+	app := setupBaseApp(t, anteOpt, routerOpt, setBlockGasLimit(1000000))
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	app.SetAccountStoreCache(cdc, app.cms.GetKVStore(capKey2), 10)
+	app.InitChain(abci.RequestInitChain{})
+
+	addr := testAccAddress(0)
+	balance := func() int64 {
+		return app.DeliverState.Ctx.AccountCache().GetAccount(addr).GetCoins().AmountOf(sdk.NativeTokenSymbol)
+	}
+	deliver := func(height, counter, coins int64) sdk.Result {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		acc := auth.NewBaseAccountWithAddress(addr)
+		acc.SetCoins(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, coins)})
+		app.DeliverState.Ctx.AccountCache().SetAccount(addr, &acc)
+		tx := &txTest{Msgs: []sdk.Msg{msgSignedCounter{msgCounter{counter}, addr}}, Counter: counter}
+		return app.RunTx(sdk.RunTxModeDeliver, tx, string(i2b(counter)))
+	}
+	commit := func(height int64) {
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+
+	// only the fixed fee is charged before gas is metered
+	res := deliver(1, 1, 1000)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(990), balance())
+	require.Equal(t, int64(10), fees.Pool.GetFee(string(i2b(1))).Tokens.AmountOf(sdk.NativeTokenSymbol))
+	commit(1)
+
+	// the gas fee is charged on top of the fixed fee, and collected with it
+	res = deliver(2, 2, 100000)
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, res.GasUsed > 0)
+	gasFee := 2 * int64(res.GasUsed)
+	require.Equal(t, 99990-gasFee, balance())
+	fee := fees.Pool.GetFee(string(i2b(2)))
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 10+gasFee)}, sdk.FeeForProposer), *fee)
+	commit(2)
+
+	// a tx can't be delivered if the gas fee can't be paid
+	res = deliver(3, 3, 10+gasFee-1)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInsufficientCoins), res.Code, res.Log)
+	require.Equal(t, int64(10+gasFee-1), balance())
+	require.Nil(t, app.DeliverState.ms.GetKVStore(capKey1).Get(i2b(3)))
+	commit(3)
+
+	// the gas fee is paid by the first signer of the tx only
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 4}})
+	addr2 := testAccAddress(1)
+	for _, a := range []sdk.AccAddress{addr, addr2} {
+		acc := auth.NewBaseAccountWithAddress(a)
+		acc.SetCoins(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100000)})
+		app.DeliverState.Ctx.AccountCache().SetAccount(a, &acc)
+	}
+	tx := &txTest{Msgs: []sdk.Msg{msgCoSignedCounter{msgSignedCounter{msgCounter{4}, addr}, addr2}}, Counter: 4}
+	res = app.RunTx(sdk.RunTxModeDeliver, tx, string(i2b(4)))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, 99990-2*int64(res.GasUsed), balance())
+	require.Equal(t, int64(100000), app.DeliverState.Ctx.AccountCache().GetAccount(addr2).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	commit(4)
+}
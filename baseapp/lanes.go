@@ -0,0 +1,229 @@
+package baseapp
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// Event returned by CheckTx and PreCheckTx with the lane and priority of a tx,
+// the priority is also the Info of their response
+const (
+	EventTypeTxPriority  = "tx_priority"
+	AttributeKeyLane     = "lane"
+	AttributeKeyPriority = "priority"
+)
+
+// DefaultTxLaneName is the name of the lane of the txs in no configured lane
+const DefaultTxLaneName = "default"
+
+const (
+	// the priority of a tx is its lane priority * lanePriorityWeight + its fee
+	lanePriorityWeight = int64(1e12)
+	maxLanePriority    = int64(1 << 20)
+	maxPriorityFee     = lanePriorityWeight - 1
+)
+
+// TxLane is a class of txs CheckTx treats alike. A tx belongs to the first lane
+// all its msgs have a type of, and whose signers sign all its msgs if Signers is
+// set. Txs in no lane belong to the default lane, of priority 0 and without
+// reserved space, they only use the space no lane reserves.
+//
+// The lanes act on the admission of the txs into the mempool, and the priority
+// of the accepted txs is reported in the Info of ResponseCheckTx, as the
+// ResponseCheckTx of tendermint v0.32 has no priority field, for the mempool to
+// order the txs it reaps. A mempool reaping the txs in the order they were
+// accepted ignores it, and the lanes then give no guarantee on the txs included
+// in a block.
+type TxLane struct {
+	Name     string
+	MsgTypes []string         // msg types of the lane, see sdk.Msg.Type
+	Signers  []sdk.AccAddress // whitelisted signers of the txs of the lane, any signer if empty
+	Priority int64            // priority reported by CheckTx for the txs of the lane, on top of their fee
+	Reserved int              // number of txs of the lane CheckTx always accepts between two blocks
+}
+
+// ValidateTxLanes returns an error if the lanes can't be used together with a
+// block capacity, 0 meaning it is not limited.
+func ValidateTxLanes(capacity int, lanes []TxLane) error {
+	names := map[string]bool{DefaultTxLaneName: true}
+	reserved := 0
+	for _, lane := range lanes {
+		if lane.Name == "" || names[lane.Name] {
+			return fmt.Errorf("tx lane name %q is empty or used twice", lane.Name)
+		}
+		names[lane.Name] = true
+		if len(lane.MsgTypes) == 0 {
+			return fmt.Errorf("tx lane %s has no msg type", lane.Name)
+		}
+		if lane.Priority < 0 || lane.Priority >= maxLanePriority {
+			return fmt.Errorf("priority of tx lane %s must be in [0, %d)", lane.Name, maxLanePriority)
+		}
+		if lane.Reserved < 0 {
+			return fmt.Errorf("reserved space of tx lane %s is negative", lane.Name)
+		}
+		reserved += lane.Reserved
+	}
+	if capacity < 0 || (capacity > 0 && reserved > capacity) {
+		return fmt.Errorf("block capacity %d can't hold the %d txs reserved by the lanes", capacity, reserved)
+	}
+	return nil
+}
+
+func (lane *TxLane) contains(tx sdk.Tx) bool {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return false
+	}
+	for _, msg := range msgs {
+		if !lane.hasMsgType(msg.Type()) {
+			return false
+		}
+		for _, signer := range msg.GetSigners() {
+			if !lane.hasSigner(signer) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (lane *TxLane) hasMsgType(msgType string) bool {
+	for _, t := range lane.MsgTypes {
+		if t == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+func (lane *TxLane) hasSigner(signer sdk.AccAddress) bool {
+	if len(lane.Signers) == 0 {
+		return true
+	}
+	for _, s := range lane.Signers {
+		if s.Equals(signer) {
+			return true
+		}
+	}
+	return false
+}
+
+// txLanes assigns the checked txs to their lanes, and counts the txs accepted
+// by CheckTx since the last commit, or kept in the mempool by the recheck after
+// it, so that the reserved space of each lane is left for it
+type txLanes struct {
+	capacity    int // max number of txs accepted between two blocks, 0 if not limited
+	lanes       []TxLane
+	defaultLane TxLane
+
+	mtx    sync.Mutex
+	counts map[string]int // txs accepted by lane since the last commit
+	shared int            // txs accepted above the reserved space of their lane
+}
+
+func newTxLanes(capacity int, lanes []TxLane) *txLanes {
+	return &txLanes{
+		capacity:    capacity,
+		lanes:       lanes,
+		defaultLane: TxLane{Name: DefaultTxLaneName},
+		counts:      make(map[string]int),
+	}
+}
+
+func (l *txLanes) laneOf(tx sdk.Tx) *TxLane {
+	for i := range l.lanes {
+		if l.lanes[i].contains(tx) {
+			return &l.lanes[i]
+		}
+	}
+	return &l.defaultLane
+}
+
+// admit counts a tx in the lane, it returns false if the lane is full unless
+// force is set. The txs of the default lane only use the shared space, that is
+// the capacity minus the space reserved by the lanes.
+func (l *txLanes) admit(lane *TxLane, force bool) bool {
+	if l.capacity == 0 {
+		return true
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.counts[lane.Name] < lane.Reserved {
+		l.counts[lane.Name]++
+		return true
+	}
+	if !force && l.shared >= l.sharedCapacity() {
+		return false
+	}
+	l.counts[lane.Name]++
+	l.shared++
+	return true
+}
+
+// release uncounts a tx admitted in the lane
+func (l *txLanes) release(lane *TxLane) {
+	if l.capacity == 0 {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.counts[lane.Name]--
+	if l.counts[lane.Name] >= lane.Reserved {
+		l.shared--
+	}
+}
+
+func (l *txLanes) sharedCapacity() int {
+	shared := l.capacity
+	for _, lane := range l.lanes {
+		shared -= lane.Reserved
+	}
+	return shared
+}
+
+// reset starts counting the txs of a new block
+func (l *txLanes) reset() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.counts = make(map[string]int)
+	l.shared = 0
+}
+
+// txPriority orders the txs by the priority of their lane first, then by the
+// fee of their msgs in the native token. It is only reported in the events of
+// CheckTx, for the clients and the mempools able to use it.
+func txPriority(lane *TxLane, tx sdk.Tx) int64 {
+	var fee int64
+	for _, msg := range tx.GetMsgs() {
+		if calculator := fees.GetCalculator(msg.Type()); calculator != nil {
+			fee += calculator(msg).Tokens.AmountOf(sdk.NativeTokenSymbol)
+		}
+		if fee > maxPriorityFee {
+			fee = maxPriorityFee
+			break
+		}
+	}
+	return lane.Priority*lanePriorityWeight + fee
+}
+
+// txPriorityInfo is the Info of the response of CheckTx, the priority of the
+// tx if it is accepted
+func txPriorityInfo(result sdk.Result, priority int64) string {
+	if !result.IsOK() {
+		return ""
+	}
+	return strconv.FormatInt(priority, 10)
+}
+
+func txPriorityEvent(lane *TxLane, priority int64) sdk.Event {
+	return sdk.NewEvent(EventTypeTxPriority,
+		sdk.NewAttribute(AttributeKeyLane, lane.Name),
+		sdk.NewAttribute(AttributeKeyPriority, strconv.FormatInt(priority, 10)),
+	)
+}
@@ -0,0 +1,86 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+func TestValidateTxLanes(t *testing.T) {
+	lane := TxLane{Name: "oracle", MsgTypes: []string{"oracleClaim"}, Priority: 1, Reserved: 10}
+	require.Nil(t, ValidateTxLanes(0, []TxLane{lane}))
+	require.Nil(t, ValidateTxLanes(10, []TxLane{lane}))
+	require.NotNil(t, ValidateTxLanes(9, []TxLane{lane}))
+	require.NotNil(t, ValidateTxLanes(0, []TxLane{lane, lane}))
+	require.NotNil(t, ValidateTxLanes(0, []TxLane{{Name: DefaultTxLaneName, MsgTypes: []string{"oracleClaim"}}}))
+	require.NotNil(t, ValidateTxLanes(0, []TxLane{{Name: "empty"}}))
+	require.NotNil(t, ValidateTxLanes(0, []TxLane{{Name: "negative", MsgTypes: []string{"oracleClaim"}, Priority: -1}}))
+}
+
+func TestCheckTxLanes(t *testing.T) {
+	fees.RegisterCalculator(msgCounter{}.Type(), fees.FixedFeeCalculator(100, sdk.FeeForProposer))
+	defer fees.UnsetAllCalculators()
+
+	routerOpt := func(bapp *BaseApp) {
+		handler := func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} }
+		bapp.Router().AddRoute(routeMsgCounter, handler)
+		bapp.Router().AddRoute(routeMsgCounter2, handler)
+	}
+	// the counter2 lane has 1 reserved tx, the 2 other txs are shared with the default lane
+	lanesOpt := SetTxLanes(3, TxLane{Name: "counter2", MsgTypes: []string{msgCounter2{}.Type()}, Priority: 5, Reserved: 1})
+	app := setupBaseApp(t, routerOpt, lanesOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	var nonce int64
+	checkTx := func(msg sdk.Msg, recheck bool) abci.ResponseCheckTx {
+		nonce++
+		tx := &txTest{Msgs: []sdk.Msg{msg}, Counter: nonce}
+		req := abci.RequestCheckTx{Tx: cdc.MustMarshalBinaryLengthPrefixed(tx)}
+		if recheck {
+			return app.ReCheckTx(req)
+		}
+		return app.CheckTx(req)
+	}
+	requireAccepted := func(res abci.ResponseCheckTx, lane string, priority string) {
+		require.True(t, res.IsOK(), res.Log)
+		event := res.Events[len(res.Events)-1]
+		require.Equal(t, EventTypeTxPriority, event.Type)
+		require.Equal(t, lane, string(event.Attributes[0].Value))
+		require.Equal(t, priority, string(event.Attributes[1].Value))
+		require.Equal(t, priority, res.Info)
+	}
+	requireLaneFull := func(res abci.ResponseCheckTx) {
+		require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeTxLaneFull), sdk.ABCICodeType(res.Code), res.Log)
+	}
+
+	// the default lane only uses the shared space
+	requireAccepted(checkTx(msgCounter{1}, false), DefaultTxLaneName, "100")
+	requireAccepted(checkTx(msgCounter{1}, false), DefaultTxLaneName, "100")
+	requireLaneFull(checkTx(msgCounter{1}, false))
+
+	// a failed tx takes no space
+	require.False(t, checkTx(msgCounter2{-1}, false).IsOK())
+
+	// the txs of a lane use its reserved space, then the shared one
+	requireAccepted(checkTx(msgCounter2{1}, false), "counter2", "5000000000000")
+	requireLaneFull(checkTx(msgCounter2{1}, false))
+
+	// the txs left in the mempool after a block take their space in the next
+	// one first
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+	require.True(t, checkTx(msgCounter2{1}, true).IsOK())
+	require.True(t, checkTx(msgCounter2{1}, true).IsOK())
+	requireAccepted(checkTx(msgCounter{1}, false), DefaultTxLaneName, "100")
+	requireLaneFull(checkTx(msgCounter{1}, false))
+	requireLaneFull(checkTx(msgCounter2{1}, false))
+}
@@ -2,8 +2,6 @@
 package baseapp
 
 import (
-	"fmt"
-
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -12,21 +10,72 @@ import (
 // File for storing in-package BaseApp optional functions,
 // for options that need access to non-exported fields of the BaseApp
 
-// SetPruning sets a pruning option on the multistore associated with the app
-func SetPruning(pruning string) func(*BaseApp) {
-	var pruningEnum sdk.PruningStrategy
-	switch pruning {
-	case "nothing":
-		pruningEnum = sdk.PruneNothing
-	case "everything":
-		pruningEnum = sdk.PruneEverything
-	case "syncable":
-		pruningEnum = sdk.PruneSyncable
-	default:
-		panic(fmt.Sprintf("invalid pruning strategy: %s", pruning))
+// SetPruning sets the pruning options on the multistore associated with the app
+func SetPruning(opts sdk.PruningOptions) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.cms.SetPruning(opts)
+	}
+}
+
+// SetParallelDeliverTx enables the parallel delivery of the txs of the routes
+// set by SetParallelRoutes
+func SetParallelDeliverTx(enabled bool) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.parallelDeliverTx = enabled
+	}
+}
+
+// SetStreamingListener sets the listener notified of the state changes of the
+// delivered blocks
+func SetStreamingListener(listener StreamingListener) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.streamingListener = listener
+	}
+}
+
+// SetTxLanes sets the lanes of the txs checked by CheckTx, and the max number
+// of txs of these lanes CheckTx accepts between two blocks, 0 meaning no limit.
+// The txs of the default lane are not limited. It panics if the lanes are
+// invalid, see ValidateTxLanes.
+func SetTxLanes(capacity int, lanes ...TxLane) func(*BaseApp) {
+	if err := ValidateTxLanes(capacity, lanes); err != nil {
+		panic(err)
+	}
+	return func(bap *BaseApp) {
+		bap.txLanes = newTxLanes(capacity, lanes)
+	}
+}
+
+// SetSnapshotOptions sets the options of the snapshots taken after breathe
+// blocks by the StateSyncHelper created by InitStateSyncHelper
+func SetSnapshotOptions(opts store.SnapshotOptions) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.snapshotOptions = opts
+	}
+}
+
+// SetAccountCacheMetrics sets the metrics the stats of the account store cache
+// are reported to
+func SetAccountCacheMetrics(metrics *AccountCacheMetrics) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.accountCacheMetrics = metrics
+	}
+}
+
+// SetAccountCacheMaxCapacity makes the account store cache adaptive: on commit,
+// its capacity is doubled up to max if it evicted accounts while missing more
+// than 10% of the accounts read in the block. 0 keeps the capacity fixed.
+func SetAccountCacheMaxCapacity(max int) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.accountCacheMaxCapacity = max
 	}
+}
+
+// SetPrefetchMempoolAccounts makes BeginBlock warm the account store cache with
+// the signers of the txs in the mempool
+func SetPrefetchMempoolAccounts(enabled bool) func(*BaseApp) {
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.prefetchMempoolSigners = enabled
 	}
 }
 
@@ -79,6 +128,17 @@ func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	app.anteHandler = ah
 }
 
+// SetBlockGasLimitGetter sets the getter of the max gas the txs of a block can
+// use once the GasMetering upgrade height is reached, 0 meaning no limit. The
+// limit must be read from the state, e.g. the block gas limit of paramHub, so
+// that all the nodes of a network use the same one.
+func (app *BaseApp) SetBlockGasLimitGetter(getter func(ctx sdk.Context) uint64) {
+	if app.sealed {
+		panic("SetBlockGasLimitGetter() on sealed BaseApp")
+	}
+	app.blockGasLimitGetter = getter
+}
+
 func (app *BaseApp) SetPreChecker(pc sdk.PreChecker) {
 	if app.sealed {
 		panic("SetPreChecker() on sealed BaseApp")
@@ -100,6 +160,18 @@ func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 	app.pubkeyPeerFilter = pf
 }
 
+// SetParallelRoutes marks the routes whose ante and msg handlers only read and
+// write the stores and the account cache of the context, so their txs can be
+// delivered in parallel.
+func (app *BaseApp) SetParallelRoutes(routes ...string) {
+	if app.sealed {
+		panic("SetParallelRoutes() on sealed BaseApp")
+	}
+	for _, route := range routes {
+		app.parallelRoutes[route] = true
+	}
+}
+
 func (app *BaseApp) Router() Router {
 	if app.sealed {
 		panic("Router() on sealed BaseApp")
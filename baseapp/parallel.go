@@ -0,0 +1,188 @@
+package baseapp
+
+import (
+	"runtime"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// accountRWSetSpace is the space of the account addresses in the read/write
+// set of a tx
+const accountRWSetSpace = "__accounts__"
+
+// txExecution is the outcome of running a tx against the deliver state
+// without writing its caches
+type txExecution struct {
+	result           sdk.Result
+	write            bool
+	gasFee           sdk.Fee
+	msCache          sdk.CacheMultiStore
+	accountCache     sdk.AccountCache
+	rwSet            *store.RWSet
+	routerCallRecord map[string]bool
+	eventManager     *sdk.EventManager
+}
+
+// ParallelDeliverTxEnabled returns true if the txs of a block may be delivered
+// in batches by DeliverTxs
+func (app *BaseApp) ParallelDeliverTxEnabled() bool {
+	return app.parallelDeliverTx
+}
+
+// DeliverTxs delivers a batch of consecutive txs of a block, the responses are
+// the same as delivering them one by one with DeliverTx.
+//
+// The txs of a parallel route are first executed concurrently, each against
+// the deliver state as it was before the batch, recording the keys and
+// accounts they read and write. They are then committed in order, and a tx
+// that read anything written by a tx committed before it in the batch is
+// executed again against the current deliver state. Any other tx is delivered
+// serially and splits the batch.
+func (app *BaseApp) DeliverTxs(reqs []abci.RequestDeliverTx) []abci.ResponseDeliverTx {
+	responses := make([]abci.ResponseDeliverTx, len(reqs))
+
+	txs := make([]deliverTxRequest, len(reqs))
+	for i, req := range reqs {
+		txs[i] = app.decodeDeliverTx(req.Tx)
+	}
+
+	for start := 0; start < len(txs); {
+		end := start
+		for end < len(txs) && app.isParallelTx(txs[end]) {
+			end++
+		}
+		if end-start < 2 {
+			responses[start] = toResponseDeliverTx(app.deliverTx(txs[start]))
+			app.streamDeliverTx(reqs[start], responses[start])
+			start++
+			continue
+		}
+
+		app.deliverTxsInParallel(reqs[start:end], txs[start:end], responses[start:end])
+		start = end
+	}
+
+	return responses
+}
+
+func (app *BaseApp) isParallelTx(req deliverTxRequest) bool {
+	// the trace context of the deliver state is shared by the txs
+	if !app.parallelDeliverTx || req.err != nil || app.DeliverState.ms.TracingEnabled() {
+		return false
+	}
+	msgs := req.tx.GetMsgs()
+	return len(msgs) == 1 && app.parallelRoutes[msgs[0].Route()]
+}
+
+func (app *BaseApp) deliverTxsInParallel(reqs []abci.RequestDeliverTx, txs []deliverTxRequest, responses []abci.ResponseDeliverTx) {
+	executions := make([]*txExecution, len(txs))
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, runtime.NumCPU())
+	for i := range txs {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			executions[i] = app.executeTx(txs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	written := store.NewRWSet()
+	for i, req := range txs {
+		execution := executions[i]
+		if execution.rwSet.ReadsAnyWriteOf(written) {
+			app.Logger.Debug("Re-execute conflicting DeliverTx", "Tx", req.txHash)
+			execution = app.executeTx(req)
+		} else {
+			app.Logger.Debug("Handle DeliverTx", "Tx", req.txHash)
+		}
+
+		app.commitTx(req, execution)
+		written.MergeWrites(execution.rwSet)
+		responses[i] = toResponseDeliverTx(execution.result)
+		app.streamDeliverTx(reqs[i], responses[i])
+	}
+}
+
+// executeTx runs a tx against the deliver state on caches recording what it
+// reads from and writes to the deliver state
+func (app *BaseApp) executeTx(req deliverTxRequest) *txExecution {
+	rwSet := store.NewRWSet()
+	msCache := store.NewRWSetCacheMultiStore(app.DeliverState.ms, rwSet)
+	accountCache := auth.NewAccountCache(rwSetAccountCache{app.DeliverState.AccountCache, rwSet})
+	routerCallRecord := make(map[string]bool)
+	eventManager := sdk.NewEventManager()
+
+	ctx := app.DeliverState.Ctx.WithTx(req.tx).
+		WithMultiStore(msCache).
+		WithAccountCache(accountCache).
+		WithRouterCallRecord(routerCallRecord).
+		WithEventManager(eventManager)
+	ctx, msCache = app.withTxGasMeter(ctx, req.mode, msCache)
+	result, write, gasFee := app.runTx(ctx, req.mode, req.tx, req.txHash)
+
+	return &txExecution{
+		result:           result,
+		write:            write,
+		gasFee:           gasFee,
+		msCache:          msCache,
+		accountCache:     accountCache,
+		rwSet:            rwSet,
+		routerCallRecord: routerCallRecord,
+		eventManager:     eventManager,
+	}
+}
+
+// commitTx applies an execution to the deliver state the way RunTx does
+func (app *BaseApp) commitTx(req deliverTxRequest, execution *txExecution) {
+	app.consumeBlockGas(&execution.result)
+	if execution.write && execution.result.IsOK() {
+		app.collectTx(req.mode, req.tx, req.txHash)
+		collectGasFee(req.mode, req.txHash, execution.gasFee)
+		execution.accountCache.Write()
+		execution.msCache.Write()
+	}
+
+	for route := range execution.routerCallRecord {
+		app.DeliverState.Ctx.RouterCallRecord()[route] = true
+	}
+	app.DeliverState.Ctx.EventManager().EmitEvents(execution.eventManager.Events())
+}
+
+// rwSetAccountCache records the accounts a tx reads from and writes to the
+// account cache of the deliver state
+type rwSetAccountCache struct {
+	parent sdk.AccountStoreCache
+	rwSet  *store.RWSet
+}
+
+var _ sdk.AccountStoreCache = rwSetAccountCache{}
+
+func (ac rwSetAccountCache) GetAccount(addr sdk.AccAddress) sdk.Account {
+	ac.rwSet.RecordRead(accountRWSetSpace, addr)
+	return ac.parent.GetAccount(addr)
+}
+
+func (ac rwSetAccountCache) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
+	ac.rwSet.RecordWrite(accountRWSetSpace, addr)
+	ac.parent.SetAccount(addr, acc)
+}
+
+func (ac rwSetAccountCache) Delete(addr sdk.AccAddress) {
+	ac.rwSet.RecordWrite(accountRWSetSpace, addr)
+	ac.parent.Delete(addr)
+}
+
+func (ac rwSetAccountCache) ClearCache() {
+	ac.parent.ClearCache()
+}
@@ -0,0 +1,92 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// handlerParallelCounter increments the counter Counter%4, so txs whose
+// counters are equal mod 4 conflict
+func handlerParallelCounter(capKey *sdk.KVStoreKey) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		store := ctx.KVStore(capKey)
+		key := []byte{byte(msg.(*msgCounter).Counter % 4)}
+		value := getIntFromStore(store, key) + 1
+		setIntOnStore(store, key, value)
+		return sdk.Result{Data: i2b(value)}
+	}
+}
+
+// handlerCounterTotal sums up all the counters
+func handlerCounterTotal(capKey *sdk.KVStoreKey) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		store := ctx.KVStore(capKey)
+		var total int64
+		iter := store.Iterator([]byte{0}, []byte{4})
+		for ; iter.Valid(); iter.Next() {
+			total += getIntFromStore(store, iter.Key())
+		}
+		iter.Close()
+		setIntOnStore(store, []byte("total"), total)
+		return sdk.Result{Data: i2b(total)}
+	}
+}
+
+func TestDeliverTxs(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerParallelCounter(capKey1))
+		bapp.Router().AddRoute(routeMsgCounter2, handlerCounterTotal(capKey1))
+		bapp.SetParallelRoutes(routeMsgCounter)
+	}
+	serialApp := setupBaseApp(t, routerOpt)
+	parallelApp := setupBaseApp(t, routerOpt, SetParallelDeliverTx(true))
+	require.True(t, parallelApp.ParallelDeliverTxEnabled())
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	var reqs []abci.RequestDeliverTx
+	addTx := func(tx *txTest) {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(tx)
+		require.NoError(t, err)
+		reqs = append(reqs, abci.RequestDeliverTx{Tx: txBytes})
+	}
+	for i := int64(0); i < 20; i++ {
+		addTx(newTxCounter(i, i))
+		if i%7 == 0 {
+			addTx(&txTest{Msgs: []sdk.Msg{msgCounter2{i}}})
+		}
+	}
+	// invalid txs are delivered serially
+	addTx(newTxCounter(20, -1))
+	addTx(newTxCounter(21, 1, 2))
+	reqs = append(reqs, abci.RequestDeliverTx{Tx: []byte("undecodable")})
+	addTx(newTxCounter(22, 3))
+
+	nBlocks := 2
+	for blockN := 0; blockN < nBlocks; blockN++ {
+		serialApp.BeginBlock(abci.RequestBeginBlock{})
+		parallelApp.BeginBlock(abci.RequestBeginBlock{})
+
+		var expected []abci.ResponseDeliverTx
+		for _, req := range reqs {
+			expected = append(expected, serialApp.DeliverTx(req))
+		}
+		responses := parallelApp.DeliverTxs(reqs)
+		require.Equal(t, expected, responses)
+
+		serialApp.EndBlock(abci.RequestEndBlock{})
+		parallelApp.EndBlock(abci.RequestEndBlock{})
+		require.Equal(t, serialApp.Commit(), parallelApp.Commit())
+	}
+
+	// 21 increments in the first block and 15 before the last total of the second one
+	total := getIntFromStore(parallelApp.cms.GetKVStore(capKey1), []byte("total"))
+	require.Equal(t, int64(21+15), total)
+}
@@ -3,10 +3,14 @@ package baseapp
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 // Test that we can only query from the latest committed state.
@@ -64,6 +68,65 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+// Test that custom queries run against the state committed at the height of
+// the request.
+func TestCustomQueryAtHeight(t *testing.T) {
+	key, addr := []byte("hello"), testAccAddress(0)
+	querierOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("test", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			acc := ctx.AccountCache().GetAccount(addr)
+			return []byte{ctx.KVStore(capKey1).Get(key)[0], byte(acc.GetSequence()), byte(ctx.BlockHeight()), byte(ctx.BlockHeader().Time.Unix())}, nil
+		})
+	}
+	app := setupBaseApp(t, querierOpt, SetPruning(sdk.NewPruningOptions(2, 0, 1)))
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	app.SetAccountStoreCache(cdc, app.cms.GetKVStore(capKey2), 10)
+
+	blockStore := make(testBlockStore)
+	for height := int64(1); height <= 5; height++ {
+		header := abci.Header{Height: height, Time: time.Unix(height*10, 0)}
+		blockStore[height] = &tmtypes.BlockMeta{Header: tmtypes.Header{Height: height, Time: header.Time}}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.DeliverState.Ctx.KVStore(capKey1).Set(key, []byte{byte(height)})
+		acc := auth.NewBaseAccountWithAddress(addr)
+		acc.SetSequence(height * 10)
+		app.DeliverState.Ctx.AccountCache().SetAccount(addr, &acc)
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+
+	query := func(height int64) abci.ResponseQuery {
+		return app.Query(abci.RequestQuery{Path: "/custom/test", Height: height})
+	}
+	require.Equal(t, []byte{5, 50, 5, 50}, query(0).Value)
+
+	// the headers of the past heights are loaded from the block store
+	res := query(4)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), sdk.ABCICodeType(res.Code))
+	require.Contains(t, res.Log, "the block store is not available")
+	app.SetBlockStore(blockStore)
+	require.Equal(t, []byte{5, 50, 5, 50}, query(5).Value)
+	require.Equal(t, []byte{4, 40, 4, 40}, query(4).Value)
+	require.Equal(t, []byte{3, 30, 3, 30}, query(3).Value)
+	delete(blockStore, 3)
+	require.Contains(t, query(3).Log, "failed to load the header at height 3")
+
+	// pruned and future heights
+	res = query(2)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), sdk.ABCICodeType(res.Code))
+	require.Contains(t, res.Log, "failed to load state at height 2")
+	res = query(6)
+	require.Contains(t, res.Log, "cannot query with height 6, the latest height is 5")
+}
+
+type testBlockStore map[int64]*tmtypes.BlockMeta
+
+func (bs testBlockStore) LoadBlockMeta(height int64) *tmtypes.BlockMeta {
+	return bs[height]
+}
+
 // Test p2p filter queries
 func TestP2PQuery(t *testing.T) {
 	addrPeerFilterOpt := func(bapp *BaseApp) {
@@ -0,0 +1,371 @@
+package baseapp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// Built-in streaming sinks
+const (
+	StreamingSinkFile   = "file"
+	StreamingSinkSocket = "socket"
+)
+
+// StoreKVPair is a key set or deleted in a store while delivering a block,
+// Value is nil if the key is deleted.
+type StoreKVPair struct {
+	StoreKey string `json:"store_key"`
+	Delete   bool   `json:"delete"`
+	Key      []byte `json:"key"`
+	Value    []byte `json:"value"`
+}
+
+// StreamingListener is notified of the state changes of the blocks delivered
+// by the app, grouped by the ABCI message that made them. Changes made by
+// InitChain are notified with the first BeginBlock. The changes of the
+// accounts are notified in the store of the account store cache.
+type StreamingListener interface {
+	ListenBeginBlock(req abci.RequestBeginBlock, res abci.ResponseBeginBlock, changes []StoreKVPair) error
+	ListenDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx, changes []StoreKVPair) error
+	ListenEndBlock(req abci.RequestEndBlock, res abci.ResponseEndBlock, changes []StoreKVPair) error
+	ListenCommit(res abci.ResponseCommit) error
+}
+
+// stateChanges buffers the changes made to the deliver state until they are
+// passed to the streaming listener
+type stateChanges struct {
+	mtx     sync.Mutex
+	changes []StoreKVPair
+}
+
+var _ sdk.WriteListener = (*stateChanges)(nil)
+
+func (sc *stateChanges) OnWrite(storeKey sdk.StoreKey, key []byte, value []byte, delete bool) {
+	sc.add(storeKey.Name(), key, value, delete)
+}
+
+func (sc *stateChanges) add(storeKey string, key []byte, value []byte, delete bool) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	sc.changes = append(sc.changes, StoreKVPair{
+		StoreKey: storeKey,
+		Delete:   delete,
+		Key:      append([]byte(nil), key...),
+		Value:    append([]byte(nil), value...),
+	})
+}
+
+func (sc *stateChanges) drain() []StoreKVPair {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+	changes := sc.changes
+	sc.changes = nil
+	return changes
+}
+
+// listenAccountCache records the accounts set and deleted in the account cache
+// of the deliver state, directly or by writing a cache of it, as the changes
+// the account store cache will make to the account store
+type listenAccountCache struct {
+	sdk.AccountCache
+	storeKey string
+	cdc      *codec.Codec
+	changes  *stateChanges
+}
+
+func (ac listenAccountCache) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
+	ac.AccountCache.SetAccount(addr, acc)
+	if acc == nil {
+		// not written to the store by the account store cache
+		return
+	}
+	bz, err := ac.cdc.MarshalBinaryBare(acc)
+	if err != nil {
+		panic(err)
+	}
+	ac.changes.add(ac.storeKey, auth.AddressStoreKey(addr), bz, false)
+}
+
+func (ac listenAccountCache) Delete(addr sdk.AccAddress) {
+	ac.AccountCache.Delete(addr)
+	ac.changes.add(ac.storeKey, auth.AddressStoreKey(addr), nil, true)
+}
+
+func (ac listenAccountCache) Cache() sdk.AccountCache {
+	return auth.NewAccountCache(ac)
+}
+
+// listenDeliverState makes the changes of the deliver state being notified
+// to the streaming listener
+func (app *BaseApp) listenDeliverState(ms sdk.CacheMultiStore, accountCache sdk.AccountCache) (sdk.CacheMultiStore, sdk.AccountCache) {
+	if app.streamingListener == nil {
+		return ms, accountCache
+	}
+	ms = store.NewListenCacheMultiStore(ms, app.stateChanges)
+	if app.accountStoreKey != nil {
+		accountCache = listenAccountCache{accountCache, app.accountStoreKey.Name(), app.accountCodec, app.stateChanges}
+	} else {
+		app.Logger.Error("The account store is not mounted in the multistore, account changes are not streamed")
+	}
+	return ms, accountCache
+}
+
+func (app *BaseApp) streamBeginBlock(req abci.RequestBeginBlock, res abci.ResponseBeginBlock) {
+	if app.streamingListener == nil {
+		return
+	}
+	if err := app.streamingListener.ListenBeginBlock(req, res, app.stateChanges.drain()); err != nil {
+		app.Logger.Error("Failed to stream BeginBlock", "height", req.Header.Height, "err", err)
+	}
+}
+
+func (app *BaseApp) streamDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx) {
+	if app.streamingListener == nil {
+		return
+	}
+	if err := app.streamingListener.ListenDeliverTx(req, res, app.stateChanges.drain()); err != nil {
+		app.Logger.Error("Failed to stream DeliverTx", "tx", fmt.Sprintf("%X", tmhash.Sum(req.Tx)), "err", err)
+	}
+}
+
+func (app *BaseApp) streamEndBlock(req abci.RequestEndBlock, res abci.ResponseEndBlock) {
+	if app.streamingListener == nil {
+		return
+	}
+	if err := app.streamingListener.ListenEndBlock(req, res, app.stateChanges.drain()); err != nil {
+		app.Logger.Error("Failed to stream EndBlock", "height", req.Height, "err", err)
+	}
+}
+
+func (app *BaseApp) streamCommit(res abci.ResponseCommit) {
+	if app.streamingListener == nil {
+		return
+	}
+	if err := app.streamingListener.ListenCommit(res); err != nil {
+		app.Logger.Error("Failed to stream Commit", "err", err)
+	}
+}
+
+//______________________________________________________________________________
+
+// Stream record types
+const (
+	StreamRecordBeginBlock = "begin_block"
+	StreamRecordDeliverTx  = "deliver_tx"
+	StreamRecordEndBlock   = "end_block"
+	StreamRecordCommit     = "commit"
+)
+
+// StreamRecord is written by the built-in sinks for every ABCI message of a
+// block, each record is amino encoded and length prefixed.
+type StreamRecord struct {
+	Type    string        `json:"type"`
+	Height  int64         `json:"height"`
+	TxIndex int64         `json:"tx_index"` // index of the tx in the block, deliver_tx only
+	TxHash  []byte        `json:"tx_hash"`  // deliver_tx only
+	Code    uint32        `json:"code"`     // deliver_tx only
+	AppHash []byte        `json:"app_hash"` // commit only
+	Changes []StoreKVPair `json:"changes"`
+}
+
+// NewStreamingListener returns the built-in sink writing the stream records of
+// each block to a file in the directory path, or to the clients connected to
+// the Unix socket path.
+func NewStreamingListener(sink, path string, logger log.Logger) (StreamingListener, error) {
+	switch sink {
+	case StreamingSinkFile:
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return nil, err
+		}
+		return &recordStreamingListener{cdc: codec.New(), write: fileRecordWriter(path)}, nil
+	case StreamingSinkSocket:
+		w, err := newSocketRecordWriter(path, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &recordStreamingListener{cdc: codec.New(), write: w.write}, nil
+	default:
+		return nil, fmt.Errorf("unknown streaming sink %q, expected %s or %s", sink, StreamingSinkFile, StreamingSinkSocket)
+	}
+}
+
+// recordStreamingListener encodes the ABCI messages of a block as stream
+// records, and writes them once the block is committed
+type recordStreamingListener struct {
+	cdc     *codec.Codec
+	write   func(height int64, records []byte) error
+	height  int64
+	txIndex int64
+	records []byte
+}
+
+var _ StreamingListener = (*recordStreamingListener)(nil)
+
+func (l *recordStreamingListener) add(record StreamRecord) error {
+	record.Height = l.height
+	bz, err := l.cdc.MarshalBinaryLengthPrefixed(record)
+	if err != nil {
+		return err
+	}
+	l.records = append(l.records, bz...)
+	return nil
+}
+
+func (l *recordStreamingListener) ListenBeginBlock(req abci.RequestBeginBlock, res abci.ResponseBeginBlock, changes []StoreKVPair) error {
+	l.height = req.Header.Height
+	l.txIndex = 0
+	l.records = nil
+	return l.add(StreamRecord{Type: StreamRecordBeginBlock, Changes: changes})
+}
+
+func (l *recordStreamingListener) ListenDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx, changes []StoreKVPair) error {
+	record := StreamRecord{
+		Type:    StreamRecordDeliverTx,
+		TxIndex: l.txIndex,
+		TxHash:  tmhash.Sum(req.Tx),
+		Code:    res.Code,
+		Changes: changes,
+	}
+	l.txIndex++
+	return l.add(record)
+}
+
+func (l *recordStreamingListener) ListenEndBlock(req abci.RequestEndBlock, res abci.ResponseEndBlock, changes []StoreKVPair) error {
+	return l.add(StreamRecord{Type: StreamRecordEndBlock, Changes: changes})
+}
+
+func (l *recordStreamingListener) ListenCommit(res abci.ResponseCommit) error {
+	if err := l.add(StreamRecord{Type: StreamRecordCommit, AppHash: res.Data}); err != nil {
+		return err
+	}
+	records := l.records
+	l.records = nil
+	return l.write(l.height, records)
+}
+
+// fileRecordWriter writes the records of each block to the file block-<height>
+// of dir
+func fileRecordWriter(dir string) func(height int64, records []byte) error {
+	return func(height int64, records []byte) error {
+		file := filepath.Join(dir, fmt.Sprintf("block-%d", height))
+		// write a temporary file first, so that readers never see a partial block
+		if err := ioutil.WriteFile(file+".tmp", records, 0644); err != nil {
+			return err
+		}
+		return os.Rename(file+".tmp", file)
+	}
+}
+
+// socketClientBufferedBlocks is the number of blocks buffered for a socket
+// client, a client that falls further behind is disconnected
+const socketClientBufferedBlocks = 100
+
+// socketWriteTimeout bounds the time spent writing a block to a socket client
+const socketWriteTimeout = 10 * time.Second
+
+// socketRecordWriter writes the records of each block to the clients connected
+// to a Unix socket. The blocks are queued to each client and written by its own
+// routine, so that a slow client never blocks the commit, a client that can't
+// be written to or falls behind is disconnected
+type socketRecordWriter struct {
+	mtx      sync.Mutex
+	listener net.Listener
+	clients  map[*socketClient]struct{}
+	logger   log.Logger
+}
+
+type socketClient struct {
+	conn    net.Conn
+	records chan []byte
+}
+
+func newSocketRecordWriter(path string, logger log.Logger) (*socketRecordWriter, error) {
+	// remove the socket left by a previous run
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &socketRecordWriter{
+		listener: listener,
+		clients:  make(map[*socketClient]struct{}),
+		logger:   logger,
+	}
+	go w.acceptRoutine()
+	return w, nil
+}
+
+func (w *socketRecordWriter) acceptRoutine() {
+	for {
+		conn, err := w.listener.Accept()
+		if err != nil {
+			w.logger.Error("Streaming socket stopped accepting clients", "err", err)
+			return
+		}
+		client := &socketClient{
+			conn:    conn,
+			records: make(chan []byte, socketClientBufferedBlocks),
+		}
+		w.mtx.Lock()
+		w.clients[client] = struct{}{}
+		w.mtx.Unlock()
+		go w.sendRoutine(client)
+	}
+}
+
+func (w *socketRecordWriter) sendRoutine(client *socketClient) {
+	for records := range client.records {
+		err := client.conn.SetWriteDeadline(time.Now().Add(socketWriteTimeout))
+		if err == nil {
+			_, err = client.conn.Write(records)
+		}
+		if err != nil {
+			w.logger.Info("Disconnect streaming client", "err", err)
+			w.mtx.Lock()
+			w.disconnect(client)
+			w.mtx.Unlock()
+			return
+		}
+	}
+}
+
+// disconnect must be called with mtx held
+func (w *socketRecordWriter) disconnect(client *socketClient) {
+	if _, ok := w.clients[client]; !ok {
+		return
+	}
+	delete(w.clients, client)
+	close(client.records)
+	client.conn.Close()
+}
+
+func (w *socketRecordWriter) write(height int64, records []byte) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for client := range w.clients {
+		select {
+		case client.records <- records:
+		default:
+			w.logger.Info("Disconnect slow streaming client", "height", height)
+			w.disconnect(client)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package baseapp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+type testStreamedMsg struct {
+	msg     string
+	changes []StoreKVPair
+}
+
+type testStreamingListener struct {
+	msgs []testStreamedMsg
+}
+
+func (l *testStreamingListener) ListenBeginBlock(req abci.RequestBeginBlock, res abci.ResponseBeginBlock, changes []StoreKVPair) error {
+	l.msgs = append(l.msgs, testStreamedMsg{"begin", changes})
+	return nil
+}
+
+func (l *testStreamingListener) ListenDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx, changes []StoreKVPair) error {
+	l.msgs = append(l.msgs, testStreamedMsg{"tx", changes})
+	return nil
+}
+
+func (l *testStreamingListener) ListenEndBlock(req abci.RequestEndBlock, res abci.ResponseEndBlock, changes []StoreKVPair) error {
+	l.msgs = append(l.msgs, testStreamedMsg{"end", changes})
+	return nil
+}
+
+func (l *testStreamingListener) ListenCommit(res abci.ResponseCommit) error {
+	l.msgs = append(l.msgs, testStreamedMsg{"commit", nil})
+	return nil
+}
+
+func testAccAddress(i int64) sdk.AccAddress {
+	return sdk.AccAddress(tmhash.SumTruncated(i2b(i)))
+}
+
+// handlerStreamedCounter sets the counter Counter%4 and the account of the
+// counter, it fails after writing them if Counter%5 is 4
+func handlerStreamedCounter(capKey *sdk.KVStoreKey) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		counter := msg.(*msgCounter).Counter
+		setIntOnStore(ctx.KVStore(capKey), i2b(counter%4), counter)
+		acc := auth.NewBaseAccountWithAddress(testAccAddress(counter))
+		ctx.AccountCache().SetAccount(acc.Address, &acc)
+		if counter%5 == 4 {
+			return sdk.ErrInternal("failed").Result()
+		}
+		return sdk.Result{}
+	}
+}
+
+func TestStreamingListener(t *testing.T) {
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	setupStreamedApp := func(options ...func(*BaseApp)) (*BaseApp, *testStreamingListener) {
+		listener := &testStreamingListener{}
+		options = append(options,
+			SetStreamingListener(listener),
+			func(bapp *BaseApp) {
+				bapp.Router().AddRoute(routeMsgCounter, handlerStreamedCounter(capKey1))
+				bapp.SetParallelRoutes(routeMsgCounter)
+				bapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+					ctx.KVStore(capKey1).Delete(i2b(0))
+					return abci.ResponseEndBlock{}
+				})
+			})
+		app := setupBaseApp(t, options...)
+		app.SetAccountStoreCache(cdc, app.cms.GetKVStore(capKey2), 10)
+		return app, listener
+	}
+	serialApp, serialListener := setupStreamedApp()
+	parallelApp, parallelListener := setupStreamedApp(SetParallelDeliverTx(true))
+
+	var reqs []abci.RequestDeliverTx
+	for i := int64(0); i < 6; i++ {
+		txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(i, i))
+		require.NoError(t, err)
+		reqs = append(reqs, abci.RequestDeliverTx{Tx: txBytes})
+	}
+
+	serialApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	parallelApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	for _, req := range reqs {
+		serialApp.DeliverTx(req)
+	}
+	parallelApp.DeliverTxs(reqs)
+	serialApp.EndBlock(abci.RequestEndBlock{Height: 1})
+	parallelApp.EndBlock(abci.RequestEndBlock{Height: 1})
+	serialApp.Commit()
+	parallelApp.Commit()
+
+	accountChange := func(i int64) StoreKVPair {
+		acc := auth.NewBaseAccountWithAddress(testAccAddress(i))
+		return StoreKVPair{StoreKey: capKey2.Name(), Key: auth.AddressStoreKey(acc.Address), Value: cdc.MustMarshalBinaryBare(&acc)}
+	}
+	require.Len(t, serialListener.msgs, 9)
+	require.Equal(t, testStreamedMsg{"begin", nil}, serialListener.msgs[0])
+	for i := int64(0); i < 6; i++ {
+		msg := serialListener.msgs[1+i]
+		require.Equal(t, "tx", msg.msg)
+		if i == 4 {
+			// the changes of a failed tx are discarded
+			require.Nil(t, msg.changes)
+			continue
+		}
+		bz := make([]byte, binary.MaxVarintLen64)
+		require.ElementsMatch(t, []StoreKVPair{
+			{StoreKey: capKey1.Name(), Key: i2b(i % 4), Value: bz[:binary.PutVarint(bz, i)]},
+			accountChange(i),
+		}, msg.changes)
+	}
+	require.Equal(t, testStreamedMsg{"end", []StoreKVPair{{StoreKey: capKey1.Name(), Delete: true, Key: i2b(0)}}}, serialListener.msgs[7])
+	require.Equal(t, testStreamedMsg{"commit", nil}, serialListener.msgs[8])
+
+	// the txs delivered in parallel are streamed as if they were delivered one by one
+	require.Equal(t, serialListener.msgs, parallelListener.msgs)
+}
+
+func TestFileStreamingListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	listener, err := NewStreamingListener(StreamingSinkFile, dir, defaultLogger())
+	require.Nil(t, err)
+	changes := []StoreKVPair{{StoreKey: "store", Key: []byte("key"), Value: []byte("value")}}
+	require.Nil(t, listener.ListenBeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}}, abci.ResponseBeginBlock{}, nil))
+	require.Nil(t, listener.ListenDeliverTx(abci.RequestDeliverTx{Tx: []byte("tx")}, abci.ResponseDeliverTx{Code: 1}, nil))
+	require.Nil(t, listener.ListenDeliverTx(abci.RequestDeliverTx{Tx: []byte("tx2")}, abci.ResponseDeliverTx{}, changes))
+	require.Nil(t, listener.ListenEndBlock(abci.RequestEndBlock{Height: 3}, abci.ResponseEndBlock{}, nil))
+	require.Nil(t, listener.ListenCommit(abci.ResponseCommit{Data: []byte("hash")}))
+
+	bz, err := ioutil.ReadFile(filepath.Join(dir, "block-3"))
+	require.Nil(t, err)
+	var records []StreamRecord
+	r := bytes.NewReader(bz)
+	for r.Len() > 0 {
+		var record StreamRecord
+		_, err := codec.New().UnmarshalBinaryLengthPrefixedReader(r, &record, 0)
+		require.Nil(t, err)
+		records = append(records, record)
+	}
+	require.Equal(t, []StreamRecord{
+		{Type: StreamRecordBeginBlock, Height: 3},
+		{Type: StreamRecordDeliverTx, Height: 3, TxIndex: 0, TxHash: tmhash.Sum([]byte("tx")), Code: 1},
+		{Type: StreamRecordDeliverTx, Height: 3, TxIndex: 1, TxHash: tmhash.Sum([]byte("tx2")), Changes: changes},
+		{Type: StreamRecordEndBlock, Height: 3},
+		{Type: StreamRecordCommit, Height: 3, AppHash: []byte("hash")},
+	}, records)
+
+	_, err = NewStreamingListener("kafka", dir, defaultLogger())
+	require.NotNil(t, err)
+}
+
+func TestSocketRecordWriterSlowClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streaming")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "stream.sock")
+	w, err := newSocketRecordWriter(path, defaultLogger())
+	require.Nil(t, err)
+	defer w.listener.Close()
+
+	numClients := func() int {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		return len(w.clients)
+	}
+	// the client never reads the records
+	conn, err := net.Dial("unix", path)
+	require.Nil(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool { return numClients() == 1 }, time.Second, 10*time.Millisecond)
+
+	// the writes never block on the client, which is disconnected once its buffer is full
+	records := make([]byte, 1<<20)
+	start := time.Now()
+	for height := int64(1); height <= 2*socketClientBufferedBlocks; height++ {
+		require.Nil(t, w.write(height, records))
+	}
+	require.True(t, time.Since(start) < socketWriteTimeout)
+	require.Equal(t, 0, numClients())
+}
@@ -46,6 +46,42 @@ func (ctx CLIContext) QueryStore(key cmn.HexBytes, storeName string) (res []byte
 	return ctx.queryStore(key, storeName, "key")
 }
 
+// QueryStoreWithProof performs a query from a Tendermint node with the provided
+// key and store name, and always asks for a merkle proof. The proof is verified
+// unless the node is trusted, and is returned with the response so that it can
+// be handed over to a light client.
+func (ctx CLIContext) QueryStoreWithProof(key cmn.HexBytes, storeName string) (abci.ResponseQuery, error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return abci.ResponseQuery{}, err
+	}
+
+	path := fmt.Sprintf("/store/%s/key", storeName)
+	opts := rpcclient.ABCIQueryOptions{
+		Height: ctx.Height,
+		Prove:  true,
+	}
+
+	result, err := node.ABCIQueryWithOptions(path, key, opts)
+	if err != nil {
+		return abci.ResponseQuery{}, err
+	}
+
+	resp := result.Response
+	if !resp.IsOK() {
+		return abci.ResponseQuery{}, errors.Errorf(resp.Log)
+	}
+
+	if !ctx.TrustNode {
+		err = ctx.verifyProof(path, resp)
+		if err != nil {
+			return abci.ResponseQuery{}, err
+		}
+	}
+
+	return resp, nil
+}
+
 // QuerySubspace performs a query from a Tendermint node with the provided
 // store name and subspace.
 func (ctx CLIContext) QuerySubspace(subspace []byte, storeName string) (res []sdk.KVPair, err error) {
@@ -19,6 +19,7 @@ import (
 const (
 	queryArgDryRun       = "simulate"
 	queryArgGenerateOnly = "generate_only"
+	queryArgHeight       = "height"
 )
 
 //----------------------------------------
@@ -61,6 +62,27 @@ func ParseInt64OrReturnBadRequest(w http.ResponseWriter, s string) (n int64, ok
 	return n, true
 }
 
+// ParseQueryHeightOrReturnBadRequest returns cliCtx querying the state at the
+// height given by the request's URL query "height" parameter, or cliCtx
+// unchanged if the parameter is not set.
+func ParseQueryHeightOrReturnBadRequest(w http.ResponseWriter, cliCtx context.CLIContext, r *http.Request) (context.CLIContext, bool) {
+	heightStr := r.URL.Query().Get(queryArgHeight)
+	if len(heightStr) == 0 {
+		return cliCtx, true
+	}
+
+	height, ok := ParseInt64OrReturnBadRequest(w, heightStr)
+	if !ok {
+		return cliCtx, false
+	}
+	if height < 0 {
+		WriteErrorResponse(w, http.StatusBadRequest, "height must not be negative")
+		return cliCtx, false
+	}
+
+	return cliCtx.WithHeight(height), true
+}
+
 // ParseFloat64OrReturnBadRequest converts s to a float64 value. It returns a
 // default value, defaultIfEmpty, if the string is empty.
 func ParseFloat64OrReturnBadRequest(w http.ResponseWriter, s string, defaultIfEmpty float64) (n float64, ok bool) {
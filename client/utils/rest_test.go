@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+)
+
+func TestParseQueryHeightOrReturnBadRequest(t *testing.T) {
+	cliCtx := context.CLIContext{Height: 3}
+	tests := []struct {
+		url    string
+		ok     bool
+		height int64
+	}{
+		{"/stake/pool", true, 3},
+		{"/stake/pool?height=10", true, 10},
+		{"/stake/pool?height=0", true, 0},
+		{"/stake/pool?height=-1", false, 0},
+		{"/stake/pool?height=ten", false, 0},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", tc.url, nil)
+		ctx, ok := ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		assert.Equal(t, tc.ok, ok, tc.url)
+		if ok {
+			assert.Equal(t, tc.height, ctx.Height, tc.url)
+		} else {
+			assert.Equal(t, http.StatusBadRequest, w.Code, tc.url)
+		}
+	}
+}
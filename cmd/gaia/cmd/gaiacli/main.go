@@ -82,6 +82,8 @@ func main() {
 		stakecmd.GetCmdQueryValidators(storeStake, cdc),
 		govcmd.GetCmdQueryVote(storeGov, cdc),
 		govcmd.GetCmdQueryVotes(storeGov, cdc),
+		govcmd.GetCmdQueryTally(storeGov, cdc),
+		govcmd.GetCmdQueryTallyDetail(storeGov, cdc),
 	)...)
 
 	//Add query commands
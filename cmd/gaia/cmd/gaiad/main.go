@@ -35,6 +35,7 @@ func main() {
 	rootCmd.AddCommand(gaiaInit.GenTxCmd(ctx, cdc))
 
 	server.AddCommands(ctx, cdc, rootCmd, exportAppStateAndTMValidators)
+	rootCmd.AddCommand(server.SnapshotCmd(ctx, cdc, newApp))
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -46,8 +47,31 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
+	pruning, err := server.GetPruningOptionsFromFlags()
+	if err != nil {
+		panic(err)
+	}
+	streamingListener, err := server.GetStreamingListenerFromFlags(logger)
+	if err != nil {
+		panic(err)
+	}
+	blockTxCapacity, txLanes, err := server.GetTxLanesFromConfig()
+	if err != nil {
+		panic(err)
+	}
+	accountCacheMetrics := baseapp.NopAccountCacheMetrics()
+	if viper.GetBool("instrumentation.prometheus") {
+		accountCacheMetrics = baseapp.PrometheusAccountCacheMetrics()
+	}
 	return app.NewGaiaApp(logger, db, traceStore,
-		baseapp.SetPruning(viper.GetString("pruning")),
+		baseapp.SetPruning(pruning),
+		baseapp.SetParallelDeliverTx(viper.GetBool("parallel-deliver-tx")),
+		baseapp.SetStreamingListener(streamingListener),
+		baseapp.SetTxLanes(blockTxCapacity, txLanes...),
+		baseapp.SetAccountCacheMetrics(accountCacheMetrics),
+		baseapp.SetAccountCacheMaxCapacity(viper.GetInt("account-cache-max-capacity")),
+		baseapp.SetPrefetchMempoolAccounts(viper.GetBool("prefetch-mempool-accounts")),
+		baseapp.SetSnapshotOptions(server.GetSnapshotOptionsFromFlags()),
 	)
 }
 
@@ -11,7 +11,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/ibc"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 
@@ -50,7 +49,7 @@ func runHackCmd(cmd *cobra.Command, args []string) error {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	app := NewGaiaApp(logger, db, baseapp.SetPruning(viper.GetString("pruning")))
+	app := NewGaiaApp(logger, db, baseapp.SetPruning(sdk.PruneNothing))
 
 	// print some info
 	id := app.LastCommitID()
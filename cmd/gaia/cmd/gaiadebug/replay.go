@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/cli"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	gaia "github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagReplayTo      = "to"
+	flagReferenceHome = "reference-home"
+	flagMaxDiffs      = "max-diffs"
+	flagTraceStore    = "trace-store"
+
+	replayAccountCacheCap = 10000
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <home> <height>",
+	Short: "Replay the blocks of a node from a height to find the first diverging state",
+	Long: `Load the state of the node at height and execute again the following blocks of
+its block store. After every block, the hash of each store is compared with the
+one of the reference state, which is the state of --reference-home or else the
+state the node committed. The keys and values of the stores which differ are
+printed at the first mismatch. When the reference has no state at a height, the
+app hash is compared with the one of the next block.
+
+The node must be stopped, its data is only read.`,
+	RunE: runReplayCmd,
+}
+
+func init() {
+	replayCmd.Flags().Int64(flagReplayTo, 0, "Last height to replay, the last block of the block store by default")
+	replayCmd.Flags().String(flagReferenceHome, "", "Home of the node whose state is the reference, the replayed node by default")
+	replayCmd.Flags().Int(flagMaxDiffs, 100, "Max number of keys printed per diverging store, 0 for all")
+	replayCmd.Flags().String(flagTraceStore, "", "File the operations on the stores of the replayed blocks are traced to")
+	rootCmd.AddCommand(replayCmd)
+}
+
+func openDB(home, name string) (dbm.DB, error) {
+	conf := cfg.DefaultConfig().SetRoot(home)
+	if _, err := os.Stat(conf.DBDir()); err != nil {
+		return nil, err
+	}
+	return dbm.NewDB(name, dbm.DBBackendType(conf.DBBackend), conf.DBDir()), nil
+}
+
+func runReplayCmd(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Expected 2 args")
+	}
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+	home := args[0]
+	height, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return err
+	}
+	// the app reads the header of the loaded height from the block store of home
+	viper.Set(cli.HomeFlag, home)
+
+	appDB, err := openDB(home, "application")
+	if err != nil {
+		return err
+	}
+	defer appDB.Close()
+	refDB := appDB
+	if refHome := viper.GetString(flagReferenceHome); refHome != "" {
+		if refDB, err = openDB(refHome, "application"); err != nil {
+			return err
+		}
+		defer refDB.Close()
+	}
+
+	// the replayed blocks are committed in memory over the state of the node
+	db, err := store.NewMultiStoreOverlayDB(appDB, height)
+	if err != nil {
+		return err
+	}
+	logger := log.NewFilter(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), log.AllowError())
+	app := gaia.NewGaiaApp(logger, db, nil, baseapp.SetPruning(sdk.PruneNothing))
+	var mainKey, accountKey sdk.StoreKey
+	for key := range app.GetCommitMultiStore().GetCommitKVStores() {
+		switch key.Name() {
+		case "main":
+			mainKey = key
+		case "acc":
+			accountKey = key
+		}
+	}
+	if err = app.LoadVersion(height, mainKey); err != nil {
+		return err
+	}
+	// the account store cache of the app still reads the accounts from the
+	// store of the latest version
+	app.SetAccountStoreCache(gaia.MakeCodec(), app.GetCommitMultiStore().GetKVStore(accountKey), replayAccountCacheCap)
+	if traceStore := viper.GetString(flagTraceStore); traceStore != "" {
+		traceWriter, err := os.OpenFile(traceStore, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+		if err != nil {
+			return err
+		}
+		defer traceWriter.Close()
+		app.SetCommitMultiStoreTracer(traceWriter)
+	}
+
+	// the block store is opened once the app has loaded the height
+	blockDB, err := openDB(home, "blockstore")
+	if err != nil {
+		return err
+	}
+	defer blockDB.Close()
+	stateDB, err := openDB(home, "state")
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+	blockStore := tmstore.NewBlockStore(blockDB)
+	to := viper.GetInt64(flagReplayTo)
+	if to == 0 {
+		to = blockStore.Height()
+	}
+	if to <= height || to > blockStore.Height() {
+		return fmt.Errorf("the block store has the blocks up to %d, cannot replay from %d to %d", blockStore.Height(), height, to)
+	}
+
+	proxyApp := proxy.NewAppConnConsensus(abcicli.NewLocalClient(new(sync.Mutex), app))
+	for h := height + 1; h <= to; h++ {
+		appHash, err := sm.ExecCommitBlock(proxyApp, blockStore.LoadBlock(h), logger, stateDB)
+		if err != nil {
+			return err
+		}
+
+		refCInfo, err := store.GetCommitInfo(refDB, h)
+		if err != nil {
+			next := blockStore.LoadBlock(h + 1)
+			if next == nil {
+				fmt.Printf("height %d: app hash %X, no reference state nor next block to compare it with\n", h, appHash)
+				continue
+			}
+			if !bytes.Equal(appHash, next.AppHash) {
+				fmt.Printf("height %d: app hash %X differs from %X of block %d, no reference state to compare the stores with\n",
+					h, appHash, next.AppHash, h+1)
+				return nil
+			}
+			fmt.Printf("height %d: app hash %X\n", h, appHash)
+			continue
+		}
+		cInfo, err := store.GetCommitInfo(db, h)
+		if err != nil {
+			return err
+		}
+		diverging := divergingStores(cInfo, refCInfo)
+		if len(diverging) == 0 {
+			fmt.Printf("height %d: app hash %X\n", h, appHash)
+			continue
+		}
+
+		fmt.Printf("height %d: app hash %X differs from reference %X\n", h, appHash, refCInfo.Hash())
+		for _, name := range diverging {
+			printStoreDiffs(os.Stdout, db, refDB, name, h, viper.GetInt(flagMaxDiffs))
+		}
+		return nil
+	}
+	fmt.Printf("replayed blocks %d to %d, no diverging state\n", height+1, to)
+	return nil
+}
+
+// divergingStores returns the names of the stores whose hashes differ between
+// the CommitInfos, or which are only in one of them
+func divergingStores(cInfo, refCInfo store.CommitInfo) []string {
+	refHashes := make(map[string][]byte, len(refCInfo.StoreInfos))
+	for _, storeInfo := range refCInfo.StoreInfos {
+		refHashes[storeInfo.Name] = storeInfo.Hash()
+	}
+	var names []string
+	for _, storeInfo := range cInfo.StoreInfos {
+		refHash, ok := refHashes[storeInfo.Name]
+		if !ok || !bytes.Equal(storeInfo.Hash(), refHash) {
+			names = append(names, storeInfo.Name)
+		}
+		delete(refHashes, storeInfo.Name)
+	}
+	for name := range refHashes {
+		names = append(names, name)
+	}
+	return names
+}
+
+func printStoreDiffs(w io.Writer, db, refDB dbm.DB, name string, version int64, max int) {
+	fmt.Fprintf(w, "store %s:\n", name)
+	diffs, err := store.DiffMultiStoreVersion(db, refDB, name, version, max)
+	if err != nil {
+		fmt.Fprintf(w, "  %v\n", err)
+		return
+	}
+	for _, diff := range diffs {
+		fmt.Fprintf(w, "  key %X\n    replayed:  %X\n    reference: %X\n", diff.Key, diff.Value, diff.RefValue)
+	}
+	if max > 0 && len(diffs) == max {
+		fmt.Fprintf(w, "  ... only the first %d keys are printed\n", max)
+	}
+}
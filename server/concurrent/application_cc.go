@@ -7,3 +7,11 @@ type ApplicationCC interface {
 	PreCheckTx(req types.RequestCheckTx) types.ResponseCheckTx
 	PreDeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx
 }
+
+// ParallelDeliverer is implemented by an ApplicationCC that can deliver a
+// batch of consecutive txs at once, which the asyncLocalClient uses instead of
+// DeliverTx when ParallelDeliverTxEnabled returns true.
+type ParallelDeliverer interface {
+	ParallelDeliverTxEnabled() bool
+	DeliverTxs(reqs []types.RequestDeliverTx) []types.ResponseDeliverTx
+}
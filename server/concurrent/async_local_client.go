@@ -23,6 +23,9 @@ const (
 	WorkerPoolSize  = 16
 	WorkerPoolSpawn = 4
 	WorkerPoolQueue = 16
+
+	// DeliverTxBatchSize is the max number of queued txs delivered at once by a ParallelDeliverer
+	DeliverTxBatchSize = WorkerPoolQueue * 2
 )
 
 type WorkItem struct {
@@ -130,6 +133,11 @@ func (app *asyncLocalClient) checkTxWorker() {
 }
 
 func (app *asyncLocalClient) deliverTxWorker() {
+	if pd, ok := app.Application.(ParallelDeliverer); ok && pd.ParallelDeliverTxEnabled() {
+		app.deliverTxBatchWorker(pd)
+		return
+	}
+
 	for i := range app.deliverTxQueue {
 		i.mtx.Lock() // wait the PreDeliverTx finish
 		i.mtx.Unlock()
@@ -151,6 +159,58 @@ func (app *asyncLocalClient) deliverTxWorker() {
 	}
 }
 
+// deliverTxBatchWorker delivers the txs that are already queued together, the
+// responses are set and called back in the queue order.
+func (app *asyncLocalClient) deliverTxBatchWorker(pd ParallelDeliverer) {
+	for i := range app.deliverTxQueue {
+		batch := app.collectDeliverTxBatch(i)
+		for _, i := range batch {
+			i.mtx.Lock() // wait the PreDeliverTx finish
+			i.mtx.Unlock()
+		}
+		func() {
+			app.rwLock.Lock()         // make sure not other non-CheckTx/non-DeliverTx ABCI is called
+			defer app.rwLock.Unlock() // this unlock is put after wgCommit.Done() to give commit priority
+			reqs := make([]types.RequestDeliverTx, 0, len(batch))
+			for _, i := range batch {
+				if i.reqRes.Response == nil {
+					reqs = append(reqs, types.RequestDeliverTx{Tx: i.reqRes.Request.GetDeliverTx().GetTx()})
+				}
+			}
+			responses := pd.DeliverTxs(reqs)
+			for _, i := range batch {
+				if i.reqRes.Response == nil {
+					i.reqRes.Response = types.ToResponseDeliverTx(responses[0]) // Set response
+					responses = responses[1:]
+				}
+				i.reqRes.Done()
+				app.wgCommit.Done() // enable Commit to start
+				if cb := i.reqRes.GetCallback(); cb != nil {
+					cb(i.reqRes.Response)
+				}
+				app.Callback(i.reqRes.Request, i.reqRes.Response)
+			}
+		}()
+	}
+}
+
+// collectDeliverTxBatch takes the items queued after first without waiting for more
+func (app *asyncLocalClient) collectDeliverTxBatch(first WorkItem) []WorkItem {
+	batch := []WorkItem{first}
+	for len(batch) < DeliverTxBatchSize {
+		select {
+		case i, ok := <-app.deliverTxQueue:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, i)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
 // TODO: change types.Application to include Error()?
 func (app *asyncLocalClient) Error() error {
 	return nil
@@ -1,7 +1,63 @@
 package config
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultPruning           = sdk.PruningStrategySyncable
+	DefaultPruningKeepRecent = 100
+	DefaultPruningKeepEvery  = 0
+	DefaultPruningInterval   = 10
+)
+
 // BaseConfig defines the server's basic configuration
 type BaseConfig struct {
+	// Pruning strategy: syncable, nothing, everything or custom
+	Pruning string `mapstructure:"pruning"`
+
+	// Pruning options of the custom strategy
+	PruningKeepRecent int64 `mapstructure:"pruning-keep-recent"`
+	PruningKeepEvery  int64 `mapstructure:"pruning-keep-every"`
+	PruningInterval   int64 `mapstructure:"pruning-interval"`
+
+	// Sink of the state changes of the delivered blocks: file, socket or
+	// empty to disable streaming
+	Streaming string `mapstructure:"streaming"`
+
+	// Directory of the file sink, or path of the Unix socket of the socket sink
+	StreamingPath string `mapstructure:"streaming-path"`
+
+	// Max number of txs accepted by CheckTx between two blocks, 0 means no
+	// limit. The txs in no lane only use the space no lane reserves.
+	BlockTxCapacity int `mapstructure:"block-tx-capacity"`
+
+	// Priority lanes of the txs accepted by CheckTx
+	TxLanes []TxLaneConfig `mapstructure:"tx-lanes"`
+
+	// Max capacity the account store cache can grow to when it misses too
+	// many accounts, 0 means the capacity is fixed
+	AccountCacheMaxCapacity int `mapstructure:"account-cache-max-capacity"`
+
+	// Load the signers of the txs in the mempool into the account store cache
+	// before every block
+	PrefetchMempoolAccounts bool `mapstructure:"prefetch-mempool-accounts"`
+
+	// Compress the app state chunks of the snapshots taken after breathe blocks
+	SnapshotCompress bool `mapstructure:"snapshot-compress"`
+
+	// Only record the state changed since the previous snapshot in the
+	// snapshots taken after breathe blocks
+	SnapshotIncremental bool `mapstructure:"snapshot-incremental"`
+}
+
+// TxLaneConfig defines a lane of the txs accepted by CheckTx, see baseapp.TxLane
+type TxLaneConfig struct {
+	Name     string   `mapstructure:"name"`
+	MsgTypes []string `mapstructure:"msg-types"`
+	Signers  []string `mapstructure:"signers"` // bech32 account addresses
+	Priority int64    `mapstructure:"priority"`
+	Reserved int      `mapstructure:"reserved"`
 }
 
 // Config defines the server's top level configuration
@@ -10,7 +66,12 @@ type Config struct {
 }
 
 func DefaultConfig() *Config {
-	return &Config{BaseConfig{}}
+	return &Config{BaseConfig{
+		Pruning:           DefaultPruning,
+		PruningKeepRecent: DefaultPruningKeepRecent,
+		PruningKeepEvery:  DefaultPruningKeepEvery,
+		PruningInterval:   DefaultPruningInterval,
+	}}
 }
 
 // Storage for init gen-tx command input parameters
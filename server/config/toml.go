@@ -13,7 +13,66 @@ const defaultConfigTemplate = `# This is a TOML config file.
 
 ##### main base config options #####
 
-`
+# Pruning strategy of the application state: syncable, nothing, everything or custom
+pruning = "{{ .BaseConfig.Pruning }}"
+
+# These options are only used by the custom pruning strategy.
+# Number of recent states to keep
+pruning-keep-recent = {{ .BaseConfig.PruningKeepRecent }}
+# Keep every pruning-keep-every-th state forever, 0 keeps none of them
+pruning-keep-every = {{ .BaseConfig.PruningKeepEvery }}
+# Number of blocks between two prunings
+pruning-interval = {{ .BaseConfig.PruningInterval }}
+
+# Stream the state changes of every delivered block to indexers: file, socket
+# or empty to disable streaming
+streaming = "{{ .BaseConfig.Streaming }}"
+# Directory of the block files of the file sink, or path of the Unix socket
+# of the socket sink
+streaming-path = "{{ .BaseConfig.StreamingPath }}"
+
+# Max number of txs CheckTx accepts between two blocks, 0 means no limit. When
+# set, the txs of each lane can always use the space reserved by the lane, and
+# share the rest of the space with the txs of the other lanes. The txs in no
+# lane only use the space no lane reserves.
+block-tx-capacity = {{ .BaseConfig.BlockTxCapacity }}
+
+# Max capacity the account store cache can grow to when it misses too many
+# accounts, 0 means the capacity is fixed
+account-cache-max-capacity = {{ .BaseConfig.AccountCacheMaxCapacity }}
+
+# Load the signers of the txs in the mempool into the account store cache
+# before every block
+prefetch-mempool-accounts = {{ .BaseConfig.PrefetchMempoolAccounts }}
+
+# Compress the app state chunks of the snapshots taken after breathe blocks
+snapshot-compress = {{ .BaseConfig.SnapshotCompress }}
+# Only record the state changed since the previous snapshot in the snapshots
+# taken after breathe blocks. Such snapshots can only be restored by nodes
+# having the state at the height of the previous snapshot.
+snapshot-incremental = {{ .BaseConfig.SnapshotIncremental }}
+
+# Priority lanes of CheckTx. A tx belongs to the first lane all its msgs have a
+# type of, and are signed by the signers of if they are set, the other txs
+# belong to the default lane of priority 0. The priority returned in the Info
+# and the events of CheckTx orders the txs by lane priority first, then by fee.
+# A mempool reaping the txs in the order they were accepted ignores it, the
+# lanes then only control which txs are accepted. For example:
+#
+# [[tx-lanes]]
+# name = "oracle"
+# msg-types = ["oracleClaim"]
+# signers = ["cosmos1..."]
+# priority = 10
+# reserved = 100
+{{ range .BaseConfig.TxLanes }}
+[[tx-lanes]]
+name = "{{ .Name }}"
+msg-types = [{{ range $i, $t := .MsgTypes }}{{ if $i }}, {{ end }}"{{ $t }}"{{ end }}]
+signers = [{{ range $i, $s := .Signers }}{{ if $i }}, {{ end }}"{{ $s }}"{{ end }}]
+priority = {{ .Priority }}
+reserved = {{ .Reserved }}
+{{ end }}`
 
 var configTemplate *template.Template
 
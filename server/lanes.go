@@ -0,0 +1,44 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/server/config"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// node config key of the tx lanes
+const configTxLanes = "tx-lanes"
+
+// GetTxLanesFromConfig returns the block tx capacity and the tx lanes of
+// CheckTx set in the node config.
+func GetTxLanesFromConfig() (int, []baseapp.TxLane, error) {
+	var laneConfigs []config.TxLaneConfig
+	if err := viper.UnmarshalKey(configTxLanes, &laneConfigs); err != nil {
+		return 0, nil, err
+	}
+
+	lanes := make([]baseapp.TxLane, 0, len(laneConfigs))
+	for _, lc := range laneConfigs {
+		lane := baseapp.TxLane{
+			Name:     lc.Name,
+			MsgTypes: lc.MsgTypes,
+			Priority: lc.Priority,
+			Reserved: lc.Reserved,
+		}
+		for _, bech32Addr := range lc.Signers {
+			addr, err := sdk.AccAddressFromBech32(bech32Addr)
+			if err != nil {
+				return 0, nil, fmt.Errorf("invalid signer of tx lane %s: %v", lc.Name, err)
+			}
+			lane.Signers = append(lane.Signers, addr)
+		}
+		lanes = append(lanes, lane)
+	}
+
+	capacity := viper.GetInt(flagBlockTxCapacity)
+	return capacity, lanes, baseapp.ValidateTxLanes(capacity, lanes)
+}
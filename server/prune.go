@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func addPruningFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagPruning, config.DefaultPruning, "Pruning strategy: syncable, nothing, everything, custom")
+	cmd.Flags().Int64(flagPruningKeepRecent, config.DefaultPruningKeepRecent, "Number of recent states to keep (custom pruning only)")
+	cmd.Flags().Int64(flagPruningKeepEvery, config.DefaultPruningKeepEvery, "Keep every n-th state forever, 0 keeps none of them (custom pruning only)")
+	cmd.Flags().Int64(flagPruningInterval, config.DefaultPruningInterval, "Number of blocks between two prunings (custom pruning only)")
+}
+
+// GetPruningOptionsFromFlags returns the pruning options set by the pruning
+// flags, or by the node config if they are not given.
+func GetPruningOptionsFromFlags() (sdk.PruningOptions, error) {
+	strategy := viper.GetString(flagPruning)
+	if strategy != sdk.PruningStrategyCustom {
+		return sdk.NewPruningOptionsFromString(strategy)
+	}
+
+	opts := sdk.NewPruningOptions(
+		viper.GetInt64(flagPruningKeepRecent),
+		viper.GetInt64(flagPruningKeepEvery),
+		viper.GetInt64(flagPruningInterval),
+	)
+	return opts, opts.Validate()
+}
+
+// PruneCmd deletes the historical states the pruning options do not keep from
+// the application database of a stopped node, and compacts the database.
+func PruneCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Prune the application state of a stopped node to the pruning options",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := GetPruningOptionsFromFlags()
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(viper.GetString("home"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			ctx.Logger.Info("Pruning application state", "pruning", opts.String())
+			deleted, err := store.PruneMultiStore(db, opts)
+			if err != nil {
+				return fmt.Errorf("failed to prune application state: %v", err)
+			}
+			ctx.Logger.Info("Pruned application state", "deleted_versions", deleted)
+
+			if levelDB, ok := db.(*dbm.GoLevelDB); ok {
+				ctx.Logger.Info("Compacting application database")
+				if err := levelDB.DB().CompactRange(util.Range{}); err != nil {
+					return fmt.Errorf("failed to compact application database: %v", err)
+				}
+			}
+			return nil
+		},
+	}
+
+	addPruningFlags(cmd)
+	return cmd
+}
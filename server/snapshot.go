@@ -0,0 +1,449 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/snapshot"
+	sm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagSnapshotHeight   = "height"
+	flagSnapshotOutput   = "output"
+	flagSnapshotCompress = "compress"
+
+	// snapshotArchiveManifest is the name of the manifest in a snapshot archive,
+	// the chunks are named after the hex encoded hash of their content
+	snapshotArchiveManifest = "MANIFEST"
+)
+
+// snapshotApp is an application whose committed state can be snapshotted
+type snapshotApp interface {
+	GetCommitMultiStore() sdk.CommitMultiStore
+}
+
+// snapshotArchive is a snapshot in the state sync format: the snappy compressed
+// manifest and chunks as they are stored by the snapshot manager
+type snapshotArchive struct {
+	manifest []byte
+	chunks   map[abci.SHA256Sum][]byte
+}
+
+// SnapshotCmd exports the state of a stopped node at a height to a portable
+// snapshot archive, and restores a node from such an archive without peers.
+func SnapshotCmd(ctx *Context, cdc *codec.Codec, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export and import state sync snapshots of a stopped node",
+	}
+	cmd.AddCommand(
+		snapshotExportCmd(ctx, cdc, appCreator),
+		snapshotImportCmd(ctx, cdc, appCreator),
+	)
+	return cmd
+}
+
+func snapshotExportCmd(ctx *Context, cdc *codec.Codec, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the snapshot of the state at a height to an archive, taking the snapshot if the node has none",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output := viper.GetString(flagSnapshotOutput)
+			if output == "" {
+				return errors.New("the archive to write must be given with --output")
+			}
+
+			db, err := openDB(viper.GetString("home"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			stateDB, txDB, blockStore := openTendermintDBs(ctx)
+			snapshot.InitSnapshotManager(stateDB, txDB, blockStore, ctx.Config.DBDir(), ctx.Logger)
+
+			app, ok := appCreator(ctx.Logger, db, nil).(snapshotApp)
+			if !ok {
+				return errors.New("the application does not support snapshots")
+			}
+			cms := app.GetCommitMultiStore()
+
+			height := viper.GetInt64(flagSnapshotHeight)
+			if height == 0 {
+				height = cms.LastCommitID().Version
+			}
+			if height <= 0 || height > cms.LastCommitID().Version {
+				return fmt.Errorf("no committed state at height %d, the latest height is %d", height, cms.LastCommitID().Version)
+			}
+
+			if !snapshot.ManagerAt(height).IsFinalized() {
+				ctx.Logger.Info("Taking snapshot", "height", height)
+				sdk.UpgradeMgr.SetHeight(height)
+				helper := store.NewStateSyncHelper(ctx.Logger, db, cms, cdc)
+				helper.SetSnapshotOptions(store.SnapshotOptions{Compress: viper.GetBool(flagSnapshotCompress)})
+				helper.ReloadSnapshotRoutine(height, 0)
+				if !snapshot.ManagerAt(height).IsFinalized() {
+					return fmt.Errorf("failed to take the snapshot at height %d", height)
+				}
+			}
+
+			archive, err := loadSnapshotArchive(abci.SnapshotReader{Height: height, DbDir: ctx.Config.DBDir()})
+			if err != nil {
+				return err
+			}
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			if err := archive.write(file); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+			ctx.Logger.Info("Exported snapshot", "height", height, "chunks", len(archive.chunks), "output", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagSnapshotHeight, 0, "Height of the snapshot, the latest committed height by default")
+	cmd.Flags().String(flagSnapshotOutput, "", "Archive to write")
+	cmd.Flags().Bool(flagSnapshotCompress, false, "Compress the app state chunks if the snapshot needs to be taken")
+	return cmd
+}
+
+func snapshotImportCmd(ctx *Context, cdc *codec.Codec, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [archive]",
+		Short: "Restore the state of a node with no state from a snapshot archive",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			archive, err := readSnapshotArchive(file)
+			file.Close()
+			if err != nil {
+				return err
+			}
+			manifest, err := archive.verify()
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(viper.GetString("home"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			stateDB, txDB, blockStore := openTendermintDBs(ctx)
+			if height := blockStore.Height(); height != 0 {
+				return fmt.Errorf("the node already has blocks up to height %d", height)
+			}
+
+			app, ok := appCreator(ctx.Logger, db, nil).(snapshotApp)
+			if !ok {
+				return errors.New("the application does not support snapshots")
+			}
+			cms := app.GetCommitMultiStore()
+			if version := cms.LastCommitID().Version; version != 0 {
+				return fmt.Errorf("the node already has application state at height %d", version)
+			}
+
+			snapshot.InitSnapshotManager(stateDB, txDB, blockStore, ctx.Config.DBDir(), ctx.Logger)
+			mgr := snapshot.Manager()
+			if err := mgr.WriteManifest(sha256.Sum256(archive.manifest), manifest); err != nil {
+				return err
+			}
+			for hash, chunk := range archive.chunks {
+				if err := mgr.Writer.Write(hash, chunk); err != nil {
+					return err
+				}
+			}
+
+			ctx.Logger.Info("Importing snapshot", "height", manifest.Height, "chunks", len(archive.chunks))
+			helper := store.NewStateSyncHelper(ctx.Logger, db, cms, cdc)
+			if err := archive.restore(manifest, helper, mgr); err != nil {
+				return err
+			}
+			if err := mgr.Finalize(); err != nil {
+				return err
+			}
+			ctx.Logger.Info("Imported snapshot", "height", manifest.Height)
+			return nil
+		},
+	}
+}
+
+// GetSnapshotOptionsFromFlags returns the options of the snapshots the node
+// takes after breathe blocks, see baseapp.SetSnapshotOptions
+func GetSnapshotOptionsFromFlags() store.SnapshotOptions {
+	return store.SnapshotOptions{
+		Compress:    viper.GetBool(flagSnapshotCompressed),
+		Incremental: viper.GetBool(flagSnapshotIncremental),
+	}
+}
+
+func openTendermintDBs(ctx *Context) (stateDB, txDB dbm.DB, blockStore *tmstore.BlockStore) {
+	backend := dbm.DBBackendType(ctx.Config.DBBackend)
+	dir := ctx.Config.DBDir()
+	stateDB = dbm.NewDB("state", backend, dir)
+	txDB = dbm.NewDB("tx_index", backend, dir)
+	blockStore = tmstore.NewBlockStore(dbm.NewDB("blockstore", backend, dir))
+	return stateDB, txDB, blockStore
+}
+
+// snapshotCodec decodes the manifest and chunks the way the state sync reactor
+// does
+func snapshotCodec() *codec.Codec {
+	cdc := codec.New()
+	snapshot.RegisterSnapshotMessages(cdc)
+	tmtypes.RegisterBlockAmino(cdc)
+	return cdc
+}
+
+func decodeSnapshotManifest(compressed []byte) (*abci.Manifest, error) {
+	decompressed, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshot manifest: %v", err)
+	}
+	var manifest abci.Manifest
+	if err := snapshotCodec().UnmarshalBinaryBare(decompressed, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot manifest: %v", err)
+	}
+	if manifest.Version != abci.ManifestVersion {
+		return nil, fmt.Errorf("snapshot manifest version mismatch, expected: %d, actual: %d", abci.ManifestVersion, manifest.Version)
+	}
+	return &manifest, nil
+}
+
+func decodeSnapshotChunk(cdc *codec.Codec, hash abci.SHA256Sum, compressed []byte) (abci.SnapshotChunk, error) {
+	decompressed, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress chunk %X: %v", hash, err)
+	}
+	var chunk abci.SnapshotChunk
+	if err := cdc.UnmarshalBinaryBare(decompressed, &chunk); err != nil {
+		return nil, fmt.Errorf("failed to decode chunk %X: %v", hash, err)
+	}
+	return chunk, nil
+}
+
+// manifestHashes returns the hashes of all the chunks of a manifest
+func manifestHashes(manifest *abci.Manifest) []abci.SHA256Sum {
+	hashes := make([]abci.SHA256Sum, 0, len(manifest.StateHashes)+len(manifest.AppStateHashes)+len(manifest.BlockHashes))
+	hashes = append(hashes, manifest.StateHashes...)
+	hashes = append(hashes, manifest.AppStateHashes...)
+	return append(hashes, manifest.BlockHashes...)
+}
+
+// loadSnapshotArchive loads the finalized snapshot of the reader height
+func loadSnapshotArchive(reader abci.SnapshotReader) (*snapshotArchive, error) {
+	_, manifestBytes, err := reader.LoadManifest(reader.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshot manifest at height %d: %v", reader.Height, err)
+	}
+	manifest, err := decodeSnapshotManifest(manifestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &snapshotArchive{manifest: manifestBytes, chunks: make(map[abci.SHA256Sum][]byte)}
+	for _, hash := range manifestHashes(manifest) {
+		chunk, err := reader.Load(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load chunk %X of snapshot at height %d: %v", hash, reader.Height, err)
+		}
+		archive.chunks[hash] = chunk
+	}
+	if _, err := archive.verify(); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// write writes the archive as a tar stream, the manifest first and then the
+// chunks ordered by hash
+func (archive *snapshotArchive) write(w io.Writer) error {
+	tw := tar.NewWriter(w)
+	writeFile := func(name string, content []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	if err := writeFile(snapshotArchiveManifest, archive.manifest); err != nil {
+		return err
+	}
+	hashes := make([]abci.SHA256Sum, 0, len(archive.chunks))
+	for hash := range archive.chunks {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+	for _, hash := range hashes {
+		if err := writeFile(hex.EncodeToString(hash[:]), archive.chunks[hash]); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// readSnapshotArchive reads an archive written by write
+func readSnapshotArchive(r io.Reader) (*snapshotArchive, error) {
+	archive := &snapshotArchive{chunks: make(map[abci.SHA256Sum][]byte)}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot archive: %v", err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from snapshot archive: %v", header.Name, err)
+		}
+
+		if header.Name == snapshotArchiveManifest {
+			archive.manifest = content
+			continue
+		}
+		var hash abci.SHA256Sum
+		if decoded, err := hex.DecodeString(header.Name); err != nil || len(decoded) != len(hash) {
+			return nil, fmt.Errorf("unexpected file %s in snapshot archive", header.Name)
+		} else {
+			copy(hash[:], decoded)
+		}
+		archive.chunks[hash] = content
+	}
+
+	if archive.manifest == nil {
+		return nil, errors.New("snapshot archive has no manifest")
+	}
+	return archive, nil
+}
+
+// verify checks that the archive holds exactly the chunks of its manifest and
+// that their content matches their hash
+func (archive *snapshotArchive) verify() (*abci.Manifest, error) {
+	manifest, err := decodeSnapshotManifest(archive.manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := manifestHashes(manifest)
+	for _, hash := range hashes {
+		chunk, ok := archive.chunks[hash]
+		if !ok {
+			return nil, fmt.Errorf("chunk %X of the manifest is missing", hash)
+		}
+		if sha256.Sum256(chunk) != hash {
+			return nil, fmt.Errorf("content of chunk %X does not match its hash", hash)
+		}
+	}
+	if len(archive.chunks) != len(hashes) {
+		return nil, fmt.Errorf("snapshot has %d chunks, but the manifest has %d", len(archive.chunks), len(hashes))
+	}
+	return manifest, nil
+}
+
+// restoreBlock saves the block of a snapshot into the block store of the manager. It is what
+// SnapshotManager.restoreBlock does for the state sync reactor, which tendermint does not export.
+func restoreBlock(mgr *snapshot.SnapshotManager, block *tmtypes.Block, seenCommit *tmtypes.Commit) {
+	blockStore := mgr.GetBlockStore()
+	blockStore.SetHeight(block.Height - 1)
+	blockStore.SaveBlock(block, block.MakePartSet(tmtypes.BlockPartSizeBytes), seenCommit)
+}
+
+// restore writes the tendermint state, the block and the app state of the
+// snapshot through the snapshot manager the way the state sync reactor does
+func (archive *snapshotArchive) restore(manifest *abci.Manifest, helper *store.StateSyncHelper, mgr *snapshot.SnapshotManager) error {
+	snapshotCdc := snapshotCodec()
+
+	for _, hash := range manifest.StateHashes {
+		chunk, err := decodeSnapshotChunk(snapshotCdc, hash, archive.chunks[hash])
+		if err != nil {
+			return err
+		}
+		stateChunk, ok := chunk.(*abci.StateChunk)
+		if !ok {
+			return fmt.Errorf("chunk %X is not a state chunk", hash)
+		}
+		var state sm.State
+		if err := snapshotCdc.UnmarshalBinaryBare(stateChunk.Statepart, &state); err != nil {
+			return fmt.Errorf("failed to decode the state of chunk %X: %v", hash, err)
+		}
+		sm.SaveState(mgr.GetStateDB(), state)
+	}
+
+	for _, hash := range manifest.BlockHashes {
+		chunk, err := decodeSnapshotChunk(snapshotCdc, hash, archive.chunks[hash])
+		if err != nil {
+			return err
+		}
+		blockChunk, ok := chunk.(*abci.BlockChunk)
+		if !ok {
+			return fmt.Errorf("chunk %X is not a block chunk", hash)
+		}
+		var block tmtypes.Block
+		var seenCommit tmtypes.Commit
+		if err := snapshotCdc.UnmarshalBinaryBare(blockChunk.Block, &block); err != nil {
+			return fmt.Errorf("failed to decode the block of chunk %X: %v", hash, err)
+		}
+		if err := snapshotCdc.UnmarshalBinaryBare(blockChunk.SeenCommit, &seenCommit); err != nil {
+			return fmt.Errorf("failed to decode the seen commit of chunk %X: %v", hash, err)
+		}
+		if block.Height != manifest.Height {
+			return fmt.Errorf("block %d of chunk %X is not at the snapshot height %d", block.Height, hash, manifest.Height)
+		}
+		restoreBlock(mgr, &block, &seenCommit)
+	}
+
+	if err := helper.StartRecovery(manifest); err != nil {
+		return err
+	}
+	for idx, hash := range manifest.AppStateHashes {
+		chunk, err := decodeSnapshotChunk(snapshotCdc, hash, archive.chunks[hash])
+		if err != nil {
+			return err
+		}
+		appStateChunk, ok := chunk.(*abci.AppStateChunk)
+		if !ok {
+			return fmt.Errorf("chunk %X is not an app state chunk", hash)
+		}
+		if err := helper.WriteRecoveryChunk(hash, appStateChunk, idx == len(manifest.AppStateHashes)-1); err != nil {
+			return err
+		}
+	}
+	if len(manifest.AppStateHashes) == 0 {
+		return helper.WriteRecoveryChunk(abci.SHA256Sum{}, nil, true)
+	}
+	return nil
+}
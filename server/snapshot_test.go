@@ -0,0 +1,77 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func newTestSnapshotArchive(t *testing.T) *snapshotArchive {
+	cdc := snapshotCodec()
+	archive := &snapshotArchive{chunks: make(map[abci.SHA256Sum][]byte)}
+	manifest := &abci.Manifest{Version: abci.ManifestVersion, Height: 10, NumKeys: []int64{1, 1}}
+	for i := 0; i < 2; i++ {
+		var chunk abci.SnapshotChunk = &abci.AppStateChunk{StartIdx: int64(i), Nodes: [][]byte{{byte(i)}}}
+		compressed := snappy.Encode(nil, cdc.MustMarshalBinaryBare(chunk))
+		hash := sha256.Sum256(compressed)
+		archive.chunks[hash] = compressed
+		manifest.AppStateHashes = append(manifest.AppStateHashes, hash)
+	}
+	archive.manifest = snappy.Encode(nil, cdc.MustMarshalBinaryBare(manifest))
+	return archive
+}
+
+func TestSnapshotArchive(t *testing.T) {
+	archive := newTestSnapshotArchive(t)
+
+	var buf bytes.Buffer
+	require.Nil(t, archive.write(&buf))
+	read, err := readSnapshotArchive(&buf)
+	require.Nil(t, err)
+	require.Equal(t, archive, read)
+
+	manifest, err := read.verify()
+	require.Nil(t, err)
+	require.Equal(t, int64(10), manifest.Height)
+	require.Len(t, manifest.AppStateHashes, 2)
+
+	chunk, err := decodeSnapshotChunk(snapshotCodec(), manifest.AppStateHashes[1], read.chunks[manifest.AppStateHashes[1]])
+	require.Nil(t, err)
+	require.Equal(t, &abci.AppStateChunk{StartIdx: 1, Nodes: [][]byte{{1}}}, chunk)
+
+	_, err = readSnapshotArchive(&bytes.Buffer{})
+	require.NotNil(t, err)
+}
+
+func TestSnapshotArchiveVerify(t *testing.T) {
+	archive := newTestSnapshotArchive(t)
+	manifest, err := archive.verify()
+	require.Nil(t, err)
+	hash := manifest.AppStateHashes[0]
+
+	// corrupted chunk
+	chunk := archive.chunks[hash]
+	archive.chunks[hash] = append([]byte{0}, chunk...)
+	_, err = archive.verify()
+	require.Contains(t, err.Error(), "does not match its hash")
+
+	// missing chunk
+	delete(archive.chunks, hash)
+	_, err = archive.verify()
+	require.Contains(t, err.Error(), "is missing")
+
+	// chunk not in the manifest
+	archive.chunks[hash] = chunk
+	archive.chunks[sha256.Sum256(nil)] = nil
+	_, err = archive.verify()
+	require.Contains(t, err.Error(), "snapshot has 3 chunks, but the manifest has 2")
+
+	archive.manifest = []byte("not a manifest")
+	_, err = archive.verify()
+	require.NotNil(t, err)
+}
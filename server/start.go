@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/server/concurrent"
 
 	"github.com/tendermint/tendermint/abci/server"
@@ -18,15 +19,32 @@ import (
 )
 
 const (
-	flagWithTendermint = "with-tendermint"
-	flagAddress        = "address"
-	flagTraceStore     = "trace-store"
-	flagPruning        = "pruning"
-	flagSequentialABCI = "seq-abci"
+	flagWithTendermint      = "with-tendermint"
+	flagAddress             = "address"
+	flagTraceStore          = "trace-store"
+	flagPruning             = "pruning"
+	flagPruningKeepRecent   = "pruning-keep-recent"
+	flagPruningKeepEvery    = "pruning-keep-every"
+	flagPruningInterval     = "pruning-interval"
+	flagSequentialABCI      = "seq-abci"
+	flagParallelDeliverTx   = "parallel-deliver-tx"
+	flagStreaming           = "streaming"
+	flagStreamingPath       = "streaming-path"
+	flagBlockTxCapacity     = "block-tx-capacity"
+	flagAccountCacheMax     = "account-cache-max-capacity"
+	flagPrefetchAccounts    = "prefetch-mempool-accounts"
+	flagSnapshotCompressed  = "snapshot-compress"
+	flagSnapshotIncremental = "snapshot-incremental"
 )
 
 var BlockStore *tmstore.BlockStore
 
+// blockStoreApp is an application loading the headers of the past blocks from
+// the block store of the node
+type blockStoreApp interface {
+	SetBlockStore(blockStore baseapp.BlockMetaStore)
+}
+
 // StartCmd runs the service passed in, either stand-alone or in-process with
 // Tendermint.
 func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
@@ -51,7 +69,15 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
 	cmd.Flags().Bool(flagSequentialABCI, false, "Run abci app in sync mode")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	addPruningFlags(cmd)
+	cmd.Flags().Bool(flagParallelDeliverTx, false, "Deliver the txs of a block in parallel with conflict detection (requires async abci)")
+	cmd.Flags().String(flagStreaming, "", "Stream the state changes of the delivered blocks to a sink: file, socket")
+	cmd.Flags().String(flagStreamingPath, "", "Directory of the file sink, or path of the Unix socket of the socket sink")
+	cmd.Flags().Int(flagBlockTxCapacity, 0, "Max number of txs CheckTx accepts between two blocks, 0 means no limit")
+	cmd.Flags().Int(flagAccountCacheMax, 0, "Max capacity the account store cache can grow to, 0 means the capacity is fixed")
+	cmd.Flags().Bool(flagPrefetchAccounts, false, "Load the signers of the mempool txs into the account store cache before every block")
+	cmd.Flags().Bool(flagSnapshotCompressed, false, "Compress the app state chunks of the snapshots taken after breathe blocks")
+	cmd.Flags().Bool(flagSnapshotIncremental, false, "Only record the state changed since the previous snapshot in the snapshots taken after breathe blocks")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -145,6 +171,9 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	}
 
 	BlockStore = tmNode.BlockStore()
+	if bsApp, ok := app.(blockStoreApp); ok {
+		bsApp.SetBlockStore(BlockStore)
+	}
 
 	err = tmNode.Start()
 	if err != nil {
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+// GetStreamingListenerFromFlags returns the built-in streaming listener set by
+// the streaming flags, or by the node config if they are not given. It returns
+// nil if streaming is disabled.
+func GetStreamingListenerFromFlags(logger log.Logger) (baseapp.StreamingListener, error) {
+	sink := viper.GetString(flagStreaming)
+	if sink == "" {
+		return nil, nil
+	}
+	path := viper.GetString(flagStreamingPath)
+	if path == "" {
+		return nil, fmt.Errorf("%s is required by the %s streaming sink", flagStreamingPath, sink)
+	}
+	return baseapp.NewStreamingListener(sink, path, logger.With("module", "streaming"))
+}
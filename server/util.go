@@ -102,12 +102,12 @@ func interceptLoadConfig() (conf *cfg.Config, err error) {
 	}
 
 	cosmosConfigFilePath := filepath.Join(rootDir, "config/gaiad.toml")
-	viper.SetConfigName("cosmos")
+	viper.SetConfigName("gaiad")
 	_ = viper.MergeInConfig()
 	var cosmosConf *config.Config
 	if _, err := os.Stat(cosmosConfigFilePath); os.IsNotExist(err) {
-		cosmosConf, _ := config.ParseConfig()
-		config.WriteConfigFile(cosmosConfigFilePath, cosmosConf)
+		// write the defaults, not the flags of the command that happens to run first
+		config.WriteConfigFile(cosmosConfigFilePath, config.DefaultConfig())
 	}
 
 	if cosmosConf == nil {
@@ -148,6 +148,7 @@ func AddCommands(
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		PruneCmd(ctx),
 		client.LineBreak,
 		version.VersionCmd,
 	)
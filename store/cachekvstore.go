@@ -145,6 +145,10 @@ func (ci *cacheKVStore) ReverseIterator(start, end []byte) Iterator {
 }
 
 func (ci *cacheKVStore) iterator(start, end []byte, ascending bool) Iterator {
+	// the cache may be read concurrently by transactions that are delivered in parallel
+	ci.mtx.Lock()
+	defer ci.mtx.Unlock()
+
 	var parent, cache Iterator
 
 	if ascending {
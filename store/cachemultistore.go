@@ -3,6 +3,8 @@ package store
 import (
 	"io"
 
+	dbm "github.com/tendermint/tendermint/libs/db"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -18,20 +20,34 @@ type cacheMultiStore struct {
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	listener WriteListener // see NewListenCacheMultiStore
+
+	gasMeter  sdk.GasMeter // see NewGasCacheMultiStore
+	gasConfig sdk.GasConfig
 }
 
 var _ CacheMultiStore = cacheMultiStore{}
 
 func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
+	stores := make(map[StoreKey]CacheWrapper, len(rms.stores))
+	for key, store := range rms.stores {
+		stores[key] = store
+	}
+	return newCacheMultiStore(rms.db, stores, rms.keysByName, rms.traceWriter, rms.traceContext)
+}
+
+func newCacheMultiStore(db dbm.DB, stores map[StoreKey]CacheWrapper, keysByName map[string]StoreKey,
+	traceWriter io.Writer, traceContext TraceContext) cacheMultiStore {
 	cms := cacheMultiStore{
-		db:           NewCacheKVStore(dbStoreAdapter{rms.db}),
-		stores:       make(map[StoreKey]CacheWrap, len(rms.stores)),
-		keysByName:   rms.keysByName,
-		traceWriter:  rms.traceWriter,
-		traceContext: rms.traceContext,
+		db:           NewCacheKVStore(dbStoreAdapter{db}),
+		stores:       make(map[StoreKey]CacheWrap, len(stores)),
+		keysByName:   keysByName,
+		traceWriter:  traceWriter,
+		traceContext: traceContext,
 	}
 
-	for key, store := range rms.stores {
+	for key, store := range stores {
 		if cms.TracingEnabled() {
 			cms.stores[key] = store.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
 		} else {
@@ -50,7 +66,8 @@ func newCacheMultiStoreFromCMS(cms cacheMultiStore) cacheMultiStore {
 		traceContext: cms.traceContext,
 	}
 
-	for key, store := range cms.stores {
+	for key := range cms.stores {
+		store := cms.GetKVStore(key)
 		if cms2.TracingEnabled() {
 			cms2.stores[key] = store.CacheWrapWithTrace(cms2.traceWriter, cms2.traceContext)
 		} else {
@@ -125,10 +142,17 @@ func (cms cacheMultiStore) CacheMultiStore() CacheMultiStore {
 
 // Implements MultiStore.
 func (cms cacheMultiStore) GetStore(key StoreKey) Store {
-	return cms.stores[key].(Store)
+	return cms.GetKVStore(key)
 }
 
 // Implements MultiStore.
 func (cms cacheMultiStore) GetKVStore(key StoreKey) KVStore {
-	return cms.stores[key].(KVStore)
+	store := cms.stores[key].(KVStore)
+	if cms.listener != nil {
+		store = listenKVStore{store, key, cms.listener}
+	}
+	if cms.gasMeter != nil {
+		store = gasKVStore{store, cms.gasMeter, cms.gasConfig}
+	}
+	return store
 }
@@ -7,7 +7,7 @@ import (
 // Import cosmos-sdk/types/store.go for convenience.
 // nolint
 type (
-	PruningStrategy  = types.PruningStrategy
+	PruningOptions   = types.PruningOptions
 	Store            = types.Store
 	Committer        = types.Committer
 	CommitStore      = types.CommitStore
@@ -27,4 +27,5 @@ type (
 	StoreType        = types.StoreType
 	Queryable        = types.Queryable
 	TraceContext     = types.TraceContext
+	WriteListener    = types.WriteListener
 )
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KVPairDiff is a key whose value differs between two stores, a nil value
+// meaning the key is not in the store.
+type KVPairDiff struct {
+	Key      []byte
+	Value    []byte
+	RefValue []byte
+}
+
+// GetCommitInfo returns the CommitInfo of a version of the multistore
+// persisted in db.
+func GetCommitInfo(db dbm.DB, ver int64) (CommitInfo, error) {
+	return getCommitInfo(db, ver)
+}
+
+// DiffMultiStoreVersion returns the keys whose values differ between the IAVL
+// store named name of the multistores persisted in db and refDB, at the given
+// version. The keys are in ascending order, at most max of them are returned
+// if max is positive.
+func DiffMultiStoreVersion(db, refDB dbm.DB, name string, version int64, max int) ([]KVPairDiff, error) {
+	store, err := loadMultiStoreIAVLVersion(db, name, version)
+	if err != nil {
+		return nil, err
+	}
+	refStore, err := loadMultiStoreIAVLVersion(refDB, name, version)
+	if err != nil {
+		return nil, err
+	}
+	return diffKVStores(store, refStore, max), nil
+}
+
+func loadMultiStoreIAVLVersion(db dbm.DB, name string, version int64) (KVStore, error) {
+	store, err := LoadIAVLStore(dbm.NewPrefixDB(db, []byte("s/k:"+name+"/")), CommitID{Version: version}, sdk.PruneNothing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version %d of store %s: %v", version, name, err)
+	}
+	return store.(KVStore), nil
+}
+
+// diffKVStores iterates both stores in ascending order to find the keys whose
+// values differ
+func diffKVStores(store, refStore KVStore, max int) []KVPairDiff {
+	iter, refIter := store.Iterator(nil, nil), refStore.Iterator(nil, nil)
+	defer iter.Close()
+	defer refIter.Close()
+
+	var diffs []KVPairDiff
+	for (iter.Valid() || refIter.Valid()) && (max <= 0 || len(diffs) < max) {
+		var cmp int
+		switch {
+		case !refIter.Valid():
+			cmp = -1
+		case !iter.Valid():
+			cmp = 1
+		default:
+			cmp = bytes.Compare(iter.Key(), refIter.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			diffs = append(diffs, KVPairDiff{Key: iter.Key(), Value: iter.Value()})
+			iter.Next()
+		case cmp > 0:
+			diffs = append(diffs, KVPairDiff{Key: refIter.Key(), RefValue: refIter.Value()})
+			refIter.Next()
+		default:
+			if !bytes.Equal(iter.Value(), refIter.Value()) {
+				diffs = append(diffs, KVPairDiff{Key: iter.Key(), Value: iter.Value(), RefValue: refIter.Value()})
+			}
+			iter.Next()
+			refIter.Next()
+		}
+	}
+	return diffs
+}
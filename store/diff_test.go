@@ -0,0 +1,47 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestDiffMultiStoreVersion(t *testing.T) {
+	db, refDB := dbm.NewMemDB(), dbm.NewMemDB()
+	for i, d := range []dbm.DB{db, refDB} {
+		store := newMultiStoreWithMounts(d)
+		require.Nil(t, store.LoadLatestVersion())
+		kv := store.GetKVStore(store.keysByName["store1"])
+		kv.Set([]byte("a"), []byte("1"))
+		kv.Set([]byte("b"), []byte{byte(i)})
+		kv.Set([]byte{'c', byte(i)}, []byte("3"))
+		store.GetKVStore(store.keysByName["store2"]).Set([]byte("a"), []byte("1"))
+		store.Commit()
+	}
+
+	cInfo, err := GetCommitInfo(db, 1)
+	require.Nil(t, err)
+	refCInfo, err := GetCommitInfo(refDB, 1)
+	require.Nil(t, err)
+	require.NotEqual(t, refCInfo.Hash(), cInfo.Hash())
+
+	diffs, err := DiffMultiStoreVersion(db, refDB, "store1", 1, 0)
+	require.Nil(t, err)
+	require.Equal(t, []KVPairDiff{
+		{Key: []byte("b"), Value: []byte{0}, RefValue: []byte{1}},
+		{Key: []byte{'c', 0}, Value: []byte("3")},
+		{Key: []byte{'c', 1}, RefValue: []byte("3")},
+	}, diffs)
+
+	diffs, err = DiffMultiStoreVersion(db, refDB, "store1", 1, 1)
+	require.Nil(t, err)
+	require.Len(t, diffs, 1)
+
+	diffs, err = DiffMultiStoreVersion(db, refDB, "store2", 1, 0)
+	require.Nil(t, err)
+	require.Empty(t, diffs)
+
+	_, err = DiffMultiStoreVersion(db, refDB, "store1", 2, 0)
+	require.NotNil(t, err)
+}
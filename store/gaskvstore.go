@@ -0,0 +1,141 @@
+package store
+
+import (
+	"io"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// gasKVStore consumes the gas of the operations on its parent from a gas
+// meter.
+type gasKVStore struct {
+	parent    KVStore
+	gasMeter  sdk.GasMeter
+	gasConfig sdk.GasConfig
+}
+
+var _ KVStore = gasKVStore{}
+
+// Implements Store.
+func (gs gasKVStore) GetStoreType() StoreType {
+	return gs.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (gs gasKVStore) Get(key []byte) []byte {
+	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostFlat, sdk.GasReadCostFlatDesc)
+	value := gs.parent.Get(key)
+	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostPerByte*sdk.Gas(len(value)), sdk.GasReadPerByteDesc)
+	return value
+}
+
+// Implements KVStore.
+func (gs gasKVStore) Has(key []byte) bool {
+	gs.gasMeter.ConsumeGas(gs.gasConfig.HasCost, sdk.GasHasDesc)
+	return gs.parent.Has(key)
+}
+
+// Implements KVStore.
+func (gs gasKVStore) Set(key, value []byte) {
+	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostFlat, sdk.GasWriteCostFlatDesc)
+	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostPerByte*sdk.Gas(len(value)), sdk.GasWritePerByteDesc)
+	gs.parent.Set(key, value)
+}
+
+// Implements KVStore.
+func (gs gasKVStore) Delete(key []byte) {
+	gs.gasMeter.ConsumeGas(gs.gasConfig.DeleteCost, sdk.GasDeleteDesc)
+	gs.parent.Delete(key)
+}
+
+// Implements KVStore.
+func (gs gasKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{gs, prefix}
+}
+
+// Implements KVStore.
+func (gs gasKVStore) Iterator(start, end []byte) Iterator {
+	return newGasIterator(gs.gasMeter, gs.gasConfig, gs.parent.Iterator(start, end))
+}
+
+// Implements KVStore.
+func (gs gasKVStore) ReverseIterator(start, end []byte) Iterator {
+	return newGasIterator(gs.gasMeter, gs.gasConfig, gs.parent.ReverseIterator(start, end))
+}
+
+// Implements CacheWrapper.
+func (gs gasKVStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(gs)
+}
+
+// CacheWrapWithTrace implements the CacheWrapper interface.
+func (gs gasKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(gs, w, tc))
+}
+
+// gasIterator consumes the gas of every entry it reads
+type gasIterator struct {
+	gasMeter  sdk.GasMeter
+	gasConfig sdk.GasConfig
+	parent    Iterator
+}
+
+func newGasIterator(gasMeter sdk.GasMeter, gasConfig sdk.GasConfig, parent Iterator) Iterator {
+	gi := &gasIterator{gasMeter, gasConfig, parent}
+	gi.consumeSeekGas()
+	return gi
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Domain() (start []byte, end []byte) {
+	return gi.parent.Domain()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Valid() bool {
+	return gi.parent.Valid()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Next() {
+	gi.parent.Next()
+	gi.consumeSeekGas()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Key() []byte {
+	return gi.parent.Key()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Value() []byte {
+	return gi.parent.Value()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Close() {
+	gi.parent.Close()
+}
+
+func (gi *gasIterator) consumeSeekGas() {
+	if !gi.parent.Valid() {
+		return
+	}
+	gi.gasMeter.ConsumeGas(gi.gasConfig.IterNextCostFlat, sdk.GasIterNextCostFlatDesc)
+	gi.gasMeter.ConsumeGas(gi.gasConfig.ReadCostPerByte*sdk.Gas(len(gi.parent.Value())), sdk.GasValuePerByteDesc)
+}
+
+// NewGasCacheMultiStore returns ms consuming the gas of the operations on its
+// stores from gasMeter. The operations on a cache-wrap of ms only consume gas
+// when they reach ms: reads missing the cache-wrap, and writes once it is
+// written.
+// It panics if ms was not created by this package.
+func NewGasCacheMultiStore(ms CacheMultiStore, gasMeter sdk.GasMeter, gasConfig sdk.GasConfig) CacheMultiStore {
+	cms, ok := ms.(cacheMultiStore)
+	if !ok {
+		panic("gas metering is only supported on a cacheMultiStore")
+	}
+	cms.gasMeter = gasMeter
+	cms.gasConfig = gasConfig
+	return cms
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGasCacheMultiStore(t *testing.T) {
+	rms := NewCommitMultiStore(dbm.NewMemDB())
+	key := sdk.NewKVStoreKey("store")
+	rms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, rms.LoadLatestVersion())
+	rms.GetKVStore(key).Set(keyFmt(1), valFmt(1))
+
+	config := sdk.KVGasConfig()
+	meter := sdk.NewGasMeter(100000)
+	ms := NewGasCacheMultiStore(rms.CacheMultiStore(), meter, config)
+	st := ms.GetKVStore(key)
+
+	require.Equal(t, valFmt(1), st.Get(keyFmt(1)))
+	consumed := config.ReadCostFlat + config.ReadCostPerByte*sdk.Gas(len(valFmt(1)))
+	require.Equal(t, consumed, meter.GasConsumed())
+
+	st.Set(keyFmt(2), valFmt(2))
+	consumed += config.WriteCostFlat + config.WriteCostPerByte*sdk.Gas(len(valFmt(2)))
+	require.Equal(t, consumed, meter.GasConsumed())
+
+	require.True(t, st.Has(keyFmt(2)))
+	st.Delete(keyFmt(2))
+	consumed += config.HasCost + config.DeleteCost
+	require.Equal(t, consumed, meter.GasConsumed())
+
+	// each entry read by an iterator consumes gas, prefixed stores too
+	st.Prefix([]byte("k")).Set([]byte("ey3"), valFmt(3))
+	consumed += config.WriteCostFlat + config.WriteCostPerByte*sdk.Gas(len(valFmt(3)))
+	iter := st.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+	}
+	iter.Close()
+	consumed += 2 * config.IterNextCostFlat
+	consumed += config.ReadCostPerByte * sdk.Gas(len(valFmt(1))+len(valFmt(3)))
+	require.Equal(t, consumed, meter.GasConsumed())
+
+	// the operations on a cache-wrap consume gas when they reach the store
+	cache := ms.CacheMultiStore()
+	cache.GetKVStore(key).Set(keyFmt(4), valFmt(4))
+	require.Equal(t, consumed, meter.GasConsumed())
+	cache.Write()
+	consumed += config.WriteCostFlat + config.WriteCostPerByte*sdk.Gas(len(valFmt(4)))
+	require.Equal(t, consumed, meter.GasConsumed())
+
+	// writing the store to its parent consumes no gas
+	ms.Write()
+	require.Equal(t, consumed, meter.GasConsumed())
+	require.Equal(t, valFmt(4), rms.GetKVStore(key).Get(keyFmt(4)))
+
+	// the store panics once the meter is out of gas
+	meter = sdk.NewGasMeter(config.ReadCostFlat - 1)
+	st = NewGasCacheMultiStore(rms.CacheMultiStore(), meter, config).GetKVStore(key)
+	require.PanicsWithValue(t, sdk.ErrorOutOfGas{sdk.GasReadCostFlatDesc}, func() { st.Get(keyFmt(1)) })
+}
@@ -19,7 +19,7 @@ const (
 )
 
 // load the iavl store
-func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningStrategy) (CommitStore, error) {
+func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningOptions) (CommitStore, error) {
 	tree := iavl.NewMutableTree(db, defaultIAVLCacheSize)
 	_, err := tree.LoadVersion(id.Version)
 	if err != nil {
@@ -53,6 +53,10 @@ type IavlStore struct {
 	// By default this value should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
 	storeEvery int64
+
+	// How many versions are committed between two prunings.
+	// A value of 0 or 1 means prune on every commit.
+	pruneInterval int64
 }
 
 // CONTRACT: tree should be fully loaded.
@@ -83,16 +87,10 @@ func (st *IavlStore) Commit() CommitID {
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint.
+	// Release old versions of history, if not sync waypoints.
 	previous := version - 1
-	if st.numRecent < previous {
-		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.Tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
-		}
+	if st.pruneInterval <= 1 || version%st.pruneInterval == 0 {
+		st.releaseVersions(previous - st.numRecent)
 	}
 
 	return CommitID{
@@ -109,20 +107,32 @@ func (st *IavlStore) LastCommitID() CommitID {
 	}
 }
 
-// Implements Committer.
-func (st *IavlStore) SetPruning(pruning sdk.PruningStrategy) {
-	switch pruning {
-	case sdk.PruneEverything:
-		st.numRecent = 0
-		st.storeEvery = 0
-	case sdk.PruneNothing:
-		st.storeEvery = 1
-	case sdk.PruneSyncable:
-		st.numRecent = 100000 // fork github.com/cosmos/cosmos-sdk/blob/9a16e2675f392b083dd1074ff92ff1f9fbda750d/store/types/pruning.go#L34
-		st.storeEvery = 100000
+// releaseVersions deletes the versions released since the last pruning, the
+// latest of them being toRelease. As every commit releases one version, these
+// are the last pruneInterval versions up to toRelease.
+func (st *IavlStore) releaseVersions(toRelease int64) {
+	interval := st.pruneInterval
+	if interval < 1 {
+		interval = 1
+	}
+	for ver := toRelease - interval + 1; ver <= toRelease; ver++ {
+		if ver <= 0 || (st.storeEvery != 0 && ver%st.storeEvery == 0) {
+			continue
+		}
+		err := st.Tree.DeleteVersion(ver)
+		if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
+			panic(err)
+		}
 	}
 }
 
+// Implements Committer.
+func (st *IavlStore) SetPruning(pruning sdk.PruningOptions) {
+	st.numRecent = pruning.KeepRecent
+	st.storeEvery = pruning.KeepEvery
+	st.pruneInterval = pruning.Interval
+}
+
 // VersionExists returns whether or not a given version is stored.
 func (st *IavlStore) VersionExists(version int64) bool {
 	return st.Tree.VersionExists(version)
@@ -179,6 +189,80 @@ func (st *IavlStore) ReverseIterator(start, end []byte) Iterator {
 	return newIAVLIterator(st.Tree.ImmutableTree, start, end, false)
 }
 
+// GetImmutable returns a read-only KVStore of the saved version of the tree,
+// it returns an error if the version was never saved or was pruned.
+func (st *IavlStore) GetImmutable(version int64) (KVStore, error) {
+	if !st.Tree.VersionExists(version) {
+		return nil, iavl.ErrVersionDoesNotExist
+	}
+	tree, err := st.Tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return immutableIAVLStore{tree}, nil
+}
+
+//----------------------------------------
+
+// immutableIAVLStore is a KVStore of a saved version of an iavl tree, it
+// panics on writes, but can be cache-wrapped.
+type immutableIAVLStore struct {
+	tree *iavl.ImmutableTree
+}
+
+var _ KVStore = immutableIAVLStore{}
+
+// Implements Store.
+func (st immutableIAVLStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st immutableIAVLStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st immutableIAVLStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Set(key, value []byte) {
+	panic("cannot set a key of an immutable iavl store")
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Get(key []byte) (value []byte) {
+	_, v := st.tree.Get(key)
+	return v
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Has(key []byte) (exists bool) {
+	return st.tree.Has(key)
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Delete(key []byte) {
+	panic("cannot delete a key of an immutable iavl store")
+}
+
+// Implements KVStore
+func (st immutableIAVLStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) Iterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements KVStore.
+func (st immutableIAVLStore) ReverseIterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
+
 // Handle gatest the latest height, if height is 0
 func getHeight(tree *iavl.MutableTree, req abci.RequestQuery) int64 {
 	height := req.Height
@@ -318,6 +318,39 @@ func TestIAVLAlternativePruning(t *testing.T) {
 	testPruning(t, int64(3), int64(5), states)
 }
 
+func TestIAVLPruningInterval(t *testing.T) {
+	//Expected stored / deleted version numbers for:
+	//numRecent = 2, storeEvery = 3, interval = 4
+	var states = []pruneState{
+		{[]int64{}, []int64{}},
+		{[]int64{1}, []int64{}},
+		{[]int64{1, 2}, []int64{}},
+		{[]int64{1, 2, 3}, []int64{}},
+		{[]int64{2, 3, 4}, []int64{1}},
+		{[]int64{2, 3, 4, 5}, []int64{1}},
+		{[]int64{2, 3, 4, 5, 6}, []int64{1}},
+		{[]int64{2, 3, 4, 5, 6, 7}, []int64{1}},
+		{[]int64{3, 6, 7, 8}, []int64{1, 2, 4, 5}},
+		{[]int64{3, 6, 7, 8, 9}, []int64{1, 2, 4, 5}},
+		{[]int64{3, 6, 7, 8, 9, 10}, []int64{1, 2, 4, 5}},
+		{[]int64{3, 6, 7, 8, 9, 10, 11}, []int64{1, 2, 4, 5}},
+		{[]int64{3, 6, 9, 10, 11, 12}, []int64{1, 2, 4, 5, 7, 8}},
+	}
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, 0, 0)
+	iavlStore.SetPruning(sdk.NewPruningOptions(2, 3, 4))
+	for step, state := range states {
+		for _, ver := range state.stored {
+			require.True(t, iavlStore.VersionExists(ver), "Missing version %d with latest version %d", ver, step)
+		}
+		for _, ver := range state.deleted {
+			require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d with latest version %d", ver, step)
+		}
+		nextVersion(iavlStore)
+	}
+}
+
 type pruneState struct {
 	stored  []int64
 	deleted []int64
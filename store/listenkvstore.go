@@ -0,0 +1,81 @@
+package store
+
+import (
+	"io"
+)
+
+// listenKVStore notifies a WriteListener of the keys set and deleted in its
+// parent.
+type listenKVStore struct {
+	parent   KVStore
+	storeKey StoreKey
+	listener WriteListener
+}
+
+var _ KVStore = listenKVStore{}
+
+// Implements Store.
+func (ls listenKVStore) GetStoreType() StoreType {
+	return ls.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Get(key []byte) []byte {
+	return ls.parent.Get(key)
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Has(key []byte) bool {
+	return ls.parent.Has(key)
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Set(key, value []byte) {
+	ls.parent.Set(key, value)
+	ls.listener.OnWrite(ls.storeKey, key, value, false)
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Delete(key []byte) {
+	ls.parent.Delete(key)
+	ls.listener.OnWrite(ls.storeKey, key, nil, true)
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{ls, prefix}
+}
+
+// Implements KVStore.
+func (ls listenKVStore) Iterator(start, end []byte) Iterator {
+	return ls.parent.Iterator(start, end)
+}
+
+// Implements KVStore.
+func (ls listenKVStore) ReverseIterator(start, end []byte) Iterator {
+	return ls.parent.ReverseIterator(start, end)
+}
+
+// Implements CacheWrapper.
+func (ls listenKVStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(ls)
+}
+
+// CacheWrapWithTrace implements the CacheWrapper interface.
+func (ls listenKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(ls, w, tc))
+}
+
+// NewListenCacheMultiStore returns ms notifying listener of every key set or
+// deleted in its stores, directly or by writing a cache-wrap of ms. Writing
+// ms itself to its parent is not notified, nor are the writes of a cache-wrap
+// to a cache-wrap of ms, so that each change is notified once.
+// It panics if ms was not created by this package.
+func NewListenCacheMultiStore(ms CacheMultiStore, listener WriteListener) CacheMultiStore {
+	cms, ok := ms.(cacheMultiStore)
+	if !ok {
+		panic("write listening is only supported on a cacheMultiStore")
+	}
+	cms.listener = listener
+	return cms
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type testWrite struct {
+	store  string
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type testWriteListener struct {
+	writes []testWrite
+}
+
+func (l *testWriteListener) OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool) {
+	l.writes = append(l.writes, testWrite{storeKey.Name(), key, value, delete})
+}
+
+func TestListenCacheMultiStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	rms := NewCommitMultiStore(db)
+	key1 := sdk.NewKVStoreKey("store1")
+	key2 := sdk.NewKVStoreKey("store2")
+	rms.MountStoreWithDB(key1, sdk.StoreTypeIAVL, nil)
+	rms.MountStoreWithDB(key2, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, rms.LoadLatestVersion())
+
+	listener := &testWriteListener{}
+	deliver := NewListenCacheMultiStore(rms.CacheMultiStore(), listener)
+
+	// direct writes are notified right away
+	deliver.GetKVStore(key1).Set(keyFmt(1), valFmt(1))
+	deliver.GetKVStore(key1).Prefix([]byte("p")).Set(keyFmt(2), valFmt(2))
+	require.Equal(t, []testWrite{
+		{"store1", keyFmt(1), valFmt(1), false},
+		{"store1", append([]byte("p"), keyFmt(2)...), valFmt(2), false},
+	}, listener.writes)
+	listener.writes = nil
+
+	// writes of a cache-wrap are notified once it is written, nested
+	// cache-wraps are notified once
+	tx := deliver.CacheMultiStore()
+	tx.GetKVStore(key2).Set(keyFmt(3), valFmt(3))
+	nested := tx.CacheMultiStore()
+	nested.GetKVStore(key1).Delete(keyFmt(1))
+	nested.Write()
+	require.Nil(t, listener.writes)
+	tx.Write()
+	require.ElementsMatch(t, []testWrite{
+		{"store1", keyFmt(1), nil, true},
+		{"store2", keyFmt(3), valFmt(3), false},
+	}, listener.writes)
+	listener.writes = nil
+
+	// a discarded cache-wrap is not notified
+	tx = deliver.CacheMultiStore()
+	tx.GetKVStore(key2).Set(keyFmt(4), valFmt(4))
+
+	// writing the listened store to its parent is not notified
+	deliver.Write()
+	rms.Commit()
+	require.Nil(t, listener.writes)
+	require.Nil(t, rms.GetKVStore(key1).Get(keyFmt(1)))
+	require.Equal(t, valFmt(3), rms.GetKVStore(key2).Get(keyFmt(3)))
+	require.Nil(t, rms.GetKVStore(key2).Get(keyFmt(4)))
+}
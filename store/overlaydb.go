@@ -0,0 +1,168 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// NewMultiStoreOverlayDB returns a db reading the multistore persisted in
+// parent, whose writes are kept in memory and never reach parent. The
+// versions of the multistore after version are hidden, so that the blocks
+// following version can be committed again, e.g. to replay them on the state
+// of a node.
+func NewMultiStoreOverlayDB(parent dbm.DB, version int64) (dbm.DB, error) {
+	db := newOverlayDB(parent)
+	cInfo, err := getCommitInfo(db, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// the IAVL trees only load the versions whose root is in the db, the root
+	// keys are 'r' followed by the big endian version
+	for _, storeInfo := range cInfo.StoreInfos {
+		storeDB := dbm.NewPrefixDB(db, []byte("s/k:"+storeInfo.Name+"/"))
+		start := make([]byte, 9)
+		start[0] = 'r'
+		binary.BigEndian.PutUint64(start[1:], uint64(storeInfo.Core.CommitID.Version+1))
+		iter := storeDB.Iterator(start, []byte{'r' + 1})
+		var roots [][]byte
+		for ; iter.Valid(); iter.Next() {
+			roots = append(roots, iter.Key())
+		}
+		iter.Close()
+		for _, root := range roots {
+			storeDB.Delete(root)
+		}
+	}
+
+	batch := db.NewBatch()
+	for ver := version + 1; ver <= getLatestVersion(parent); ver++ {
+		batch.Delete([]byte(fmt.Sprintf(commitInfoKeyFmt, ver)))
+	}
+	setLatestVersion(batch, version)
+	batch.Write()
+	return db, nil
+}
+
+// overlayDB is a dbm.DB keeping its writes in memory over a parent db which
+// is only read. The cache is never written to the parent.
+type overlayDB struct {
+	cache *cacheKVStore
+}
+
+var _ dbm.DB = overlayDB{}
+
+func newOverlayDB(parent dbm.DB) overlayDB {
+	return overlayDB{NewCacheKVStore(dbStoreAdapter{parent})}
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) Get(key []byte) []byte {
+	return odb.cache.Get(nonNilKey(key))
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) Has(key []byte) bool {
+	return odb.cache.Has(nonNilKey(key))
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) Set(key []byte, value []byte) {
+	odb.cache.Set(nonNilKey(key), value)
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) SetSync(key []byte, value []byte) {
+	odb.Set(key, value)
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) Delete(key []byte) {
+	odb.cache.Delete(nonNilKey(key))
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) DeleteSync(key []byte) {
+	odb.Delete(key)
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) Iterator(start, end []byte) dbm.Iterator {
+	return odb.cache.Iterator(start, end)
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) ReverseIterator(start, end []byte) dbm.Iterator {
+	return odb.cache.ReverseIterator(start, end)
+}
+
+// Implements dbm.DB. The parent is not closed.
+func (odb overlayDB) Close() {}
+
+// Implements dbm.DB.
+func (odb overlayDB) NewBatch() dbm.Batch {
+	return &overlayBatch{db: odb}
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) Print() {
+	iter := odb.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", iter.Key(), iter.Value())
+	}
+}
+
+// Implements dbm.DB.
+func (odb overlayDB) Stats() map[string]string {
+	odb.cache.mtx.Lock()
+	defer odb.cache.mtx.Unlock()
+	return map[string]string{
+		"database.type": "overlayDB",
+		"database.size": fmt.Sprintf("%d", len(odb.cache.cache)),
+	}
+}
+
+// a nil key is interpreted as an empty key, as by the dbm.DB implementations
+func nonNilKey(key []byte) []byte {
+	if key == nil {
+		return []byte{}
+	}
+	return key
+}
+
+// overlayBatch applies its operations to the overlay db on Write
+type overlayBatch struct {
+	db  overlayDB
+	ops []func()
+}
+
+// Implements dbm.Batch.
+func (b *overlayBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, func() { b.db.Set(key, value) })
+}
+
+// Implements dbm.Batch.
+func (b *overlayBatch) Delete(key []byte) {
+	b.ops = append(b.ops, func() { b.db.Delete(key) })
+}
+
+// Implements dbm.Batch.
+func (b *overlayBatch) Write() {
+	for _, op := range b.ops {
+		op()
+	}
+	b.ops = nil
+}
+
+// Implements dbm.Batch.
+func (b *overlayBatch) WriteSync() {
+	b.Write()
+}
+
+// Implements dbm.Batch.
+func (b *overlayBatch) Close() {
+	b.ops = nil
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestOverlayDB(t *testing.T) {
+	parent := dbm.NewMemDB()
+	parent.Set([]byte("a"), []byte("1"))
+	parent.Set([]byte("b"), []byte("2"))
+
+	db := newOverlayDB(parent)
+	db.Set([]byte("c"), []byte("3"))
+	db.Delete([]byte("a"))
+	batch := db.NewBatch()
+	batch.Set([]byte("d"), []byte("4"))
+	batch.Delete([]byte("b"))
+	require.True(t, db.Has([]byte("b")))
+	batch.Write()
+
+	require.Nil(t, db.Get([]byte("a")))
+	require.False(t, db.Has([]byte("b")))
+	var keys []string
+	for iter := db.Iterator(nil, nil); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	require.Equal(t, []string{"c", "d"}, keys)
+
+	// the parent is only read
+	require.Equal(t, []byte("1"), parent.Get([]byte("a")))
+	require.Equal(t, []byte("2"), parent.Get([]byte("b")))
+	require.False(t, parent.Has([]byte("c")))
+}
+
+func TestMultiStoreOverlayDB(t *testing.T) {
+	parent := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(parent)
+	require.Nil(t, store.LoadLatestVersion())
+	var commitIDs []CommitID
+	for i := 0; i < 3; i++ {
+		store.GetKVStore(store.keysByName["store1"]).Set([]byte("key"), []byte{byte(i)})
+		commitIDs = append(commitIDs, store.Commit())
+	}
+
+	_, err := NewMultiStoreOverlayDB(parent, 4)
+	require.NotNil(t, err)
+
+	// the versions after 1 can be committed again with other values
+	db, err := NewMultiStoreOverlayDB(parent, 1)
+	require.Nil(t, err)
+	store = newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitIDs[0], store.LastCommitID())
+	store.GetKVStore(store.keysByName["store1"]).Set([]byte("key"), []byte("other"))
+	commitID := store.Commit()
+	require.Equal(t, int64(2), commitID.Version)
+	require.NotEqual(t, commitIDs[1], commitID)
+
+	// without altering the parent
+	store = newMultiStoreWithMounts(parent)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, commitIDs[2], store.LastCommitID())
+	require.Nil(t, store.LoadVersion(2))
+	require.Equal(t, []byte{1}, store.GetKVStore(store.keysByName["store1"]).Get([]byte("key")))
+}
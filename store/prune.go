@@ -0,0 +1,51 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PruneMultiStore deletes the versions of the IAVL stores of the multistore
+// persisted in db that pruning does not keep, as if the multistore had been
+// committed with these pruning options from the start. The multistore must
+// not be loaded while it is pruned. It returns the number of store versions
+// deleted.
+func PruneMultiStore(db dbm.DB, pruning sdk.PruningOptions) (int64, error) {
+	if err := pruning.Validate(); err != nil {
+		return 0, err
+	}
+
+	latest := getLatestVersion(db)
+	if latest == 0 {
+		return 0, nil
+	}
+	cInfo, err := getCommitInfo(db, latest)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, storeInfo := range cInfo.StoreInfos {
+		tree := iavl.NewMutableTree(dbm.NewPrefixDB(db, []byte("s/k:"+storeInfo.Name+"/")), defaultIAVLCacheSize)
+		if _, err := tree.LoadVersion(storeInfo.Core.CommitID.Version); err != nil {
+			return deleted, fmt.Errorf("failed to load store %s: %v", storeInfo.Name, err)
+		}
+
+		// only versions older than the recent ones can be released
+		for ver := int64(1); ver < latest-pruning.KeepRecent && ver < tree.Version(); ver++ {
+			if pruning.KeepVersion(ver, latest) || !tree.VersionExists(ver) {
+				continue
+			}
+			if err := tree.DeleteVersion(ver); err != nil {
+				return deleted, fmt.Errorf("failed to delete version %d of store %s: %v", ver, storeInfo.Name, err)
+			}
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestPruneMultiStore(t *testing.T) {
+	db := dbm.NewMemDB()
+
+	// nothing to prune before the first commit
+	deleted, err := PruneMultiStore(db, sdk.PruneEverything)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), deleted)
+
+	store := newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.PruneNothing)
+	require.Nil(t, store.LoadLatestVersion())
+	for i := 0; i < 10; i++ {
+		store.GetKVStore(store.keysByName["store1"]).Set([]byte{byte(i)}, []byte{byte(i)})
+		store.Commit()
+	}
+
+	_, err = PruneMultiStore(db, sdk.NewPruningOptions(2, 4, 0))
+	require.NotNil(t, err)
+
+	deleted, err = PruneMultiStore(db, sdk.NewPruningOptions(2, 4, 1))
+	require.Nil(t, err)
+	// versions 1, 2, 3, 5, 6 and 7 of the 3 stores
+	require.Equal(t, int64(18), deleted)
+
+	store = newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.PruneNothing)
+	require.Nil(t, store.LoadLatestVersion())
+	for _, name := range []string{"store1", "store2", "store3"} {
+		iavlStore := store.getStoreByName(name).(*IavlStore)
+		for ver := int64(1); ver <= 10; ver++ {
+			kept := ver == 4 || ver >= 8
+			require.Equal(t, kept, iavlStore.VersionExists(ver), "version %d of %s", ver, name)
+		}
+	}
+	require.Equal(t, int64(10), store.LastCommitID().Version)
+
+	// pruning again deletes nothing
+	deleted, err = PruneMultiStore(db, sdk.NewPruningOptions(2, 4, 1))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), deleted)
+}
@@ -24,7 +24,7 @@ const (
 type rootMultiStore struct {
 	db           dbm.DB
 	lastCommitID CommitID
-	pruning      sdk.PruningStrategy
+	pruning      sdk.PruningOptions
 	storesParams map[StoreKey]storeParams
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
@@ -40,6 +40,7 @@ var _ Queryable = (*rootMultiStore)(nil)
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneSyncable,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
@@ -47,7 +48,7 @@ func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 }
 
 // Implements CommitMultiStore
-func (rs *rootMultiStore) SetPruning(pruning sdk.PruningStrategy) {
+func (rs *rootMultiStore) SetPruning(pruning sdk.PruningOptions) {
 	rs.pruning = pruning
 	for _, substore := range rs.stores {
 		substore.SetPruning(pruning)
@@ -242,6 +243,41 @@ func (rs *rootMultiStore) CacheMultiStore() CacheMultiStore {
 	return newCacheMultiStoreFromRMS(rs)
 }
 
+// CacheMultiStoreWithVersion implements the CommitMultiStore interface. The
+// IAVL stores are loaded at version, the other stores have no history and are
+// cache-wrapped in their current state.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error) {
+	if version <= 0 || version > rs.lastCommitID.Version {
+		return nil, fmt.Errorf("version %d is not committed, the latest version is %d", version, rs.lastCommitID.Version)
+	}
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return nil, fmt.Errorf("version %d is not available: %v", version, err)
+	}
+	committed := make(map[string]bool, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		committed[storeInfo.Name] = true
+	}
+
+	stores := make(map[StoreKey]CacheWrapper, len(rs.stores))
+	for key, store := range rs.stores {
+		iavlStore, ok := store.(*IavlStore)
+		switch {
+		case !ok:
+			stores[key] = store
+		case !committed[key.Name()]:
+			// the store was mounted after version
+			stores[key] = dbStoreAdapter{dbm.NewMemDB()}
+		default:
+			stores[key], err = iavlStore.GetImmutable(version)
+			if err != nil {
+				return nil, fmt.Errorf("version %d of store %s is not available, it may have been pruned: %v", version, key.Name(), err)
+			}
+		}
+	}
+	return newCacheMultiStore(rs.db, stores, rs.keysByName, rs.traceWriter, rs.traceContext), nil
+}
+
 // Implements MultiStore.
 func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 	return rs.stores[key]
@@ -96,6 +96,45 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestCacheMultiStoreWithVersion(t *testing.T) {
+	var db dbm.DB = dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.NewPruningOptions(1, 0, 1))
+	require.Nil(t, store.LoadLatestVersion())
+	key := store.keysByName["store1"]
+
+	for i := 1; i <= 4; i++ {
+		store.GetKVStore(key).Set([]byte("key"), []byte{byte(i)})
+		store.Commit()
+	}
+
+	ms, err := store.CacheMultiStoreWithVersion(3)
+	require.Nil(t, err)
+	kv := ms.GetKVStore(key)
+	require.Equal(t, []byte{3}, kv.Get([]byte("key")))
+	iter := kv.Iterator(nil, nil)
+	require.Equal(t, []byte("key"), iter.Key())
+	iter.Close()
+
+	// the state can be written to the cache only
+	kv.Set([]byte("key"), []byte{5})
+	require.Equal(t, []byte{5}, kv.Get([]byte("key")))
+	require.Panics(t, ms.Write)
+	require.Equal(t, []byte{4}, store.GetKVStore(key).Get([]byte("key")))
+
+	ms, err = store.CacheMultiStoreWithVersion(4)
+	require.Nil(t, err)
+	require.Equal(t, []byte{4}, ms.GetKVStore(key).Get([]byte("key")))
+
+	// pruned and future versions
+	_, err = store.CacheMultiStoreWithVersion(1)
+	require.Contains(t, err.Error(), "may have been pruned")
+	_, err = store.CacheMultiStoreWithVersion(5)
+	require.Contains(t, err.Error(), "is not committed")
+	_, err = store.CacheMultiStoreWithVersion(0)
+	require.NotNil(t, err)
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
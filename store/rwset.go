@@ -0,0 +1,235 @@
+package store
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// dbRWSetSpace is the space of the keys read from and written to the db
+// store of a cacheMultiStore.
+const dbRWSetSpace = "__db__"
+
+// RWSet records the keys a transaction read and wrote, grouped by space (the
+// name of the store the keys belong to). It is used to detect whether a
+// transaction that was executed speculatively against an earlier state
+// observed a key that was changed before it was committed.
+type RWSet struct {
+	mtx    sync.Mutex
+	reads  map[string]map[string]struct{}
+	writes map[string]map[string]struct{}
+	ranges map[string][]iterRange
+}
+
+// iterRange is the domain of an iteration, nil means unbounded.
+type iterRange struct {
+	start, end []byte
+}
+
+func (r iterRange) contains(key []byte) bool {
+	if r.start != nil && bytes.Compare(key, r.start) < 0 {
+		return false
+	}
+	if r.end != nil && bytes.Compare(key, r.end) >= 0 {
+		return false
+	}
+	return true
+}
+
+// NewRWSet returns an empty RWSet.
+func NewRWSet() *RWSet {
+	return &RWSet{
+		reads:  make(map[string]map[string]struct{}),
+		writes: make(map[string]map[string]struct{}),
+		ranges: make(map[string][]iterRange),
+	}
+}
+
+// RecordRead records that key of space was read.
+func (s *RWSet) RecordRead(space string, key []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	addKey(s.reads, space, key)
+}
+
+// RecordWrite records that key of space was set or deleted.
+func (s *RWSet) RecordWrite(space string, key []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	addKey(s.writes, space, key)
+}
+
+// RecordRange records that the keys of space in [start, end) were iterated.
+func (s *RWSet) RecordRange(space string, start, end []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.ranges[space] = append(s.ranges[space], iterRange{cp(start), cp(end)})
+}
+
+// MergeWrites adds the writes of other to the writes of s.
+func (s *RWSet) MergeWrites(other *RWSet) {
+	if s == other {
+		return
+	}
+	other.mtx.Lock()
+	defer other.mtx.Unlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for space, keys := range other.writes {
+		for key := range keys {
+			addKey(s.writes, space, []byte(key))
+		}
+	}
+}
+
+// ReadsAnyWriteOf returns true if s read or iterated over a key that written
+// wrote.
+func (s *RWSet) ReadsAnyWriteOf(written *RWSet) bool {
+	if s == written {
+		return false
+	}
+	written.mtx.Lock()
+	defer written.mtx.Unlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for space, keys := range s.reads {
+		writes := written.writes[space]
+		for key := range keys {
+			if _, ok := writes[key]; ok {
+				return true
+			}
+		}
+	}
+	for space, ranges := range s.ranges {
+		for key := range written.writes[space] {
+			for _, r := range ranges {
+				if r.contains([]byte(key)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Writes returns the number of keys written.
+func (s *RWSet) Writes() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	n := 0
+	for _, keys := range s.writes {
+		n += len(keys)
+	}
+	return n
+}
+
+func addKey(sets map[string]map[string]struct{}, space string, key []byte) {
+	keys, ok := sets[space]
+	if !ok {
+		keys = make(map[string]struct{})
+		sets[space] = keys
+	}
+	keys[string(key)] = struct{}{}
+}
+
+//----------------------------------------
+// rwSetKVStore
+
+// rwSetKVStore sits between a cacheKVStore and its parent and records every key
+// the cacheKVStore reads from or writes to the parent. As a cacheKVStore only
+// reads a key from its parent the first time it is accessed and only writes
+// its dirty keys on Write, these are exactly the keys the cached operations
+// depend on and modify.
+type rwSetKVStore struct {
+	parent KVStore
+	space  string
+	rwSet  *RWSet
+}
+
+var _ KVStore = rwSetKVStore{}
+
+// Implements Store.
+func (rs rwSetKVStore) GetStoreType() StoreType {
+	return rs.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (rs rwSetKVStore) Get(key []byte) []byte {
+	rs.rwSet.RecordRead(rs.space, key)
+	return rs.parent.Get(key)
+}
+
+// Implements KVStore.
+func (rs rwSetKVStore) Has(key []byte) bool {
+	rs.rwSet.RecordRead(rs.space, key)
+	return rs.parent.Has(key)
+}
+
+// Implements KVStore.
+func (rs rwSetKVStore) Set(key, value []byte) {
+	rs.rwSet.RecordWrite(rs.space, key)
+	rs.parent.Set(key, value)
+}
+
+// Implements KVStore.
+func (rs rwSetKVStore) Delete(key []byte) {
+	rs.rwSet.RecordWrite(rs.space, key)
+	rs.parent.Delete(key)
+}
+
+// Implements KVStore.
+func (rs rwSetKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{rs, prefix}
+}
+
+// Implements KVStore.
+func (rs rwSetKVStore) Iterator(start, end []byte) Iterator {
+	rs.rwSet.RecordRange(rs.space, start, end)
+	return rs.parent.Iterator(start, end)
+}
+
+// Implements KVStore.
+func (rs rwSetKVStore) ReverseIterator(start, end []byte) Iterator {
+	rs.rwSet.RecordRange(rs.space, start, end)
+	return rs.parent.ReverseIterator(start, end)
+}
+
+// Implements CacheWrapper.
+func (rs rwSetKVStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(rs)
+}
+
+// CacheWrapWithTrace implements the CacheWrapper interface.
+func (rs rwSetKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(rs, w, tc))
+}
+
+// NewRWSetCacheMultiStore cache-wraps ms the way ms.CacheMultiStore() does,
+// and records the keys the returned store reads from and writes to ms in
+// rwSet. The keys are grouped by the name of the store they belong to.
+// It panics if ms was not created by this package.
+func NewRWSetCacheMultiStore(ms CacheMultiStore, rwSet *RWSet) CacheMultiStore {
+	parent, ok := ms.(cacheMultiStore)
+	if !ok {
+		panic("read/write set recording is only supported on a cacheMultiStore")
+	}
+
+	cms := cacheMultiStore{
+		db:           NewCacheKVStore(rwSetKVStore{parent.db, dbRWSetSpace, rwSet}),
+		stores:       make(map[StoreKey]CacheWrap, len(parent.stores)),
+		keysByName:   parent.keysByName,
+		traceWriter:  parent.traceWriter,
+		traceContext: parent.traceContext,
+	}
+
+	for key := range parent.stores {
+		recorder := rwSetKVStore{parent.GetKVStore(key), key.Name(), rwSet}
+		if cms.TracingEnabled() {
+			cms.stores[key] = recorder.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
+		} else {
+			cms.stores[key] = recorder.CacheWrap()
+		}
+	}
+
+	return cms
+}
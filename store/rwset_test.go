@@ -0,0 +1,62 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestRWSetCacheMultiStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	rms := NewCommitMultiStore(db)
+	key1 := sdk.NewKVStoreKey("store1")
+	key2 := sdk.NewKVStoreKey("store2")
+	rms.MountStoreWithDB(key1, sdk.StoreTypeIAVL, nil)
+	rms.MountStoreWithDB(key2, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, rms.LoadLatestVersion())
+
+	deliver := rms.CacheMultiStore()
+	deliver.GetKVStore(key1).Set(keyFmt(1), valFmt(1))
+
+	rwSet1 := NewRWSet()
+	tx1 := NewRWSetCacheMultiStore(deliver, rwSet1)
+	require.Equal(t, valFmt(1), tx1.GetKVStore(key1).Get(keyFmt(1)))
+	// a key written before it is read does not depend on the parent
+	tx1.GetKVStore(key2).Set(keyFmt(2), valFmt(2))
+	require.Equal(t, valFmt(2), tx1.GetKVStore(key2).Get(keyFmt(2)))
+	tx1.GetKVStore(key1).Set(keyFmt(1), valFmt(3))
+	require.Equal(t, 0, rwSet1.Writes())
+
+	rwSet2 := NewRWSet()
+	tx2 := NewRWSetCacheMultiStore(deliver, rwSet2)
+	iter := tx2.GetKVStore(key2).Iterator(keyFmt(0), keyFmt(5))
+	iter.Close()
+
+	rwSet3 := NewRWSet()
+	tx3 := NewRWSetCacheMultiStore(deliver, rwSet3)
+	tx3.GetKVStore(key2).Get(keyFmt(1))
+	tx3.GetKVStore(key1).Set(keyFmt(1), valFmt(4))
+
+	// nothing is written to the parent before Write
+	require.Equal(t, valFmt(1), deliver.GetKVStore(key1).Get(keyFmt(1)))
+	require.Nil(t, deliver.GetKVStore(key2).Get(keyFmt(2)))
+
+	tx1.Write()
+	require.Equal(t, 2, rwSet1.Writes())
+	require.Equal(t, valFmt(3), deliver.GetKVStore(key1).Get(keyFmt(1)))
+	require.Equal(t, valFmt(2), deliver.GetKVStore(key2).Get(keyFmt(2)))
+
+	require.False(t, rwSet1.ReadsAnyWriteOf(rwSet1))
+	// tx2 iterated over the key tx1 wrote to store2
+	require.True(t, rwSet2.ReadsAnyWriteOf(rwSet1))
+	// tx3 only read a key tx1 did not write, and a blind write is no conflict
+	require.False(t, rwSet3.ReadsAnyWriteOf(rwSet1))
+
+	written := NewRWSet()
+	written.MergeWrites(rwSet1)
+	require.Equal(t, 2, written.Writes())
+	require.True(t, rwSet2.ReadsAnyWriteOf(written))
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+
+	amino "github.com/tendermint/go-amino"
+)
+
+// Formats of the app state chunks of a snapshot
+const (
+	// SnapshotFormatRaw chunks hold the amino encoded iavl nodes as they are
+	SnapshotFormatRaw int64 = 0
+	// SnapshotFormatCompressed chunks hold a single DEFLATE compressed blob of
+	// the length prefixed nodes of the chunk
+	SnapshotFormatCompressed int64 = 1
+)
+
+// snapshotFormatMarker follows the per store key counts in Manifest.NumKeys
+// when a snapshot is not a full raw one. It is followed by the format and the
+// base height of the snapshot. The manifest version is checked by tendermint
+// and can't be changed by the app, and nodes not knowing this layout reject
+// such manifests as their store count doesn't match.
+const snapshotFormatMarker int64 = -1
+
+// SnapshotOptions configures the snapshots taken by StateSyncHelper
+type SnapshotOptions struct {
+	// Compress the app state chunks
+	Compress bool
+	// Incremental snapshots only record the nodes changed since the previous
+	// snapshot taken by the helper, and the root of each store. They can only be
+	// restored by a node having the state at the height of the previous snapshot.
+	Incremental bool
+}
+
+func (opts SnapshotOptions) format() int64 {
+	if opts.Compress {
+		return SnapshotFormatCompressed
+	}
+	return SnapshotFormatRaw
+}
+
+// encodeSnapshotNumKeys appends the format and the base height of a snapshot to
+// the key counts of its stores, a full raw snapshot keeps the legacy layout
+func encodeSnapshotNumKeys(numKeys []int64, format, baseHeight int64) []int64 {
+	if format == SnapshotFormatRaw && baseHeight == 0 {
+		return numKeys
+	}
+	return append(numKeys[:len(numKeys):len(numKeys)], snapshotFormatMarker, format, baseHeight)
+}
+
+// decodeSnapshotNumKeys is the reverse of encodeSnapshotNumKeys for a snapshot
+// of numStores stores
+func decodeSnapshotNumKeys(numKeys []int64, numStores int) (storeNumKeys []int64, format, baseHeight int64, err error) {
+	if len(numKeys) == numStores+3 && numKeys[numStores] == snapshotFormatMarker {
+		storeNumKeys, format, baseHeight = numKeys[:numStores], numKeys[numStores+1], numKeys[numStores+2]
+	} else {
+		storeNumKeys = numKeys
+	}
+
+	if len(storeNumKeys) != numStores {
+		return nil, 0, 0, fmt.Errorf("sub store count in manifest %d does not match local %d", len(storeNumKeys), numStores)
+	}
+	if format != SnapshotFormatRaw && format != SnapshotFormatCompressed {
+		return nil, 0, 0, fmt.Errorf("unknown snapshot format %d", format)
+	}
+	if baseHeight < 0 {
+		return nil, 0, 0, fmt.Errorf("invalid snapshot base height %d", baseHeight)
+	}
+	return storeNumKeys, format, baseHeight, nil
+}
+
+// encodeChunkNodes encodes the nodes of an app state chunk in format
+func encodeChunkNodes(format int64, nodes [][]byte) ([][]byte, error) {
+	if format == SnapshotFormatRaw {
+		return nodes, nil
+	}
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	for _, node := range nodes {
+		n := binary.PutUvarint(lenBuf, uint64(len(node)))
+		if _, err := w.Write(lenBuf[:n]); err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(node); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return [][]byte{buf.Bytes()}, nil
+}
+
+// decodeChunkNodes is the reverse of encodeChunkNodes
+func decodeChunkNodes(format int64, nodes [][]byte) ([][]byte, error) {
+	if format == SnapshotFormatRaw {
+		return nodes, nil
+	}
+
+	if len(nodes) != 1 {
+		return nil, fmt.Errorf("compressed chunk should have exactly one blob, but has %d", len(nodes))
+	}
+	raw, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(nodes[0])))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress chunk: %v", err)
+	}
+
+	var decoded [][]byte
+	for len(raw) > 0 {
+		size, n := binary.Uvarint(raw)
+		if n <= 0 || uint64(len(raw)-n) < size {
+			return nil, fmt.Errorf("malformed node %d in compressed chunk", len(decoded))
+		}
+		decoded = append(decoded, raw[n:n+int(size)])
+		raw = raw[n+int(size):]
+	}
+	return decoded, nil
+}
+
+// iavlNodeVersion reads the version from the header of an amino encoded iavl node
+func iavlNodeVersion(nodeBytes []byte) (int64, error) {
+	_, n, err := amino.DecodeInt8(nodeBytes)
+	if err != nil {
+		return 0, fmt.Errorf("decoding node height: %v", err)
+	}
+	nodeBytes = nodeBytes[n:]
+	if _, n, err = amino.DecodeVarint(nodeBytes); err != nil {
+		return 0, fmt.Errorf("decoding node size: %v", err)
+	}
+	nodeBytes = nodeBytes[n:]
+	version, _, err := amino.DecodeVarint(nodeBytes)
+	if err != nil {
+		return 0, fmt.Errorf("decoding node version: %v", err)
+	}
+	return version, nil
+}
@@ -24,6 +24,8 @@ const (
 	snapshotToRemoveQueueSize = 5
 	snapshotRetry             = 5
 	chunksToFlushBatch        = 10
+
+	lastSnapshotHeightKey = "statesync/lastSnapshotHeight"
 )
 
 type incompleteChunkItem struct {
@@ -65,6 +67,7 @@ type StateSyncHelper struct {
 	cdc      *codec.Codec
 
 	manifest            *abci.Manifest
+	snapshotFormat      int64
 	stateSyncStoreInfos []StoreInfo
 
 	hashesToIdx      map[abci.SHA256Sum]int          // chunkhash -> idx in manifest
@@ -74,7 +77,9 @@ type StateSyncHelper struct {
 
 	reloadingMtx sync.RWMutex // guard below fields to make sure no concurrent load snapshot and response snapshot, and they should be updated atomically
 
-	snapshotManager *snapshot.SnapshotManager
+	snapshotManager    *snapshot.SnapshotManager
+	snapshotOptions    SnapshotOptions
+	lastSnapshotHeight int64 // height of the last snapshot taken, the base of an incremental snapshot
 }
 
 func NewStateSyncHelper(
@@ -94,6 +99,11 @@ func NewStateSyncHelper(
 	return &helper
 }
 
+// SetSnapshotOptions sets the options of the snapshots taken after Init
+func (helper *StateSyncHelper) SetSnapshotOptions(opts SnapshotOptions) {
+	helper.snapshotOptions = opts
+}
+
 // not all key in cms is committed
 // for example the BEP9 timelock store upgrade will not commit the newly added store until upgrade height
 func (helper *StateSyncHelper) getCommitedSortedStoreKeys() []sdk.StoreKey {
@@ -123,6 +133,7 @@ func (helper *StateSyncHelper) getCommitedSortedStoreKeys() []sdk.StoreKey {
 
 // Split Init method and NewStateSyncHelper for snapshot command
 func (helper *StateSyncHelper) Init(lastBreatheBlockHeight int64) {
+	helper.lastSnapshotHeight = getLastSnapshotHeight(helper.db)
 	go helper.ReloadSnapshotRoutine(lastBreatheBlockHeight, 0)
 	go func() {
 		for height := range helper.SnapshotHeights {
@@ -142,7 +153,23 @@ func (helper *StateSyncHelper) StartRecovery(manifest *abci.Manifest) error {
 	sdk.UpgradeMgr.SetHeight(manifest.Height)
 	storeKeys := helper.getCommitedSortedStoreKeys()
 
+	numKeys, format, baseHeight, err := decodeSnapshotNumKeys(manifest.NumKeys, len(storeKeys))
+	if err != nil {
+		return err
+	}
+	if baseHeight > 0 {
+		// the nodes not changed since the base height must be present locally
+		if baseHeight >= manifest.Height {
+			return fmt.Errorf("invalid base height %d of incremental snapshot at height %d", baseHeight, manifest.Height)
+		}
+		if err := helper.checkBaseVersion(storeKeys, baseHeight); err != nil {
+			return fmt.Errorf("incremental snapshot at height %d requires the local state at height %d: %v", manifest.Height, baseHeight, err)
+		}
+	}
+	helper.logger.Info("recover from snapshot", "height", manifest.Height, "format", format, "baseHeight", baseHeight)
+
 	helper.manifest = manifest
+	helper.snapshotFormat = format
 	helper.stateSyncStoreInfos = make([]StoreInfo, 0, len(storeKeys))
 	helper.hashesToIdx = make(map[abci.SHA256Sum]int, len(manifest.AppStateHashes))
 	helper.incompleteChunks = make(map[int64][]incompleteChunkItem, 0)
@@ -154,12 +181,8 @@ func (helper *StateSyncHelper) StartRecovery(manifest *abci.Manifest) error {
 		idxOfChunk++
 	}
 
-	if len(manifest.NumKeys) != len(storeKeys) {
-		return fmt.Errorf("sub store count in manifest %d does not match local %d", len(manifest.NumKeys), len(storeKeys))
-	}
-
 	var startIdxForEachStore int64
-	for idx, numOfKeys := range manifest.NumKeys {
+	for idx, numOfKeys := range numKeys {
 		db := dbm.NewPrefixDB(helper.db, []byte("s/k:"+storeKeys[idx].Name()+"/"))
 		nodeDB := iavl.NewNodeDB(db, 10000)
 		helper.prefixNodeDBs = append(helper.prefixNodeDBs,
@@ -174,46 +197,80 @@ func (helper *StateSyncHelper) StartRecovery(manifest *abci.Manifest) error {
 	return nil
 }
 
+// checkBaseVersion makes sure every iavl store keeps the tree of the base height, the commit info of a height
+// is kept even if the stores have pruned it
+func (helper *StateSyncHelper) checkBaseVersion(storeKeys []sdk.StoreKey, baseHeight int64) error {
+	for _, key := range storeKeys {
+		tree := iavl.NewMutableTree(dbm.NewPrefixDB(helper.db, []byte("s/k:"+key.Name()+"/")), 0)
+		if _, err := tree.Load(); err != nil {
+			return fmt.Errorf("failed to load store %s: %v", key.Name(), err)
+		}
+		if !tree.VersionExists(baseHeight) {
+			return fmt.Errorf("store %s does not keep version %d", key.Name(), baseHeight)
+		}
+	}
+	return nil
+}
+
 func (helper *StateSyncHelper) WriteRecoveryChunk(hash abci.SHA256Sum, chunk *abci.AppStateChunk, isComplete bool) (err error) {
 	helper.reloadingMtx.Lock()
 	defer helper.reloadingMtx.Unlock()
 
 	if chunk != nil {
-		numOfNodes := len(chunk.Nodes)
+		chunkIdx, ok := helper.hashesToIdx[hash]
+		if !ok {
+			return fmt.Errorf("app state chunk %X is not in the manifest", hash)
+		}
+		chunkNodes, err := decodeChunkNodes(helper.snapshotFormat, chunk.Nodes)
+		if err != nil {
+			return chunkError(chunkIdx, hash, err)
+		}
+
+		numOfNodes := len(chunkNodes)
 		nodes := make([]*iavl.Node, 0, numOfNodes)
 
-		helper.logger.Info("start write recovery chunk", "isComplete", isComplete, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
+		helper.logger.Info("start write recovery chunk", "isComplete", isComplete, "chunkIdx", chunkIdx, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
+
+		if chunk.StartIdx < 0 || chunk.StartIdx+int64(numOfNodes) > helper.totalNodes() {
+			return chunkError(chunkIdx, hash, fmt.Errorf("nodes [%d, %d) are out of the %d nodes of the snapshot", chunk.StartIdx, chunk.StartIdx+int64(numOfNodes), helper.totalNodes()))
+		}
 
 		switch chunk.Completeness {
 		case abci.Complete: // chunk is independent and complete
 			for idx := 0; idx < numOfNodes; idx++ {
-				node, _ := iavl.MakeNode(chunk.Nodes[idx])
+				node, err := iavl.MakeNode(chunkNodes[idx])
+				if err != nil {
+					return chunkError(chunkIdx, hash, fmt.Errorf("invalid node %d: %v", chunk.StartIdx+int64(idx), err))
+				}
 				iavl.Hash(node)
 				nodes = append(nodes, node)
 			}
 		case abci.InComplete_First:
+			if numOfNodes == 0 {
+				return chunkError(chunkIdx, hash, fmt.Errorf("incomplete chunk has no node"))
+			}
 			for idx := 0; idx < numOfNodes-1; idx++ {
-				if node, err := iavl.MakeNode(chunk.Nodes[idx]); err == nil {
-					iavl.Hash(node)
-					nodes = append(nodes, node)
-				} else {
-					return err
+				node, err := iavl.MakeNode(chunkNodes[idx])
+				if err != nil {
+					return chunkError(chunkIdx, hash, fmt.Errorf("invalid node %d: %v", chunk.StartIdx+int64(idx), err))
 				}
+				iavl.Hash(node)
+				nodes = append(nodes, node)
 			}
 			nodeIdx := chunk.StartIdx + int64(numOfNodes-1)
 			helper.incompleteChunks[nodeIdx] = append(helper.incompleteChunks[nodeIdx],
 				incompleteChunkItem{
-					helper.hashesToIdx[hash],
+					chunkIdx,
 					chunk.Completeness,
-					chunk.Nodes[numOfNodes-1]})
+					chunkNodes[numOfNodes-1]})
 		case abci.InComplete_Mid, abci.InComplete_Last:
 			if numOfNodes != 1 {
-				helper.logger.Error("incomplete chunk should has only one node", "hash", hash, "startIdx", chunk.StartIdx, "completeness", chunk.Completeness, "numOfNodes", numOfNodes)
+				return chunkError(chunkIdx, hash, fmt.Errorf("incomplete chunk should have only one node, but has %d", numOfNodes))
 			}
 
-			helper.incompleteChunks[chunk.StartIdx] = append(helper.incompleteChunks[chunk.StartIdx], incompleteChunkItem{helper.hashesToIdx[hash], chunk.Completeness, chunk.Nodes[0]})
+			helper.incompleteChunks[chunk.StartIdx] = append(helper.incompleteChunks[chunk.StartIdx], incompleteChunkItem{chunkIdx, chunk.Completeness, chunkNodes[0]})
 		default:
-			helper.logger.Error("unknown completeness status", "hash", hash, "startIdx", chunk.StartIdx, "completeness", chunk.Completeness, "numOfNodes", numOfNodes)
+			return chunkError(chunkIdx, hash, fmt.Errorf("unknown completeness status %d", chunk.Completeness))
 		}
 
 		// write complete nodes right now
@@ -226,7 +283,7 @@ func (helper *StateSyncHelper) WriteRecoveryChunk(hash abci.SHA256Sum, chunk *ab
 		if helper.chunksSynced%chunksToFlushBatch == 0 {
 			helper.flushBatch()
 		}
-		helper.logger.Info("finished write recovery chunk", "isComplete", isComplete, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
+		helper.logger.Info("finished write recovery chunk", "isComplete", isComplete, "chunkIdx", chunkIdx, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
 	}
 
 	if isComplete {
@@ -236,6 +293,18 @@ func (helper *StateSyncHelper) WriteRecoveryChunk(hash abci.SHA256Sum, chunk *ab
 	return err
 }
 
+// chunkError reports which chunk of the manifest failed the verification
+func chunkError(chunkIdx int, hash abci.SHA256Sum, err error) error {
+	return fmt.Errorf("app state chunk %d (hash %X) is invalid: %v", chunkIdx, hash, err)
+}
+
+func (helper *StateSyncHelper) totalNodes() int64 {
+	if len(helper.prefixNodeDBs) == 0 {
+		return 0
+	}
+	return helper.prefixNodeDBs[len(helper.prefixNodeDBs)-1].endIdxExclusive
+}
+
 func (helper *StateSyncHelper) DeleteSnapshot(height int64) error {
 	err := snapshot.ManagerAt(height).Delete()
 	helper.logger.Info("deleted snapshot", "height", height, "err", err)
@@ -286,15 +355,15 @@ func (helper *StateSyncHelper) saveIncompleteChunks() error {
 		for idx, chunkItem := range chunkItems {
 			if idx == 0 {
 				if chunkItem.completeness != abci.InComplete_First {
-					return fmt.Errorf("first node part containing chunk's completeness %d is wrong, should be %d, nodeIdx: %d", chunkItem.completeness, abci.InComplete_First, nodeIdx)
+					return fmt.Errorf("first node part containing chunk's completeness %d is wrong, should be %d, nodeIdx: %d, chunkIdx: %d", chunkItem.completeness, abci.InComplete_First, nodeIdx, chunkItem.chunkIdx)
 				}
 			} else if idx == len(chunkItems)-1 {
 				if chunkItem.completeness != abci.InComplete_Last {
-					return fmt.Errorf("last node part containing chunk's completeness %d is wrong, should be %d, nodeIdx: %d", chunkItem.completeness, abci.InComplete_Last, nodeIdx)
+					return fmt.Errorf("last node part containing chunk's completeness %d is wrong, should be %d, nodeIdx: %d, chunkIdx: %d", chunkItem.completeness, abci.InComplete_Last, nodeIdx, chunkItem.chunkIdx)
 				}
 			} else {
 				if chunkItem.completeness != abci.InComplete_Mid {
-					return fmt.Errorf("middle node part containing chunk's completeness %d is wrong, should be %d, nodeIdx: %d", chunkItem.completeness, abci.InComplete_Mid, nodeIdx)
+					return fmt.Errorf("middle node part containing chunk's completeness %d is wrong, should be %d, nodeIdx: %d, chunkIdx: %d", chunkItem.completeness, abci.InComplete_Mid, nodeIdx, chunkItem.chunkIdx)
 				}
 			}
 			completeNode.Write(chunkItem.nodePart)
@@ -304,7 +373,7 @@ func (helper *StateSyncHelper) saveIncompleteChunks() error {
 			iavl.Hash(node)
 			helper.saveNode(nodeIdx, node)
 		} else {
-			return err
+			return fmt.Errorf("invalid node %d assembled from app state chunks %d to %d: %v", nodeIdx, chunkItems[0].chunkIdx, chunkItems[len(chunkItems)-1].chunkIdx, err)
 		}
 	}
 	return nil
@@ -368,7 +437,7 @@ func (helper *StateSyncHelper) saveNode(nodeIdx int64, node *iavl.Node) {
 
 // the method might take quite a while, BETTER to be called concurrently
 // so we only do it once a day after breathe block
-func (helper StateSyncHelper) ReloadSnapshotRoutine(height int64, retry int) {
+func (helper *StateSyncHelper) ReloadSnapshotRoutine(height int64, retry int) {
 	helper.reloadingMtx.Lock()
 	defer helper.reloadingMtx.Unlock()
 
@@ -395,10 +464,16 @@ func (helper *StateSyncHelper) takeSnapshotImpl(height int64, retry int) {
 	}
 
 	if helper.snapshotManager.IsFinalized() {
+		helper.setLastSnapshotHeight(height)
 		return
 	}
 
 	storeKeys := helper.getCommitedSortedStoreKeys()
+	format := helper.snapshotOptions.format()
+	var baseHeight int64
+	if helper.snapshotOptions.Incremental && helper.lastSnapshotHeight < height {
+		baseHeight = helper.lastSnapshotHeight
+	}
 
 	failed := true
 	for failed {
@@ -429,6 +504,16 @@ func (helper *StateSyncHelper) takeSnapshotImpl(height int64, retry int) {
 			mutableTree := store.(*IavlStore).Tree
 			if tree, err := mutableTree.GetImmutable(height); err == nil {
 				tree.IterateFirst(func(nodeBytes []byte) {
+					// the root is always recorded, so that an empty store of an incremental snapshot is not taken as unchanged
+					if baseHeight > 0 && currStoreKeys > 0 {
+						version, err := iavlNodeVersion(nodeBytes)
+						if err != nil {
+							panic(err)
+						}
+						if version <= baseHeight {
+							return
+						}
+					}
 					nodeBytesLength := len(nodeBytes)
 
 					if currChunkTotalBytes+nodeBytesLength <= abci.ChunkPayloadMaxBytes {
@@ -466,7 +551,7 @@ func (helper *StateSyncHelper) takeSnapshotImpl(height int64, retry int) {
 
 					currStoreKeys++
 				})
-				helper.logger.Info("snapshoted a substore", "storeName", key, "numOfKeys", currStoreKeys)
+				helper.logger.Info("snapshoted a substore", "storeName", key, "numOfKeys", currStoreKeys, "baseHeight", baseHeight)
 			} else {
 				helper.logger.Error("failed to load immutable tree", "err", err, "store", key)
 				return
@@ -479,8 +564,9 @@ func (helper *StateSyncHelper) takeSnapshotImpl(height int64, retry int) {
 			if len(currChunkNodes) > 0 {
 				helper.finalizeAppStateChunk(currStartIdx, abci.Complete, currChunkNodes)
 			}
-			if err := helper.snapshotManager.SelfFinalize(numKeys); err == nil {
-				helper.logger.Info("finish read snapshot chunk", "height", height, "keys", totalKeys)
+			if err := helper.snapshotManager.SelfFinalize(encodeSnapshotNumKeys(numKeys, format, baseHeight)); err == nil {
+				helper.setLastSnapshotHeight(height)
+				helper.logger.Info("finish read snapshot chunk", "height", height, "keys", totalKeys, "format", format, "baseHeight", baseHeight)
 			} else {
 				helper.logger.Error("failed read snapshot chunk", "height", height, "keys", totalKeys, "err", err)
 			}
@@ -488,6 +574,34 @@ func (helper *StateSyncHelper) takeSnapshotImpl(height int64, retry int) {
 	}
 }
 
+// setLastSnapshotHeight records height as the base of the next incremental
+// snapshot, it is persisted so that a restarted node keeps the chain of
+// incremental snapshots
+func (helper *StateSyncHelper) setLastSnapshotHeight(height int64) {
+	if height <= helper.lastSnapshotHeight {
+		return
+	}
+	helper.lastSnapshotHeight = height
+	heightBytes, _ := cdc.MarshalBinaryLengthPrefixed(height) // Does not error
+	helper.db.SetSync([]byte(lastSnapshotHeightKey), heightBytes)
+}
+
+func getLastSnapshotHeight(db dbm.DB) int64 {
+	var height int64
+	heightBytes := db.Get([]byte(lastSnapshotHeightKey))
+	if heightBytes == nil {
+		return 0
+	}
+	if err := cdc.UnmarshalBinaryLengthPrefixed(heightBytes, &height); err != nil {
+		panic(err)
+	}
+	return height
+}
+
 func (helper *StateSyncHelper) finalizeAppStateChunk(startIdx int64, completeness uint8, nodes [][]byte) error {
+	nodes, err := encodeChunkNodes(helper.snapshotOptions.format(), nodes)
+	if err != nil {
+		return err
+	}
 	return helper.snapshotManager.WriteAppStateChunk(&abci.AppStateChunk{startIdx, completeness, nodes})
 }
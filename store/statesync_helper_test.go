@@ -0,0 +1,187 @@
+package store
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// snapshotNodes returns the nodes of the stores of ms at height the way
+// takeSnapshotImpl records them
+func snapshotNodes(t *testing.T, ms *rootMultiStore, height, baseHeight int64) (nodes [][]byte, numKeys []int64) {
+	for _, name := range []string{"store1", "store2", "store3"} {
+		tree, err := ms.getStoreByName(name).(*IavlStore).Tree.GetImmutable(height)
+		require.Nil(t, err)
+		var storeKeys int64
+		tree.IterateFirst(func(nodeBytes []byte) {
+			version, err := iavlNodeVersion(nodeBytes)
+			require.Nil(t, err)
+			if storeKeys > 0 && version <= baseHeight {
+				return
+			}
+			nodes = append(nodes, nodeBytes)
+			storeKeys++
+		})
+		numKeys = append(numKeys, storeKeys)
+	}
+	return nodes, numKeys
+}
+
+func TestStateSyncHelperRecovery(t *testing.T) {
+	source := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, source.LoadLatestVersion())
+	store1 := source.GetKVStore(source.keysByName["store1"])
+	store2 := source.GetKVStore(source.keysByName["store2"])
+	for i := 0; i < 100; i++ {
+		store1.Set([]byte{byte(i)}, []byte("value"))
+		store2.Set([]byte{byte(i)}, []byte("value"))
+	}
+	commitID1 := source.Commit()
+	store1.Set([]byte{0}, []byte("changed"))
+	commitID2 := source.Commit()
+
+	db := dbm.NewMemDB()
+	target := newMultiStoreWithMounts(db)
+	require.Nil(t, target.LoadLatestVersion())
+
+	restore := func(height, baseHeight int64, chunks []abci.AppStateChunk, numKeys []int64) {
+		manifest := &abci.Manifest{Height: height, NumKeys: encodeSnapshotNumKeys(numKeys, SnapshotFormatCompressed, baseHeight)}
+		for idx := range chunks {
+			manifest.AppStateHashes = append(manifest.AppStateHashes, sha256.Sum256([]byte{byte(idx)}))
+		}
+		helper := NewStateSyncHelper(log.NewNopLogger(), db, target, codec.New())
+		require.Nil(t, helper.StartRecovery(manifest))
+		for idx := range chunks {
+			err := helper.WriteRecoveryChunk(manifest.AppStateHashes[idx], &chunks[idx], idx == len(chunks)-1)
+			require.Nil(t, err)
+		}
+	}
+
+	// full snapshot, split into a complete chunk and a node split over 3 chunks
+	nodes, numKeys := snapshotNodes(t, source, 1, 0)
+	last := len(nodes) - 1
+	lastNode := nodes[last]
+	var chunks []abci.AppStateChunk
+	addChunk := func(startIdx int64, completeness uint8, nodes ...[]byte) {
+		encoded, err := encodeChunkNodes(SnapshotFormatCompressed, nodes)
+		require.Nil(t, err)
+		chunks = append(chunks, abci.AppStateChunk{StartIdx: startIdx, Completeness: completeness, Nodes: encoded})
+	}
+	addChunk(0, abci.Complete, nodes[:last-1]...)
+	addChunk(int64(last-1), abci.InComplete_First, nodes[last-1], lastNode[:2])
+	addChunk(int64(last), abci.InComplete_Mid, lastNode[2:4])
+	addChunk(int64(last), abci.InComplete_Last, lastNode[4:])
+	restore(1, 0, chunks, numKeys)
+
+	target = newMultiStoreWithMounts(db)
+	require.Nil(t, target.LoadLatestVersion())
+	require.Equal(t, commitID1, target.LastCommitID())
+
+	// incremental snapshot on top of the restored state
+	incrementalNodes, numKeys := snapshotNodes(t, source, 2, 1)
+	require.True(t, len(incrementalNodes) < len(nodes))
+	chunks = chunks[:0]
+	addChunk(0, abci.Complete, incrementalNodes...)
+	restore(2, 1, chunks, numKeys)
+
+	target = newMultiStoreWithMounts(db)
+	require.Nil(t, target.LoadLatestVersion())
+	require.Equal(t, commitID2, target.LastCommitID())
+	require.Equal(t, []byte("changed"), target.GetKVStore(target.keysByName["store1"]).Get([]byte{0}))
+	require.Equal(t, []byte("value"), target.GetKVStore(target.keysByName["store1"]).Get([]byte{99}))
+
+	// the base of an incremental snapshot must be present locally
+	helper := NewStateSyncHelper(log.NewNopLogger(), dbm.NewMemDB(), target, codec.New())
+	err := helper.StartRecovery(&abci.Manifest{Height: 2, NumKeys: encodeSnapshotNumKeys(numKeys, SnapshotFormatRaw, 1)})
+	require.NotNil(t, err)
+
+	// in every store, even if the commit info of the base height is kept
+	helper = NewStateSyncHelper(log.NewNopLogger(), db, target, codec.New())
+	require.Nil(t, helper.StartRecovery(&abci.Manifest{Height: 3, NumKeys: encodeSnapshotNumKeys(numKeys, SnapshotFormatRaw, 1)}))
+	tree := iavl.NewMutableTree(dbm.NewPrefixDB(db, []byte("s/k:store2/")), 0)
+	_, err = tree.Load()
+	require.Nil(t, err)
+	require.Nil(t, tree.DeleteVersion(1))
+	_, err = getCommitInfo(db, 1)
+	require.Nil(t, err)
+	err = helper.StartRecovery(&abci.Manifest{Height: 3, NumKeys: encodeSnapshotNumKeys(numKeys, SnapshotFormatRaw, 1)})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "store2")
+}
+
+func TestStateSyncHelperInvalidChunk(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	hashes := []abci.SHA256Sum{sha256.Sum256([]byte{0}), sha256.Sum256([]byte{1})}
+	helper := NewStateSyncHelper(log.NewNopLogger(), db, ms, codec.New())
+	err := helper.StartRecovery(&abci.Manifest{Height: 1, AppStateHashes: hashes, NumKeys: []int64{1, 1}})
+	require.NotNil(t, err)
+	err = helper.StartRecovery(&abci.Manifest{Height: 1, AppStateHashes: hashes, NumKeys: encodeSnapshotNumKeys([]int64{1, 1, 0}, SnapshotFormatCompressed, 0)})
+	require.Nil(t, err)
+
+	err = helper.WriteRecoveryChunk(sha256.Sum256([]byte{2}), &abci.AppStateChunk{}, false)
+	require.Contains(t, err.Error(), "is not in the manifest")
+
+	err = helper.WriteRecoveryChunk(hashes[1], &abci.AppStateChunk{Nodes: [][]byte{[]byte("not compressed")}}, false)
+	require.Contains(t, err.Error(), "app state chunk 1 ")
+
+	encoded, err := encodeChunkNodes(SnapshotFormatCompressed, [][]byte{[]byte("not a node")})
+	require.Nil(t, err)
+	err = helper.WriteRecoveryChunk(hashes[0], &abci.AppStateChunk{Nodes: encoded}, false)
+	require.Contains(t, err.Error(), "app state chunk 0 ")
+	require.Contains(t, err.Error(), "invalid node 0")
+
+	err = helper.WriteRecoveryChunk(hashes[0], &abci.AppStateChunk{StartIdx: 2, Nodes: encoded}, false)
+	require.Contains(t, err.Error(), "out of the 2 nodes")
+}
+
+func TestSnapshotFormat(t *testing.T) {
+	numKeys := []int64{3, 0, 5}
+	require.Equal(t, numKeys, encodeSnapshotNumKeys(numKeys, SnapshotFormatRaw, 0))
+
+	encoded := encodeSnapshotNumKeys(numKeys, SnapshotFormatCompressed, 10)
+	require.Equal(t, []int64{3, 0, 5}, numKeys)
+	storeNumKeys, format, baseHeight, err := decodeSnapshotNumKeys(encoded, 3)
+	require.Nil(t, err)
+	require.Equal(t, numKeys, storeNumKeys)
+	require.Equal(t, SnapshotFormatCompressed, format)
+	require.Equal(t, int64(10), baseHeight)
+
+	_, _, _, err = decodeSnapshotNumKeys(encoded, 2)
+	require.NotNil(t, err)
+	_, _, _, err = decodeSnapshotNumKeys([]int64{3, snapshotFormatMarker, 2, 0}, 1)
+	require.NotNil(t, err)
+
+	nodes := [][]byte{[]byte("node1"), {}, []byte("node3")}
+	chunkNodes, err := encodeChunkNodes(SnapshotFormatCompressed, nodes)
+	require.Nil(t, err)
+	require.Len(t, chunkNodes, 1)
+	decoded, err := decodeChunkNodes(SnapshotFormatCompressed, chunkNodes)
+	require.Nil(t, err)
+	require.Equal(t, nodes, decoded)
+}
+
+func TestLastSnapshotHeightPersisted(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	helper := NewStateSyncHelper(log.NewNopLogger(), db, ms, codec.New())
+	require.Equal(t, int64(0), getLastSnapshotHeight(db))
+	helper.setLastSnapshotHeight(100)
+	// a snapshot exported offline at a lower height is not the base of the next one
+	helper.setLastSnapshotHeight(50)
+	require.Equal(t, int64(100), helper.lastSnapshotHeight)
+
+	// a restarted node bases its next incremental snapshot on the last one
+	require.Equal(t, int64(100), getLastSnapshotHeight(db))
+}
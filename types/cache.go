@@ -36,3 +36,27 @@ func (d *DummyAccountCache) Cache() AccountCache {
 
 func (d *DummyAccountCache) Write() {
 }
+
+// AccountStoreCacheStats are the counters of an account store cache since it
+// was created
+type AccountStoreCacheStats struct {
+	Hits      uint64 // accounts got from the cache
+	Misses    uint64 // accounts got from the store
+	Evictions uint64 // accounts evicted to make space for others
+	Size      int    // number of cached accounts
+	Capacity  int    // max number of cached accounts
+}
+
+// InstrumentedAccountStoreCache is an AccountStoreCache reporting its stats,
+// whose capacity can be changed at runtime and which can be warmed with the
+// accounts about to be used.
+type InstrumentedAccountStoreCache interface {
+	AccountStoreCache
+
+	Stats() AccountStoreCacheStats
+	// Resize changes the capacity, evicting the least recently used accounts
+	// if there are too many
+	Resize(cap int)
+	// Prefetch loads the accounts not cached yet from the store
+	Prefetch(addrs []AccAddress)
+}
@@ -30,6 +30,8 @@ type Context struct {
 	sideChainKeyPrefix []byte
 	sideChainId        string
 	crossStake         bool
+	gasMeter           GasMeter
+	blockGasMeter      GasMeter
 }
 
 // create a new context
@@ -44,6 +46,8 @@ func NewContext(ms MultiStore, header abci.Header, runTxMode RunTxMode, logger l
 		logger:           logger,
 		routerCallRecord: make(map[string]bool),
 		eventManager:     NewEventManager(),
+		gasMeter:         NewInfiniteGasMeter(),
+		blockGasMeter:    NewInfiniteGasMeter(),
 	}
 }
 
@@ -123,6 +127,16 @@ func (c Context) CrossStake() bool {
 	return c.crossStake
 }
 
+// GasMeter returns the gas meter of the tx, the KVStores only consume its gas
+// once gas metering is enabled
+func (c Context) GasMeter() GasMeter {
+	return c.gasMeter
+}
+
+func (c Context) BlockGasMeter() GasMeter {
+	return c.blockGasMeter
+}
+
 //----------------------------------------
 // With* (setting a value)
 
@@ -234,6 +248,16 @@ func (c Context) WithCrossStake(crossStake bool) Context {
 	return c
 }
 
+func (c Context) WithGasMeter(meter GasMeter) Context {
+	c.gasMeter = meter
+	return c
+}
+
+func (c Context) WithBlockGasMeter(meter GasMeter) Context {
+	c.blockGasMeter = meter
+	return c
+}
+
 // is context nil
 func (c Context) IsZero() bool {
 	return c.ctx == nil && c.ms == nil
@@ -36,6 +36,13 @@ const (
 	SynCrossChainPackageType     CrossChainPackageType = 0x00
 	AckCrossChainPackageType     CrossChainPackageType = 0x01
 	FailAckCrossChainPackageType CrossChainPackageType = 0x02
+
+	// The packages of the following types carry a timeout header after the package header, they are
+	// the syn packages sent with a timeout and the ack and fail ack packages answering them.
+	// They are only used after the IBCPackageTimeout upgrade.
+	TimeoutSynCrossChainPackageType     CrossChainPackageType = 0x03
+	TimeoutAckCrossChainPackageType     CrossChainPackageType = 0x04
+	TimeoutFailAckCrossChainPackageType CrossChainPackageType = 0x05
 )
 
 type ChannelPermission uint8
@@ -56,6 +63,20 @@ func IsValidCrossChainPackageType(packageType CrossChainPackageType) bool {
 	return packageType == SynCrossChainPackageType || packageType == AckCrossChainPackageType || packageType == FailAckCrossChainPackageType
 }
 
+// IsTimeoutCrossChainPackageType tells whether the packages of the type carry a timeout header
+func IsTimeoutCrossChainPackageType(packageType CrossChainPackageType) bool {
+	return packageType == TimeoutSynCrossChainPackageType || packageType == TimeoutAckCrossChainPackageType || packageType == TimeoutFailAckCrossChainPackageType
+}
+
+// WithoutTimeout returns the package type that a package of the given type is executed and decoded as once its
+// timeout header is stripped
+func WithoutTimeout(packageType CrossChainPackageType) CrossChainPackageType {
+	if IsTimeoutCrossChainPackageType(packageType) {
+		return packageType - TimeoutSynCrossChainPackageType
+	}
+	return packageType
+}
+
 func ParseChannelID(input string) (ChannelID, error) {
 	channelID, err := strconv.Atoi(input)
 	if err != nil {
@@ -85,6 +106,21 @@ type CrossChainApplication interface {
 	ExecuteFailAckPackage(ctx Context, payload []byte) ExecuteResult
 }
 
+// CrossChainPackageValuer can be implemented by a CrossChainApplication to report the value carried by
+// a package of its channel, the value is accounted against the rate limit of the channel.
+// The payload does not include the package header.
+type CrossChainPackageValuer interface {
+	PackageValue(packageType CrossChainPackageType, payload []byte) int64
+}
+
+// CrossChainTimeoutApplication can be implemented by a CrossChainApplication to be notified when a package
+// it sent with a timeout is not confirmed in time, e.g. to refund the user. The payload does not include the package header.
+// The packages sent with a timeout are TimeoutSynCrossChainPackageType packages, which carry their timeout to the side
+// chain, and an answer to a package which has timed out is rejected before it reaches the application.
+type CrossChainTimeoutApplication interface {
+	ExecuteTimeoutPackage(ctx Context, payload []byte) ExecuteResult
+}
+
 type ExecuteResult struct {
 	Err     Error
 	Tags    Tags
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+)
+
+// CrossChainPackageDecodeFunc decodes the payload of a package, which does not include the package header.
+type CrossChainPackageDecodeFunc func(payload []byte) (interface{}, error)
+
+// CrossChainPackageValidator can be implemented by a typed package to be validated after it is decoded.
+type CrossChainPackageValidator interface {
+	ValidateBasic() error
+}
+
+// CrossChainPackageTypesRegistrar can be implemented by a CrossChainApplication to register the typed packages
+// of its channel when the channel is registered.
+type CrossChainPackageTypesRegistrar interface {
+	RegisterPackageTypes(codec *CrossChainPackageCodec, channelID ChannelID) error
+}
+
+// CrossChainPackageCodec maps the packages of every channel and package type to typed Go structs
+type CrossChainPackageCodec struct {
+	decoders map[ChannelID]map[CrossChainPackageType]CrossChainPackageDecodeFunc
+}
+
+func NewCrossChainPackageCodec() *CrossChainPackageCodec {
+	return &CrossChainPackageCodec{
+		decoders: make(map[ChannelID]map[CrossChainPackageType]CrossChainPackageDecodeFunc),
+	}
+}
+
+// RegisterPackageType registers the struct that the packages are RLP decoded into, pack is a value or a pointer
+// of the struct and the decoded packages are pointers of it.
+func (c *CrossChainPackageCodec) RegisterPackageType(channelID ChannelID, packageType CrossChainPackageType, pack interface{}) error {
+	t := reflect.TypeOf(pack)
+	if t == nil {
+		return fmt.Errorf("package type of channel %d can not be nil", channelID)
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return c.RegisterPackageDecoder(channelID, packageType, func(payload []byte) (interface{}, error) {
+		decoded := reflect.New(t).Interface()
+		if err := rlp.DecodeBytes(payload, decoded); err != nil {
+			return nil, err
+		}
+		return decoded, nil
+	})
+}
+
+// RegisterPackageDecoder registers a decode function for the packages that are not decoded into a single struct,
+// e.g. the packages that carry one of several event types.
+func (c *CrossChainPackageCodec) RegisterPackageDecoder(channelID ChannelID, packageType CrossChainPackageType, decode CrossChainPackageDecodeFunc) error {
+	if !IsValidCrossChainPackageType(packageType) {
+		return fmt.Errorf("invalid package type %d", packageType)
+	}
+	if c.IsRegistered(channelID, packageType) {
+		return fmt.Errorf("package type %d of channel %d is already registered", packageType, channelID)
+	}
+	if _, ok := c.decoders[channelID]; !ok {
+		c.decoders[channelID] = make(map[CrossChainPackageType]CrossChainPackageDecodeFunc)
+	}
+	c.decoders[channelID][packageType] = decode
+	return nil
+}
+
+func (c *CrossChainPackageCodec) IsRegistered(channelID ChannelID, packageType CrossChainPackageType) bool {
+	_, ok := c.decoders[channelID][packageType]
+	return ok
+}
+
+// Decode decodes the payload of a package into the registered type and validates it
+func (c *CrossChainPackageCodec) Decode(channelID ChannelID, packageType CrossChainPackageType, payload []byte) (interface{}, error) {
+	decode, ok := c.decoders[channelID][packageType]
+	if !ok {
+		return nil, fmt.Errorf("package type %d of channel %d is not registered", packageType, channelID)
+	}
+	pack, err := decode(payload)
+	if err != nil {
+		return nil, err
+	}
+	if validator, ok := pack.(CrossChainPackageValidator); ok {
+		if err := validator.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	return pack, nil
+}
@@ -1,10 +1,12 @@
 package types_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	"github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -29,3 +31,41 @@ func TestParseCrossChainID(t *testing.T) {
 	_, err = types.ParseChainID("65536")
 	require.Error(t, err)
 }
+
+type testTransferPackage struct {
+	Receiver []byte
+	Amount   uint64
+}
+
+func (p testTransferPackage) ValidateBasic() error {
+	if p.Amount == 0 {
+		return fmt.Errorf("amount should be positive")
+	}
+	return nil
+}
+
+func TestCrossChainPackageCodec(t *testing.T) {
+	codec := types.NewCrossChainPackageCodec()
+	require.NoError(t, codec.RegisterPackageType(1, types.SynCrossChainPackageType, testTransferPackage{}))
+	require.Error(t, codec.RegisterPackageType(1, types.SynCrossChainPackageType, &testTransferPackage{}))
+	require.Error(t, codec.RegisterPackageType(1, types.CrossChainPackageType(3), testTransferPackage{}))
+	require.True(t, codec.IsRegistered(1, types.SynCrossChainPackageType))
+	require.False(t, codec.IsRegistered(1, types.AckCrossChainPackageType))
+
+	payload, err := rlp.EncodeToBytes(testTransferPackage{Receiver: []byte{1, 2}, Amount: 10})
+	require.NoError(t, err)
+	pack, err := codec.Decode(1, types.SynCrossChainPackageType, payload)
+	require.NoError(t, err)
+	require.Equal(t, &testTransferPackage{Receiver: []byte{1, 2}, Amount: 10}, pack)
+
+	_, err = codec.Decode(2, types.SynCrossChainPackageType, payload)
+	require.Error(t, err)
+	_, err = codec.Decode(1, types.SynCrossChainPackageType, []byte{1})
+	require.Error(t, err)
+
+	// decoded packages are validated
+	payload, err = rlp.EncodeToBytes(testTransferPackage{Receiver: []byte{1, 2}})
+	require.NoError(t, err)
+	_, err = codec.Decode(1, types.SynCrossChainPackageType, payload)
+	require.Error(t, err)
+}
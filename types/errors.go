@@ -59,6 +59,8 @@ const (
 	CodeMsgNotSupported     CodeType = 14
 	CodeInvalidAccountFlags CodeType = 15
 	CodeInvalidTxMemo       CodeType = 16
+	CodeTxLaneFull          CodeType = 17
+	CodeOutOfGas            CodeType = 18
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -109,6 +111,10 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "account flags is invalid"
 	case CodeInvalidTxMemo:
 		return "transaction memo is invalid"
+	case CodeTxLaneFull:
+		return "transaction lane is full"
+	case CodeOutOfGas:
+		return "out of gas"
 	default:
 		return unknownCodeMsg(code)
 	}
@@ -164,6 +170,12 @@ func ErrInvalidAccountFlags(msg string) Error {
 func ErrInvalidTxMemo(msg string) Error {
 	return newErrorWithRootCodespace(CodeInvalidTxMemo, msg)
 }
+func ErrTxLaneFull(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxLaneFull, msg)
+}
+func ErrOutOfGas(msg string) Error {
+	return newErrorWithRootCodespace(CodeOutOfGas, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
@@ -1,6 +1,8 @@
 package fees
 
 import (
+	"math"
+
 	"github.com/cosmos/cosmos-sdk/types"
 	param "github.com/cosmos/cosmos-sdk/x/paramHub/types"
 )
@@ -8,7 +10,12 @@ import (
 type FeeCalculator func(msg types.Msg) types.Fee
 type FeeCalculatorGenerator func(params param.FeeParam) FeeCalculator
 
+// GasFeeCalculator calculates the fee of a msg from the gas used to deliver it,
+// it is only used once gas is metered.
+type GasFeeCalculator func(msg types.Msg, gasUsed types.Gas) types.Fee
+
 var calculators = make(map[string]FeeCalculator)
+var gasCalculators = make(map[string]GasFeeCalculator)
 var CalculatorsGen = make(map[string]FeeCalculatorGenerator)
 
 func RegisterCalculator(msgType string, feeCalc FeeCalculator) {
@@ -23,10 +30,36 @@ func GetCalculator(msgType string) FeeCalculator {
 	return calculators[msgType]
 }
 
+func RegisterGasCalculator(msgType string, feeCalc GasFeeCalculator) {
+	gasCalculators[msgType] = feeCalc
+}
+
+func GetGasCalculator(msgType string) GasFeeCalculator {
+	return gasCalculators[msgType]
+}
+
 func UnsetAllCalculators() {
 	for key := range calculators {
 		delete(calculators, key)
 	}
+	for key := range gasCalculators {
+		delete(gasCalculators, key)
+	}
+}
+
+// CalculateFee returns the fee of a msg whose delivery used gasUsed: once gas
+// is metered, the gas calculator of the msg type is used if it is registered,
+// otherwise the fee calculator. It returns false if the msg type has neither.
+func CalculateFee(msg types.Msg, gasUsed types.Gas) (types.Fee, bool) {
+	if types.IsUpgrade(types.GasMetering) {
+		if calculator := GetGasCalculator(msg.Type()); calculator != nil {
+			return calculator(msg, gasUsed), true
+		}
+	}
+	if calculator := GetCalculator(msg.Type()); calculator != nil {
+		return calculator(msg), true
+	}
+	return types.Fee{}, false
 }
 
 func FixedFeeCalculator(amount int64, feeType types.FeeDistributeType) FeeCalculator {
@@ -38,9 +71,32 @@ func FixedFeeCalculator(amount int64, feeType types.FeeDistributeType) FeeCalcul
 	}
 }
 
+// FixedAndGasFeeCalculator adds the gas used at gasPrice to the fee of the
+// fixed fee calculator, a free fee stays free
+func FixedAndGasFeeCalculator(fixed FeeCalculator, gasPrice int64) GasFeeCalculator {
+	return func(msg types.Msg, gasUsed types.Gas) types.Fee {
+		fee := fixed(msg)
+		if fee.Type == types.FeeFree || gasPrice <= 0 || gasUsed == 0 {
+			return fee
+		}
+		gasFee := gasFeeAmount(gasUsed, gasPrice, fee.Tokens.AmountOf(types.NativeTokenSymbol))
+		return types.NewFee(fee.Tokens.Plus(types.Coins{types.NewCoin(types.NativeTokenSymbol, gasFee)}), fee.Type)
+	}
+}
+
+// gasFeeAmount returns gasUsed * gasPrice, capped so that adding it to the
+// fixed fee does not overflow
+func gasFeeAmount(gasUsed types.Gas, gasPrice int64, fixedFee int64) int64 {
+	max := math.MaxInt64 - fixedFee
+	if gasUsed > uint64(max/gasPrice) {
+		return max
+	}
+	return int64(gasUsed) * gasPrice
+}
+
 func FreeFeeCalculator() FeeCalculator {
 	return func(msg types.Msg) types.Fee {
-		return types.NewFee(append(types.Coins{}), types.FeeFree)
+		return types.NewFee(types.Coins{}, types.FeeFree)
 	}
 }
 
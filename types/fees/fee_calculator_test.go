@@ -1,6 +1,7 @@
 package fees
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -54,6 +55,51 @@ func TestRegisterAndGetCalculators(t *testing.T) {
 	require.Nil(t, GetCalculator(msg.Type()))
 }
 
+func TestFixedAndGasFeeCalculator(t *testing.T) {
+	_, addr := privAndAddr()
+	msg := types.NewTestMsg(addr)
+
+	calculator := FixedAndGasFeeCalculator(FixedFeeCalculator(10, types.FeeForProposer), 2)
+	fee := calculator(msg, 100)
+	require.Equal(t, types.FeeForProposer, fee.Type)
+	require.Equal(t, types.Coins{types.NewCoin(types.NativeTokenSymbol, 210)}, fee.Tokens)
+	fee = calculator(msg, math.MaxUint64)
+	require.Equal(t, types.Coins{types.NewCoin(types.NativeTokenSymbol, math.MaxInt64)}, fee.Tokens)
+
+	calculator = FixedAndGasFeeCalculator(FreeFeeCalculator(), 2)
+	fee = calculator(msg, 100)
+	require.Equal(t, types.FeeFree, fee.Type)
+	require.Equal(t, types.Coins{}, fee.Tokens)
+}
+
+func TestCalculateFee(t *testing.T) {
+	defer types.UpgradeMgr.Reset()
+	defer UnsetAllCalculators()
+	_, addr := privAndAddr()
+	msg := types.NewTestMsg(addr)
+
+	_, ok := CalculateFee(msg, 100)
+	require.False(t, ok)
+
+	fixed := FixedFeeCalculator(10, types.FeeForAll)
+	RegisterCalculator(msg.Type(), fixed)
+	RegisterGasCalculator(msg.Type(), FixedAndGasFeeCalculator(fixed, 1))
+	types.UpgradeMgr.AddUpgradeHeight(types.GasMetering, 10)
+
+	// the gas calculator is only used once gas is metered
+	types.UpgradeMgr.SetHeight(9)
+	fee, ok := CalculateFee(msg, 100)
+	require.True(t, ok)
+	require.Equal(t, types.Coins{types.NewCoin(types.NativeTokenSymbol, 10)}, fee.Tokens)
+	types.UpgradeMgr.SetHeight(10)
+	fee, ok = CalculateFee(msg, 100)
+	require.True(t, ok)
+	require.Equal(t, types.Coins{types.NewCoin(types.NativeTokenSymbol, 110)}, fee.Tokens)
+
+	UnsetAllCalculators()
+	require.Nil(t, GetGasCalculator(msg.Type()))
+}
+
 func privAndAddr() (crypto.PrivKey, types.AccAddress) {
 	priv := secp256k1.GenPrivKey()
 	addr := types.AccAddress(priv.PubKey().Address())
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"math"
+)
+
+// Gas consumption descriptors of the stores
+const (
+	GasIterNextCostFlatDesc = "IterNextFlat"
+	GasValuePerByteDesc     = "ValuePerByte"
+	GasWritePerByteDesc     = "WritePerByte"
+	GasReadPerByteDesc      = "ReadPerByte"
+	GasWriteCostFlatDesc    = "WriteFlat"
+	GasReadCostFlatDesc     = "ReadFlat"
+	GasHasDesc              = "Has"
+	GasDeleteDesc           = "Delete"
+)
+
+// Gas measures the computation and storage a tx costs
+type Gas = uint64
+
+// ErrorOutOfGas is the panic raised when a gas meter runs past its limit
+type ErrorOutOfGas struct {
+	Descriptor string
+}
+
+// ErrorGasOverflow is the panic raised when the gas consumed overflows
+type ErrorGasOverflow struct {
+	Descriptor string
+}
+
+// GasMeter tracks the gas consumed by a tx or a block
+type GasMeter interface {
+	GasConsumed() Gas
+	// GasConsumedToLimit returns the gas consumed, capped at the limit
+	GasConsumedToLimit() Gas
+	Limit() Gas
+	// ConsumeGas panics with ErrorOutOfGas once the limit is exceeded
+	ConsumeGas(amount Gas, descriptor string)
+	IsPastLimit() bool
+	IsOutOfGas() bool
+}
+
+type basicGasMeter struct {
+	limit    Gas
+	consumed Gas
+}
+
+// NewGasMeter returns a gas meter with a limit
+func NewGasMeter(limit Gas) GasMeter {
+	return &basicGasMeter{
+		limit: limit,
+	}
+}
+
+func (g *basicGasMeter) GasConsumed() Gas {
+	return g.consumed
+}
+
+func (g *basicGasMeter) GasConsumedToLimit() Gas {
+	if g.IsPastLimit() {
+		return g.limit
+	}
+	return g.consumed
+}
+
+func (g *basicGasMeter) Limit() Gas {
+	return g.limit
+}
+
+func (g *basicGasMeter) ConsumeGas(amount Gas, descriptor string) {
+	var overflow bool
+	g.consumed, overflow = addUint64Overflow(g.consumed, amount)
+	if overflow {
+		panic(ErrorGasOverflow{descriptor})
+	}
+	if g.consumed > g.limit {
+		panic(ErrorOutOfGas{descriptor})
+	}
+}
+
+func (g *basicGasMeter) IsPastLimit() bool {
+	return g.consumed > g.limit
+}
+
+func (g *basicGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
+type infiniteGasMeter struct {
+	consumed Gas
+}
+
+// NewInfiniteGasMeter returns a gas meter without limit
+func NewInfiniteGasMeter() GasMeter {
+	return &infiniteGasMeter{}
+}
+
+func (g *infiniteGasMeter) GasConsumed() Gas {
+	return g.consumed
+}
+
+func (g *infiniteGasMeter) GasConsumedToLimit() Gas {
+	return g.consumed
+}
+
+func (g *infiniteGasMeter) Limit() Gas {
+	return 0
+}
+
+func (g *infiniteGasMeter) ConsumeGas(amount Gas, descriptor string) {
+	var overflow bool
+	g.consumed, overflow = addUint64Overflow(g.consumed, amount)
+	if overflow {
+		panic(ErrorGasOverflow{descriptor})
+	}
+}
+
+func (g *infiniteGasMeter) IsPastLimit() bool {
+	return false
+}
+
+func (g *infiniteGasMeter) IsOutOfGas() bool {
+	return false
+}
+
+func addUint64Overflow(a, b uint64) (uint64, bool) {
+	if math.MaxUint64-a < b {
+		return 0, true
+	}
+	return a + b, false
+}
+
+// GasConfig defines the gas cost of each operation on a KVStore
+type GasConfig struct {
+	HasCost          Gas
+	DeleteCost       Gas
+	ReadCostFlat     Gas
+	ReadCostPerByte  Gas
+	WriteCostFlat    Gas
+	WriteCostPerByte Gas
+	IterNextCostFlat Gas
+}
+
+// KVGasConfig returns the default gas costs of the KVStores
+func KVGasConfig() GasConfig {
+	return GasConfig{
+		HasCost:          1000,
+		DeleteCost:       1000,
+		ReadCostFlat:     1000,
+		ReadCostPerByte:  3,
+		WriteCostFlat:    2000,
+		WriteCostPerByte: 30,
+		IterNextCostFlat: 30,
+	}
+}
@@ -18,6 +18,11 @@ type Result struct {
 	FeeAmount int64
 	FeeDenom  string
 
+	// GasWanted is the gas limit of the tx, GasUsed the gas it consumed. Both
+	// are 0 until gas metering is enabled.
+	GasWanted uint64
+	GasUsed   uint64
+
 	// Tags are used for transaction indexing and pubsub.
 	Tags   Tags
 	Events Events
@@ -12,20 +12,80 @@ import (
 
 // NOTE: These are implemented in cosmos-sdk/store.
 
-// PruningStrategy specfies how old states will be deleted over time
-type PruningStrategy uint8
+// PruningOptions specifies how old states will be deleted over time
+type PruningOptions struct {
+	// KeepRecent is the number of recent states to keep
+	KeepRecent int64 `json:"keep_recent"`
+	// KeepEvery keeps every KeepEvery-th state forever, 0 keeps none of them and 1 keeps all states
+	KeepEvery int64 `json:"keep_every"`
+	// Interval is the number of blocks between two prunings, the states released in between are deleted together
+	Interval int64 `json:"interval"`
+}
 
+// Names of the preset pruning strategies, a custom strategy uses the given options
 const (
-	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100 + every 10000th)
-	PruneSyncable PruningStrategy = iota
+	PruningStrategySyncable   = "syncable"
+	PruningStrategyEverything = "everything"
+	PruningStrategyNothing    = "nothing"
+	PruningStrategyCustom     = "custom"
+)
+
+var (
+	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100000 + every 100000th)
+	// fork github.com/cosmos/cosmos-sdk/blob/9a16e2675f392b083dd1074ff92ff1f9fbda750d/store/types/pruning.go#L34
+	PruneSyncable = PruningOptions{KeepRecent: 100000, KeepEvery: 100000, Interval: 1}
 
 	// PruneEverything means all saved states will be deleted, storing only the current state
-	PruneEverything PruningStrategy = iota
+	PruneEverything = PruningOptions{KeepRecent: 0, KeepEvery: 0, Interval: 1}
 
 	// PruneNothing means all historic states will be saved, nothing will be deleted
-	PruneNothing PruningStrategy = iota
+	PruneNothing = PruningOptions{KeepRecent: 0, KeepEvery: 1, Interval: 1}
 )
 
+func NewPruningOptions(keepRecent, keepEvery, interval int64) PruningOptions {
+	return PruningOptions{
+		KeepRecent: keepRecent,
+		KeepEvery:  keepEvery,
+		Interval:   interval,
+	}
+}
+
+// NewPruningOptionsFromString returns the options of a preset pruning strategy
+func NewPruningOptionsFromString(strategy string) (PruningOptions, error) {
+	switch strategy {
+	case PruningStrategySyncable:
+		return PruneSyncable, nil
+	case PruningStrategyEverything:
+		return PruneEverything, nil
+	case PruningStrategyNothing:
+		return PruneNothing, nil
+	default:
+		return PruningOptions{}, fmt.Errorf("invalid pruning strategy: %s", strategy)
+	}
+}
+
+func (po PruningOptions) Validate() error {
+	if po.KeepRecent < 0 {
+		return fmt.Errorf("pruning keep-recent should not be negative, got %d", po.KeepRecent)
+	}
+	if po.KeepEvery < 0 {
+		return fmt.Errorf("pruning keep-every should not be negative, got %d", po.KeepEvery)
+	}
+	if po.Interval < 1 {
+		return fmt.Errorf("pruning interval should be positive, got %d", po.Interval)
+	}
+	return nil
+}
+
+// KeepVersion returns true if the state of version should be kept when latest is the latest saved version
+func (po PruningOptions) KeepVersion(version, latest int64) bool {
+	return version >= latest-po.KeepRecent || (po.KeepEvery != 0 && version%po.KeepEvery == 0)
+}
+
+func (po PruningOptions) String() string {
+	return fmt.Sprintf("keep-recent=%d, keep-every=%d, interval=%d", po.KeepRecent, po.KeepEvery, po.Interval)
+}
+
 type Store interface { //nolint
 	GetStoreType() StoreType
 	CacheWrapper
@@ -35,7 +95,7 @@ type Store interface { //nolint
 type Committer interface {
 	Commit() CommitID
 	LastCommitID() CommitID
-	SetPruning(PruningStrategy)
+	SetPruning(PruningOptions)
 	SetVersion(version int64)
 }
 
@@ -94,6 +154,12 @@ type CacheMultiStore interface {
 	Write() // Writes operations to underlying KVStore
 }
 
+// WriteListener is notified of every key set or deleted in a store, value is
+// nil when the key is deleted.
+type WriteListener interface {
+	OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool)
+}
+
 // A non-cache MultiStore.
 type CommitMultiStore interface {
 	Committer
@@ -120,6 +186,10 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Cache wrap the state committed at version, for queries. Returns an
+	// error if the version is not committed or was pruned.
+	CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error)
 }
 
 //---------subsp-------------------------------
@@ -37,3 +37,24 @@ func TestCommitID(t *testing.T) {
 	}
 	require.False(t, nonempty.IsZero())
 }
+
+func TestPruningOptions(t *testing.T) {
+	opts, err := NewPruningOptionsFromString(PruningStrategySyncable)
+	require.Nil(t, err)
+	require.Equal(t, PruneSyncable, opts)
+	_, err = NewPruningOptionsFromString(PruningStrategyCustom)
+	require.NotNil(t, err)
+
+	require.Nil(t, NewPruningOptions(10, 0, 5).Validate())
+	require.NotNil(t, NewPruningOptions(-1, 0, 5).Validate())
+	require.NotNil(t, NewPruningOptions(10, -1, 5).Validate())
+	require.NotNil(t, NewPruningOptions(10, 0, 0).Validate())
+
+	opts = NewPruningOptions(10, 100, 1)
+	require.True(t, opts.KeepVersion(1000, 1000))
+	require.True(t, opts.KeepVersion(990, 1000))
+	require.False(t, opts.KeepVersion(989, 1000))
+	require.True(t, opts.KeepVersion(900, 1000))
+	require.True(t, PruneNothing.KeepVersion(1, 1000))
+	require.False(t, PruneEverything.KeepVersion(999, 1000))
+}
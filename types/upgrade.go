@@ -22,6 +22,19 @@ const (
 	LimitConsAddrUpdateInterval = "LimitConsAddrUpdateInterval"
 	BEP173                      = "BEP173" // https://github.com/bnb-chain/BEPs/pull/173
 	FixDoubleSignChainId        = "FixDoubleSignChainId"
+	OracleVoteStrategy          = "OracleVoteStrategy"  // select the vote strategy of oracle prophecies per destination chain
+	ProphecyExpiry              = "ProphecyExpiry"      // prune oracle prophecies that do not finalize within the expiry window
+	OracleRelayerReward         = "OracleRelayerReward" // track oracle relayer performance and share relay fees with relayers
+	OracleBatchClaim            = "OracleBatchClaim"    // claim packages of consecutive sequences in one oracle message
+	GasMetering                 = "GasMetering"         // meter the gas used by the txs and limit the gas of a block
+	LiquidStaking               = "LiquidStaking"       // mint transferable receipts for side chain delegations
+	AutoCompound                = "AutoCompound"        // delegate back the rewards of the delegations which opt in
+	CommissionSchedule          = "CommissionSchedule"  // queue the commission rate changes of side chain validators for some breathe blocks
+	SlashInsurance              = "SlashInsurance"      // reimburse the slash losses of delegators from the insurance pools of their validators
+	IBCPackageMeta              = "IBCPackageMeta"      // record the creation height and time of outbound ibc packages
+	ChannelRateLimit            = "ChannelRateLimit"    // limit the value going through the cross chain channels and pause the channels exceeding it
+	IBCPackageTimeout           = "IBCPackageTimeout"   // send syn packages with a timeout and report the packages which time out to their applications
+	SideChainEvidence           = "SideChainEvidence"   // accept the double sign evidence of any registered side chain
 )
 
 var MainNetConfig = UpgradeConfig{
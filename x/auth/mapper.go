@@ -3,6 +3,7 @@ package auth
 import (
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hashicorp/golang-lru"
 	"github.com/tendermint/tendermint/crypto"
@@ -201,9 +202,10 @@ func NewAccountStoreCache(cdc *codec.Codec, store sdk.KVStore, cap int) sdk.Acco
 	}
 
 	return &accountStoreCache{
-		cdc:   cdc,
-		cache: cache,
-		store: store,
+		cdc:      cdc,
+		cache:    cache,
+		store:    store,
+		capacity: int64(cap),
 	}
 }
 
@@ -211,8 +213,16 @@ type accountStoreCache struct {
 	cdc   *codec.Codec
 	cache *lru.Cache
 	store sdk.KVStore
+
+	// stats, updated atomically
+	hits      uint64
+	misses    uint64
+	evictions uint64
+	capacity  int64
 }
 
+var _ sdk.InstrumentedAccountStoreCache = (*accountStoreCache)(nil)
+
 func (ac *accountStoreCache) getAccountFromCache(addr sdk.AccAddress) (acc sdk.Account, ok bool) {
 	cacc, ok := ac.cache.Get(string(addr))
 	if !ok {
@@ -225,13 +235,17 @@ func (ac *accountStoreCache) getAccountFromCache(addr sdk.AccAddress) (acc sdk.A
 }
 
 func (ac *accountStoreCache) setAccountToCache(addr sdk.AccAddress, acc sdk.Account) {
-	ac.cache.Add(string(addr), acc.Clone())
+	if ac.cache.Add(string(addr), acc.Clone()) {
+		atomic.AddUint64(&ac.evictions, 1)
+	}
 }
 
 func (ac *accountStoreCache) GetAccount(addr sdk.AccAddress) sdk.Account {
 	if acc, ok := ac.getAccountFromCache(addr); ok {
+		atomic.AddUint64(&ac.hits, 1)
 		return acc
 	}
+	atomic.AddUint64(&ac.misses, 1)
 
 	bz := ac.store.Get(AddressStoreKey(addr))
 	if bz == nil {
@@ -262,6 +276,40 @@ func (ac *accountStoreCache) ClearCache() {
 	ac.cache.Purge()
 }
 
+func (ac *accountStoreCache) Stats() sdk.AccountStoreCacheStats {
+	return sdk.AccountStoreCacheStats{
+		Hits:      atomic.LoadUint64(&ac.hits),
+		Misses:    atomic.LoadUint64(&ac.misses),
+		Evictions: atomic.LoadUint64(&ac.evictions),
+		Size:      ac.cache.Len(),
+		Capacity:  int(atomic.LoadInt64(&ac.capacity)),
+	}
+}
+
+func (ac *accountStoreCache) Resize(cap int) {
+	if cap <= 0 {
+		panic("account store cache capacity must be positive")
+	}
+	evicted := ac.cache.Resize(cap)
+	atomic.AddUint64(&ac.evictions, uint64(evicted))
+	atomic.StoreInt64(&ac.capacity, int64(cap))
+}
+
+// Prefetch does not count hits nor misses, so that the stats reflect the
+// accounts actually used
+func (ac *accountStoreCache) Prefetch(addrs []sdk.AccAddress) {
+	for _, addr := range addrs {
+		if ac.cache.Contains(string(addr)) {
+			continue
+		}
+		bz := ac.store.Get(AddressStoreKey(addr))
+		if bz == nil {
+			continue
+		}
+		ac.setAccountToCache(addr, ac.decodeAccount(bz))
+	}
+}
+
 func (ac *accountStoreCache) encodeAccount(acc sdk.Account) []byte {
 	bz, err := ac.cdc.MarshalBinaryBare(acc)
 	if err != nil {
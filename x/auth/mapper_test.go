@@ -106,6 +106,40 @@ func TestAccountMapperRemoveAccount(t *testing.T) {
 	require.Equal(t, accSeq2, acc2.GetSequence())
 }
 
+func TestAccountStoreCacheStats(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStore := ms.GetKVStore(capKey)
+
+	var addrs []sdk.AccAddress
+	writer := NewAccountStoreCache(cdc, accountStore, 10)
+	for i := 0; i < 4; i++ {
+		addr := sdk.AccAddress([]byte{byte(i)})
+		acc := NewBaseAccountWithAddress(addr)
+		writer.SetAccount(addr, &acc)
+		addrs = append(addrs, addr)
+	}
+
+	cache := NewAccountStoreCache(cdc, accountStore, 2).(sdk.InstrumentedAccountStoreCache)
+	require.NotNil(t, cache.GetAccount(addrs[0]))
+	require.NotNil(t, cache.GetAccount(addrs[0]))
+	require.Nil(t, cache.GetAccount(sdk.AccAddress([]byte("unknown"))))
+	require.Equal(t, sdk.AccountStoreCacheStats{Hits: 1, Misses: 2, Size: 1, Capacity: 2}, cache.Stats())
+
+	// prefetched accounts are not counted as read
+	cache.Prefetch(addrs[1:3])
+	require.Equal(t, sdk.AccountStoreCacheStats{Hits: 1, Misses: 2, Evictions: 1, Size: 2, Capacity: 2}, cache.Stats())
+	require.NotNil(t, cache.GetAccount(addrs[2]))
+	require.Equal(t, uint64(2), cache.Stats().Hits)
+
+	cache.Resize(4)
+	cache.Prefetch(addrs)
+	require.Equal(t, sdk.AccountStoreCacheStats{Hits: 2, Misses: 2, Evictions: 1, Size: 4, Capacity: 4}, cache.Stats())
+	cache.Resize(1)
+	require.Equal(t, sdk.AccountStoreCacheStats{Hits: 2, Misses: 2, Evictions: 4, Size: 1, Capacity: 1}, cache.Stats())
+}
+
 func BenchmarkAccountMapperGetAccountFound(b *testing.B) {
 	ms, capKey, _ := setupMultiStore()
 	cdc := codec.New()
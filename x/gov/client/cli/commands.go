@@ -37,6 +37,8 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 			GetCmdQueryDeposits(storeGov, cdc),
 			GetCmdQueryVote(storeGov, cdc),
 			GetCmdQueryVotes(storeGov, cdc),
+			GetCmdQueryTally(storeGov, cdc),
+			GetCmdQueryTallyDetail(storeGov, cdc),
 		)...,
 	)
 	cmd.AddCommand(govCmd)
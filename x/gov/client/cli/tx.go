@@ -627,6 +627,41 @@ func GetCmdQueryTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryTallyDetail implements the command to query the detailed tally of a proposal.
+func GetCmdQueryTallyDetail(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tally-detail",
+		Short: "Get the tally of a proposal vote with the votes and voting power of each validator and delegator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			proposalID := viper.GetInt64(flagProposalID)
+			sideChainId := viper.GetString(flagSideChainId)
+
+			params := gov.QueryTallyParams{
+				BaseParams: gov.NewBaseParams(sideChainId),
+				ProposalID: proposalID,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryTallyDetail), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal is being tallied")
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
+
+	return cmd
+}
+
 // GetCmdSubmitListProposal implements submitting a proposal transaction command.
 func GetCmdSubmitListProposal(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -10,13 +10,14 @@ import (
 
 // query endpoints supported by the governance Querier
 const (
-	QueryProposals = "proposals"
-	QueryProposal  = "proposal"
-	QueryDeposits  = "deposits"
-	QueryDeposit   = "deposit"
-	QueryVotes     = "votes"
-	QueryVote      = "vote"
-	QueryTally     = "tally"
+	QueryProposals   = "proposals"
+	QueryProposal    = "proposal"
+	QueryDeposits    = "deposits"
+	QueryDeposit     = "deposit"
+	QueryVotes       = "votes"
+	QueryVote        = "vote"
+	QueryTally       = "tally"
+	QueryTallyDetail = "tallyDetail"
 )
 
 func NewQuerier(keeper Keeper) sdk.Querier {
@@ -71,6 +72,13 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 				return res, err
 			}
 			return queryTally(ctx, path[1:], req, p, keeper)
+		case QueryTallyDetail:
+			p := new(QueryTallyParams)
+			ctx, err = RequestPrepare(ctx, keeper, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryTallyDetail(ctx, path[1:], req, p, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
 		}
@@ -232,6 +240,32 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, params *Q
 	return bz, nil
 }
 
+// nolint: unparam
+func queryTallyDetail(ctx sdk.Context, path []string, req abci.RequestQuery, params *QueryTallyParams, keeper Keeper) (res []byte, err sdk.Error) {
+
+	proposal := keeper.GetProposal(ctx, params.ProposalID)
+	if proposal == nil {
+		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
+	}
+
+	var detail DetailedTallyResult
+
+	if proposal.GetStatus() == StatusDepositPeriod {
+		detail = DetailedTallyResult{Result: EmptyTallyResult()}
+	} else if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected {
+		// the votes are removed once the proposal is tallied
+		return nil, ErrAlreadyFinishedProposal(DefaultCodespace, params.ProposalID)
+	} else {
+		detail = TallyDetail(ctx, keeper, proposal)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, detail)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
 func RequestPrepare(ctx sdk.Context, k Keeper, req abci.RequestQuery, p SideChainIder) (newCtx sdk.Context, err sdk.Error) {
 	if req.Data == nil || len(req.Data) == 0 {
 		return ctx, nil
@@ -1,6 +1,9 @@
 package gov
 
 import (
+	"bytes"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -13,7 +16,49 @@ type validatorGovInfo struct {
 	Vote                VoteOption     // Vote of the validator
 }
 
+// ValidatorTally is the vote of a bonded validator in a tally. VotingPower is
+// the power counted for its vote, what is left of Power once the delegators
+// voting on their own are deducted.
+type ValidatorTally struct {
+	Validator           sdk.ValAddress `json:"validator"`
+	Vote                VoteOption     `json:"vote"`
+	Power               sdk.Dec        `json:"power"`
+	DelegatorShares     sdk.Dec        `json:"delegator_shares"`
+	DelegatorDeductions sdk.Dec        `json:"delegator_deductions"`
+	VotingPower         sdk.Dec        `json:"voting_power"`
+}
+
+// DelegatorTally is the vote of a delegator counted on one of its delegations,
+// overriding the vote of the validator
+type DelegatorTally struct {
+	Delegator   sdk.AccAddress `json:"delegator"`
+	Validator   sdk.ValAddress `json:"validator"`
+	Vote        VoteOption     `json:"vote"`
+	Shares      sdk.Dec        `json:"shares"`
+	VotingPower sdk.Dec        `json:"voting_power"`
+}
+
+// DetailedTallyResult is a tally result with the votes behind it
+type DetailedTallyResult struct {
+	Result     TallyResult      `json:"result"`
+	Validators []ValidatorTally `json:"validators"`
+	Delegators []DelegatorTally `json:"delegators"`
+}
+
 func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refundDeposits bool, tallyResults TallyResult) {
+	passes, refundDeposits, tallyResults, _ = tally(ctx, keeper, proposal, false)
+	return passes, refundDeposits, tallyResults
+}
+
+// TallyDetail tallies the votes of a proposal as Tally does but keeps the votes,
+// and reports the vote and the voting power of each validator and delegator
+func TallyDetail(ctx sdk.Context, keeper Keeper, proposal Proposal) DetailedTallyResult {
+	_, _, _, detail := tally(ctx, keeper, proposal, true)
+	return detail
+}
+
+// the votes are removed once tallied unless the detail is asked for
+func tally(ctx sdk.Context, keeper Keeper, proposal Proposal, withDetail bool) (passes bool, refundDeposits bool, tallyResults TallyResult, detail DetailedTallyResult) {
 	results := make(map[VoteOption]sdk.Dec)
 	results[OptionYes] = sdk.ZeroDec()
 	results[OptionAbstain] = sdk.ZeroDec()
@@ -61,18 +106,33 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 
 					results[vote.Option] = results[vote.Option].Add(votingPower)
 					totalVotingPower = totalVotingPower.Add(votingPower)
+
+					if withDetail {
+						detail.Delegators = append(detail.Delegators, DelegatorTally{
+							Delegator:   vote.Voter,
+							Validator:   delegation.GetValidatorAddr(),
+							Vote:        vote.Option,
+							Shares:      delegation.GetShares(),
+							VotingPower: votingPower,
+						})
+					}
 				}
 
 				return false
 			})
 		}
 
-		keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
+		if !withDetail {
+			keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
+		}
 	}
 
 	// iterate over the validators again to tally their voting power
 	for _, val := range currValidators {
 		if val.Vote == OptionEmpty {
+			if withDetail {
+				detail.Validators = append(detail.Validators, val.toValidatorTally(sdk.ZeroDec()))
+			}
 			continue
 		}
 
@@ -82,6 +142,16 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 
 		results[val.Vote] = results[val.Vote].Add(votingPower)
 		totalVotingPower = totalVotingPower.Add(votingPower)
+
+		if withDetail {
+			detail.Validators = append(detail.Validators, val.toValidatorTally(votingPower))
+		}
+	}
+	if withDetail {
+		// the validators are collected from a map
+		sort.Slice(detail.Validators, func(i, j int) bool {
+			return bytes.Compare(detail.Validators[i].Validator, detail.Validators[j].Validator) < 0
+		})
 	}
 
 	tallyingParams := keeper.GetTallyParams(ctx)
@@ -93,29 +163,41 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 		NoWithVeto: results[OptionNoWithVeto],
 		Total:      totalPower,
 	}
+	detail.Result = tallyResults
 
 	// If there is no staked coins, the proposal fails
 	if keeper.vs.TotalPower(ctx).IsZero() {
-		return false, true, tallyResults
+		return false, true, tallyResults, detail
 	}
 	// If there is not enough quorum of votes, the proposal fails
 	percentVoting := totalVotingPower.Quo(totalPower)
 	if percentVoting.LT(tallyingParams.Quorum) {
-		return false, true, tallyResults
+		return false, true, tallyResults, detail
 	}
 	// If no one votes, proposal fails
 	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroDec()) {
-		return false, true, tallyResults
+		return false, true, tallyResults, detail
 	}
 	// If more than 1/3 of voters veto, proposal fails
 	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingParams.Veto) {
-		return false, false, tallyResults
+		return false, false, tallyResults, detail
 	}
 	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
 	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingParams.Threshold) {
-		return true, true, tallyResults
+		return true, true, tallyResults, detail
 	}
 	// If more than 1/2 of non-abstaining voters vote No, proposal fails
 
-	return false, false, tallyResults
+	return false, false, tallyResults, detail
+}
+
+func (val validatorGovInfo) toValidatorTally(votingPower sdk.Dec) ValidatorTally {
+	return ValidatorTally{
+		Validator:           val.Address,
+		Vote:                val.Vote,
+		Power:               val.Power,
+		DelegatorShares:     val.DelegatorShares,
+		DelegatorDeductions: val.DelegatorDeductions,
+		VotingPower:         votingPower,
+	}
 }
@@ -375,6 +375,68 @@ func TestTallyDelgatorOverride(t *testing.T) {
 	require.False(t, tallyResults.Equals(gov.EmptyTallyResult()))
 }
 
+func TestTallyDetailDelegatorOverride(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5e8, 6e8, 7e8})
+	stake.EndBlocker(ctx, sk)
+
+	delegator1Msg := stake.NewMsgDelegate(addrs[3], sdk.ValAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 3e8))
+	stakeHandler(ctx, delegator1Msg)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], gov.OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], gov.OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[3], gov.OptionNo)
+	require.Nil(t, err)
+
+	detail := gov.TallyDetail(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	require.Len(t, detail.Validators, 3)
+	for _, val := range detail.Validators {
+		switch {
+		case val.Validator.Equals(valAddrs[0]):
+			require.Equal(t, gov.OptionYes, val.Vote)
+			require.Equal(t, sdk.NewDec(5e8), val.VotingPower)
+		case val.Validator.Equals(valAddrs[1]):
+			require.Equal(t, gov.OptionEmpty, val.Vote)
+			require.Equal(t, sdk.ZeroDec(), val.VotingPower)
+		case val.Validator.Equals(valAddrs[2]):
+			require.Equal(t, gov.OptionYes, val.Vote)
+			require.Equal(t, sdk.NewDec(10e8), val.Power)
+			require.Equal(t, sdk.NewDec(3e8), val.DelegatorDeductions)
+			require.Equal(t, sdk.NewDec(7e8), val.VotingPower)
+		}
+	}
+	require.Equal(t, []gov.DelegatorTally{{
+		Delegator:   addrs[3],
+		Validator:   valAddrs[2],
+		Vote:        gov.OptionNo,
+		Shares:      sdk.NewDec(3e8),
+		VotingPower: sdk.NewDec(3e8),
+	}}, detail.Delegators)
+	require.Equal(t, sdk.NewDec(12e8), detail.Result.Yes)
+	require.Equal(t, sdk.NewDec(3e8), detail.Result.No)
+
+	// the votes are kept for the tally at the end of the voting period
+	_, _, tallyResults := gov.Tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	require.True(t, tallyResults.Equals(detail.Result))
+}
+
 func TestTallyDelegatorInherit(t *testing.T) {
 	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
 	mapp.BeginBlock(abci.RequestBeginBlock{})
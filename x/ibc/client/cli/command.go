@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+const (
+	flagDestChainId   = "dest-chain-id"
+	flagChannelId     = "channel-id"
+	flagStartSequence = "start-sequence"
+	flagLimit         = "limit"
+	flagRetention     = "retention"
+	flagProve         = "prove"
+
+	storeName = "ibc"
+)
+
+func AddCommands(cmd *cobra.Command, cdc *amino.Codec) {
+	ibcCmd := &cobra.Command{
+		Use:   "ibc",
+		Short: "ibc package commands",
+	}
+	ibcCmd.AddCommand(
+		client.GetCommands(
+			GetCmdQueryOutbox(storeName, cdc))...)
+	cmd.AddCommand(ibcCmd)
+}
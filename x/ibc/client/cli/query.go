@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	ibcclient "github.com/cosmos/cosmos-sdk/x/ibc/client"
+)
+
+func GetCmdQueryOutbox(storeName string, cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outbox",
+		Short: "List pending cross chain packages of a channel",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := ibc.QueryOutboxParams{
+				DestChainID:   sdk.ChainID(viper.GetUint(flagDestChainId)),
+				ChannelID:     sdk.ChannelID(viper.GetUint(flagChannelId)),
+				StartSequence: viper.GetUint64(flagStartSequence),
+				Limit:         viper.GetUint64(flagLimit),
+				Retention:     viper.GetInt64(flagRetention),
+			}
+			if params.DestChainID == 0 {
+				return fmt.Errorf("missing %s", flagDestChainId)
+			}
+
+			outbox, err := ibcclient.QueryOutbox(cliCtx, storeName, params, viper.GetBool(flagProve))
+			if err != nil {
+				return err
+			}
+
+			output, err := json.MarshalIndent(outbox, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint16(flagDestChainId, 0, "the id of the destination chain")
+	cmd.Flags().Uint8(flagChannelId, 0, "the id of the channel")
+	cmd.Flags().Uint64(flagStartSequence, 0, "the sequence to start listing from")
+	cmd.Flags().Uint64(flagLimit, ibc.DefaultOutboxLimit, fmt.Sprintf("max number of packages to list, at most %d", ibc.MaxOutboxLimit))
+	cmd.Flags().Int64(flagRetention, 0, "retention window in blocks, older packages are reported as expired")
+	cmd.Flags().Bool(flagProve, false, "fetch every package with its merkle proof")
+	return cmd
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	ibcclient "github.com/cosmos/cosmos-sdk/x/ibc/client"
+)
+
+// REST Variable names
+// nolint
+const (
+	RestDestChainID   = "destChainId"
+	RestChannelID     = "channelId"
+	RestStartSequence = "start"
+	RestLimit         = "limit"
+	RestRetention     = "retention"
+	RestProve         = "prove"
+	storeName         = "ibc"
+)
+
+// RegisterRoutes - Central function to define routes that get registered by the main application
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(fmt.Sprintf("/ibc/outbox/{%s}/{%s}", RestDestChainID, RestChannelID), queryOutboxHandlerFn(cdc, cliCtx)).Methods("GET")
+}
+
+func queryOutboxHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		destChainID, err := strconv.ParseUint(vars[RestDestChainID], 10, 16)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid chain id", vars[RestDestChainID]))
+			return
+		}
+		channelID, err := strconv.ParseUint(vars[RestChannelID], 10, 8)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid channel id", vars[RestChannelID]))
+			return
+		}
+
+		params := ibc.QueryOutboxParams{
+			DestChainID: sdk.ChainID(destChainID),
+			ChannelID:   sdk.ChannelID(channelID),
+		}
+		query := r.URL.Query()
+		if s := query.Get(RestStartSequence); len(s) != 0 {
+			if params.StartSequence, err = strconv.ParseUint(s, 10, 64); err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid sequence", s))
+				return
+			}
+		}
+		if s := query.Get(RestLimit); len(s) != 0 {
+			if params.Limit, err = strconv.ParseUint(s, 10, 64); err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid limit", s))
+				return
+			}
+		}
+		if s := query.Get(RestRetention); len(s) != 0 {
+			var ok bool
+			if params.Retention, ok = utils.ParseInt64OrReturnBadRequest(w, s); !ok {
+				return
+			}
+		}
+		prove := query.Get(RestProve) == "true"
+
+		outbox, err := ibcclient.QueryOutbox(cliCtx, storeName, params, prove)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		output, err := json.Marshal(outbox)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, output, cliCtx.Indent)
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+// QueryOutbox lists pending packages of a channel. If prove is set every listed package is fetched again
+// from the ibc store together with its merkle proof, which is verified unless the node is trusted.
+func QueryOutbox(cliCtx context.CLIContext, storeName string, params ibc.QueryOutboxParams, prove bool) (ibc.Outbox, error) {
+	var outbox ibc.Outbox
+	bz, err := json.Marshal(params)
+	if err != nil {
+		return outbox, err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", storeName, ibc.QueryOutbox), bz)
+	if err != nil {
+		return outbox, err
+	}
+	err = json.Unmarshal(res, &outbox)
+	if err != nil {
+		return outbox, err
+	}
+	if !prove {
+		return outbox, nil
+	}
+
+	// pin all proofs to the height of the listing
+	cliCtx = cliCtx.WithHeight(outbox.Height)
+	for i, pkg := range outbox.Packages {
+		resp, err := cliCtx.QueryStoreWithProof(pkg.Key, storeName)
+		if err != nil {
+			return outbox, err
+		}
+		if len(resp.Value) == 0 {
+			return outbox, fmt.Errorf("package %d is missing at height %d", pkg.Sequence, resp.Height)
+		}
+		outbox.Packages[i].Payload = resp.Value
+		outbox.Packages[i].ProofHeight = resp.Height
+		outbox.Packages[i].Proof = resp.Proof
+	}
+	return outbox, nil
+}
@@ -5,6 +5,11 @@ import (
 )
 
 func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	// timeout hooks may write new packages, so run them before the packages are collected
+	if sdk.IsUpgrade(sdk.IBCPackageTimeout) {
+		keeper.processTimeouts(ctx)
+	}
+
 	if len(keeper.packageCollector.collectedPackages) == 0 {
 		return
 	}
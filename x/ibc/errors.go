@@ -12,6 +12,9 @@ const (
 	CodeFeeParamMismatch      sdk.CodeType = 102
 	CodeInvalidChainId        sdk.CodeType = 103
 	CodeWritePackageForbidden sdk.CodeType = 104
+	CodeInvalidTimeout        sdk.CodeType = 105
+	CodePackageTimedOut       sdk.CodeType = 106
+	CodeInvalidPackage        sdk.CodeType = 107
 )
 
 func ErrDuplicatedSequence(codespace sdk.CodespaceType, msg string) sdk.Error {
@@ -29,3 +32,15 @@ func ErrInvalidChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
 func ErrWritePackageForbidden(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeWritePackageForbidden, msg)
 }
+
+func ErrInvalidTimeout(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidTimeout, msg)
+}
+
+func ErrPackageTimedOut(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodePackageTimedOut, msg)
+}
+
+func ErrInvalidPackage(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPackage, msg)
+}
@@ -1,7 +1,6 @@
 package ibc
 
 import (
-	"encoding/binary"
 	"fmt"
 	"math/big"
 
@@ -77,10 +76,22 @@ func (k *Keeper) CreateRawIBCPackageById(ctx sdk.Context, destChainID sdk.ChainI
 
 func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
 	packageType sdk.CrossChainPackageType, packageLoad []byte, relayerFee big.Int) (uint64, sdk.Error) {
+	return k.createRawIBCPackage(ctx, destChainID, channelID, packageType, packageLoad, relayerFee, PackageTimeout{})
+}
+
+func (k *Keeper) createRawIBCPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	packageType sdk.CrossChainPackageType, packageLoad []byte, relayerFee big.Int, timeout PackageTimeout) (uint64, sdk.Error) {
 
-	if packageType == sdk.SynCrossChainPackageType && k.sideKeeper.GetChannelSendPermission(ctx, destChainID, channelID) != sdk.ChannelAllow {
+	isSyn := sdk.WithoutTimeout(packageType) == sdk.SynCrossChainPackageType
+	if isSyn && k.sideKeeper.GetChannelSendPermission(ctx, destChainID, channelID) != sdk.ChannelAllow {
 		return 0, ErrWritePackageForbidden(DefaultCodespace, fmt.Sprintf("channel %d is not allowed to write syn package", channelID))
 	}
+	if isSyn {
+		value := k.sideKeeper.GetPackageValue(channelID, sdk.SynCrossChainPackageType, packageLoad)
+		if err := k.sideKeeper.ConsumeChannelQuota(ctx, destChainID, channelID, value); err != nil {
+			return 0, err
+		}
+	}
 
 	sequence := k.sideKeeper.GetSendSequence(ctx, destChainID, channelID)
 	key := buildIBCPackageKey(k.sideKeeper.GetSrcChainID(), destChainID, channelID, sequence)
@@ -91,8 +102,15 @@ func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk
 
 	// Assemble the package header
 	packageHeader := sTypes.EncodePackageHeader(packageType, relayerFee)
+	if packageType == sdk.TimeoutSynCrossChainPackageType {
+		packageHeader = append(packageHeader, sTypes.EncodePackageTimeoutHeader(sequence, timeout.Height, timeout.Time)...)
+	}
 
 	kvStore.Set(key, append(packageHeader, packageLoad...))
+	if sdk.IsUpgrade(sdk.IBCPackageMeta) {
+		meta := packageMeta{height: ctx.BlockHeight(), time: ctx.BlockHeader().Time.Unix()}
+		kvStore.Set(buildIBCPackageMetaKey(k.sideKeeper.GetSrcChainID(), destChainID, channelID, sequence), meta.encode())
+	}
 	k.sideKeeper.IncrSendSequence(ctx, destChainID, channelID)
 
 	if ctx.IsDeliverTx() {
@@ -143,12 +161,92 @@ func (k *Keeper) CleanupIBCPackage(ctx sdk.Context, destChainName string, channe
 		if len(packageKey) != totalPackageKeyLength {
 			continue
 		}
-		sequence := binary.BigEndian.Uint64(packageKey[totalPackageKeyLength-sequenceLength:])
+		sequence := sequenceFromPackageKey(packageKey)
 		if sequence > confirmedSequence {
 			break
 		}
 		kvStore.Delete(packageKey)
+		if sdk.IsUpgrade(sdk.IBCPackageMeta) {
+			kvStore.Delete(buildIBCPackageMetaKey(k.sideKeeper.GetSrcChainID(), destChainID, channelID, sequence))
+		}
+		k.ClearPackageTimeout(ctx, destChainID, channelID, sequence)
+	}
+}
+
+// GetOutbox lists at most limit pending packages of the channel starting from startSequence.
+// Packages created more than retention blocks ago are marked as expired, a non-positive retention disables it.
+// Packages written before the IBCPackageMeta upgrade have no metadata and are never expired.
+// At most MaxOutboxGaps gaps are listed.
+func (k *Keeper) GetOutbox(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	startSequence uint64, limit uint64, retention int64) Outbox {
+
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	sendSequence := k.sideKeeper.GetSendSequence(ctx, destChainID, channelID)
+	outbox := Outbox{
+		DestChainID:  destChainID,
+		ChannelID:    channelID,
+		Height:       ctx.BlockHeight(),
+		SendSequence: sendSequence,
+		Packages:     make([]OutboxPackage, 0),
+		Gaps:         make([]uint64, 0),
+		NextSequence: startSequence,
+		Retention:    retention,
+	}
+	if startSequence >= sendSequence {
+		return outbox
+	}
+
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := kvStore.Iterator(
+		buildIBCPackageKey(srcChainID, destChainID, channelID, startSequence),
+		buildIBCPackageKey(srcChainID, destChainID, channelID, sendSequence))
+	defer iterator.Close()
+
+	expectedSequence := startSequence
+	for ; iterator.Valid(); iterator.Next() {
+		packageKey := iterator.Key()
+		if len(packageKey) != totalPackageKeyLength {
+			continue
+		}
+		if uint64(len(outbox.Packages)) >= limit {
+			outbox.HasMore = true
+			break
+		}
+		sequence := sequenceFromPackageKey(packageKey)
+		// sequences below the first pending package are confirmed and cleaned up, they are not gaps
+		if len(outbox.Packages) != 0 {
+			for missing := expectedSequence; missing < sequence; missing++ {
+				if len(outbox.Gaps) >= MaxOutboxGaps {
+					outbox.GapsTruncated = true
+					break
+				}
+				outbox.Gaps = append(outbox.Gaps, missing)
+			}
+		}
+		expectedSequence = sequence + 1
+
+		pkg := OutboxPackage{
+			Sequence: sequence,
+			Key:      packageKey,
+			Payload:  iterator.Value(),
+		}
+		if meta, ok := decodePackageMeta(kvStore.Get(buildIBCPackageMetaKey(srcChainID, destChainID, channelID, sequence))); ok {
+			pkg.CreatedHeight = meta.height
+			pkg.CreatedTime = meta.time
+			pkg.Age = ctx.BlockHeight() - meta.height
+			pkg.Expired = retention > 0 && pkg.Age > retention
+		}
+		if timeout, ok := k.GetPackageTimeout(ctx, destChainID, channelID, sequence); ok {
+			pkg.Timeout = &timeout
+		}
+		if pkg.Expired {
+			outbox.ExpiredCount++
+		}
+		outbox.Packages = append(outbox.Packages, pkg)
 	}
+	outbox.NextSequence = expectedSequence
+
+	return outbox
 }
 
 func (k Keeper) GetRelayerFeeParam(ctx sdk.Context, destChainName string) (relaterFee *big.Int, err error) {
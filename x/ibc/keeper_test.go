@@ -3,6 +3,7 @@ package ibc
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -12,17 +13,25 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
 )
 
 func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	keyIBC := sdk.NewKVStoreKey("ibc")
 	keySideChain := sdk.NewKVStoreKey("sc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	keyAcc := sdk.NewKVStoreKey("acc")
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -30,13 +39,11 @@ func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	if isCheckTx {
 		mode = sdk.RunTxModeCheck
 	}
-	keyParams := sdk.NewKVStoreKey("params")
-	tkeyParams := sdk.NewTransientStoreKey("transient_params")
-
 	cdc := createTestCodec()
 	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
 
-	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, mode, log.NewNopLogger())
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, mode, log.NewNopLogger()).WithAccountCache(accountCache)
 	scKeeper := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), cdc)
 	ibcKeeper := NewKeeper(keyIBC, pk.Subspace(DefaultParamspace), DefaultCodespace, scKeeper)
 
@@ -123,3 +130,232 @@ func createTestCodec() *codec.Codec {
 	codec.RegisterCrypto(cdc)
 	return cdc
 }
+
+func TestOutbox(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCPackageMeta, 30)
+
+	for i := int64(1); i <= 6; i++ {
+		ctx = ctx.WithBlockHeight(i * 10)
+		sdk.UpgradeMgr.SetHeight(i * 10)
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{byte(i)}, *big.NewInt(100))
+		require.NoError(t, err)
+	}
+	// the packages written before the upgrade have no metadata
+	outbox := keeper.GetOutbox(ctx, destChainID, channelID, 0, 10, 35)
+	require.Equal(t, int64(0), outbox.Packages[0].CreatedHeight)
+	require.False(t, outbox.Packages[0].Expired)
+	keeper.CleanupIBCPackage(ctx, destChainName, channelName, 1)
+	// simulate a hole in the outbox
+	ctx.KVStore(keeper.storeKey).Delete(buildIBCPackageKey(keeper.sideKeeper.GetSrcChainID(), destChainID, channelID, 3))
+
+	ctx = ctx.WithBlockHeight(70)
+	outbox = keeper.GetOutbox(ctx, destChainID, channelID, 0, 10, 35)
+	require.Equal(t, uint64(6), outbox.SendSequence)
+	require.Len(t, outbox.Packages, 3)
+	require.Equal(t, uint64(2), outbox.Packages[0].Sequence)
+	require.Equal(t, int64(30), outbox.Packages[0].CreatedHeight)
+	require.Equal(t, int64(40), outbox.Packages[0].Age)
+	require.True(t, outbox.Packages[0].Expired)
+	require.False(t, outbox.Packages[1].Expired)
+	require.Equal(t, []uint64{3}, outbox.Gaps)
+	require.Equal(t, 1, outbox.ExpiredCount)
+	require.False(t, outbox.HasMore)
+
+	outbox = keeper.GetOutbox(ctx, destChainID, channelID, 0, 2, 0)
+	require.Len(t, outbox.Packages, 2)
+	require.True(t, outbox.HasMore)
+	require.Equal(t, uint64(5), outbox.NextSequence)
+	require.Equal(t, 0, outbox.ExpiredCount)
+
+	outbox = keeper.GetOutbox(ctx, destChainID, channelID, outbox.NextSequence, 2, 0)
+	require.Len(t, outbox.Packages, 1)
+	require.Equal(t, uint64(5), outbox.Packages[0].Sequence)
+	require.False(t, outbox.HasMore)
+
+	// metadata is removed with the package
+	keeper.CleanupIBCPackage(ctx, destChainName, channelName, 5)
+	require.Nil(t, ctx.KVStore(keeper.storeKey).Get(buildIBCPackageMetaKey(keeper.sideKeeper.GetSrcChainID(), destChainID, channelID, 5)))
+}
+
+func TestOutboxGapsLimit(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+
+	total := uint64(MaxOutboxGaps + 10)
+	for i := uint64(0); i < total; i++ {
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x01}, *big.NewInt(100))
+		require.NoError(t, err)
+	}
+	// only the first and the last packages are left
+	for i := uint64(1); i < total-1; i++ {
+		ctx.KVStore(keeper.storeKey).Delete(buildIBCPackageKey(keeper.sideKeeper.GetSrcChainID(), destChainID, channelID, i))
+	}
+
+	outbox := keeper.GetOutbox(ctx, destChainID, channelID, 0, 10, 0)
+	require.Len(t, outbox.Packages, 2)
+	require.Len(t, outbox.Gaps, MaxOutboxGaps)
+	require.True(t, outbox.GapsTruncated)
+	require.Equal(t, total, outbox.NextSequence)
+}
+
+type timeoutApp struct {
+	timedOut [][]byte
+}
+
+func (app *timeoutApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *timeoutApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *timeoutApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *timeoutApp) ExecuteTimeoutPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	app.timedOut = append(app.timedOut, payload)
+	return sdk.ExecuteResult{}
+}
+
+func TestPackageTimeout(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelID := sdk.ChannelID(0x01)
+	app := &timeoutApp{}
+
+	ctx, keeper := createTestInput(t, false)
+	defer sdk.UpgradeMgr.Reset()
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("transfer", channelID, app))
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, []byte{0x01})
+	keeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x01}), Params{RelayerFee: DefaultRelayerFeeParam})
+
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	// not activated yet
+	_, err := keeper.CreateRawIBCPackageByIdWithTimeout(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x01}, PackageTimeout{Height: 20})
+	require.Error(t, err)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCPackageTimeout, 10)
+	sdk.UpgradeMgr.SetHeight(10)
+	// the plain syn packages of the channel are not changed
+	plainSeq, err := keeper.CreateRawIBCPackageById(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x01})
+	require.NoError(t, err)
+	plain, _ := keeper.GetIBCPackageById(ctx, destChainID, channelID, plainSeq)
+	require.Equal(t, byte(sdk.SynCrossChainPackageType), plain[0])
+	require.Equal(t, []byte{0x01}, plain[sTypes.PackageHeaderLength:])
+	keeper.CleanupIBCPackage(ctx, destChainName, "transfer", plainSeq)
+
+	_, err = keeper.CreateRawIBCPackageByIdWithTimeout(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x01}, PackageTimeout{Height: 10})
+	require.Error(t, err)
+	_, err = keeper.CreateRawIBCPackageByIdWithTimeout(ctx, destChainID, channelID, sdk.AckCrossChainPackageType, []byte{0x01}, PackageTimeout{Height: 20})
+	require.Error(t, err)
+
+	seq0, err := keeper.CreateRawIBCPackageByIdWithTimeout(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x01}, PackageTimeout{Height: 20})
+	require.NoError(t, err)
+	seq1, err := keeper.CreateRawIBCPackageByIdWithTimeout(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x02}, PackageTimeout{Height: 15, Time: 1100})
+	require.NoError(t, err)
+	seq2, err := keeper.CreateRawIBCPackageByIdWithTimeout(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x03}, PackageTimeout{Time: 1050})
+	require.NoError(t, err)
+	outbox := keeper.GetOutbox(ctx, destChainID, channelID, 0, 10, 0)
+	require.Equal(t, &PackageTimeout{Height: 15, Time: 1100}, outbox.Packages[1].Timeout)
+	// the timeout is relayed to the side chain in a timeout syn package
+	packageType, _, decodeErr := sTypes.DecodePackageHeader(outbox.Packages[1].Payload)
+	require.NoError(t, decodeErr)
+	require.Equal(t, sdk.TimeoutSynCrossChainPackageType, packageType)
+	sequence, timeoutHeight, timeoutTime, decodeErr := sTypes.DecodePackageTimeoutHeader(outbox.Packages[1].Payload[sTypes.PackageHeaderLength:])
+	require.NoError(t, decodeErr)
+	require.Equal(t, seq1, sequence)
+	require.Equal(t, int64(15), timeoutHeight)
+	require.Equal(t, int64(1100), timeoutTime)
+	require.Equal(t, []byte{0x02}, []byte(outbox.Packages[1].Payload[sTypes.PackageHeaderLength+sTypes.PackageTimeoutHeaderLength:]))
+
+	// the third package is answered
+	answer, err := keeper.AcceptPackageAnswer(ctx, destChainID, channelID, append(sTypes.EncodePackageTimeoutHeader(seq2, 0, 1050), 0x09))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x09}, answer)
+	_, found := keeper.GetPackageTimeout(ctx, destChainID, channelID, seq2)
+	require.False(t, found)
+
+	EndBlocker(ctx.WithBlockHeight(14).WithBlockTime(time.Unix(1060, 0)), keeper)
+	require.Len(t, app.timedOut, 0)
+
+	// both timeout height and time of the second package are reached, it is reported once
+	ctx = ctx.WithBlockHeight(15).WithBlockTime(time.Unix(1200, 0)).WithEventManager(sdk.NewEventManager())
+	EndBlocker(ctx, keeper)
+	require.Equal(t, [][]byte{{0x02}}, app.timedOut)
+	require.Len(t, ctx.EventManager().Events(), 1)
+	_, found = keeper.GetPackageTimeout(ctx, destChainID, channelID, seq1)
+	require.False(t, found)
+	require.True(t, keeper.IsPackageTimedOut(ctx, destChainID, channelID, seq1))
+
+	// the ack arriving after the timeout is rejected, the package is not settled twice
+	_, err = keeper.AcceptPackageAnswer(ctx, destChainID, channelID, append(sTypes.EncodePackageTimeoutHeader(seq1, 15, 1100), 0x09))
+	require.Error(t, err)
+	require.Equal(t, CodePackageTimedOut, err.Code())
+	require.False(t, keeper.IsPackageTimedOut(ctx, destChainID, channelID, seq1))
+	_, err = keeper.AcceptPackageAnswer(ctx, destChainID, channelID, []byte{0x09})
+	require.Error(t, err)
+
+	// confirmed packages do not time out
+	keeper.CleanupIBCPackage(ctx, destChainName, "transfer", seq0)
+	EndBlocker(ctx.WithBlockHeight(20), keeper)
+	require.Len(t, app.timedOut, 1)
+}
+
+func TestTimedOutPackageRetention(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelID := sdk.ChannelID(0x01)
+	app := &timeoutApp{}
+
+	ctx, keeper := createTestInput(t, false)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCPackageTimeout, 1)
+	sdk.UpgradeMgr.SetHeight(10)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("transfer", channelID, app))
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, []byte{0x01})
+	keeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x01}), Params{RelayerFee: DefaultRelayerFeeParam})
+
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	seq, err := keeper.CreateRawIBCPackageByIdWithTimeout(ctx, destChainID, channelID, sdk.SynCrossChainPackageType, []byte{0x01}, PackageTimeout{Time: 1100})
+	require.NoError(t, err)
+	EndBlocker(ctx.WithBlockTime(time.Unix(1100, 0)), keeper)
+	require.Len(t, app.timedOut, 1)
+	require.True(t, keeper.IsPackageTimedOut(ctx, destChainID, channelID, seq))
+
+	// the package is forgotten if it is never answered
+	EndBlocker(ctx.WithBlockTime(time.Unix(1100+TimedOutPackageRetention-1, 0)), keeper)
+	require.True(t, keeper.IsPackageTimedOut(ctx, destChainID, channelID, seq))
+	EndBlocker(ctx.WithBlockTime(time.Unix(1100+TimedOutPackageRetention, 0)), keeper)
+	require.False(t, keeper.IsPackageTimedOut(ctx, destChainID, channelID, seq))
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(keeper.storeKey), PrefixForTimedOutQueueKey)
+	defer iterator.Close()
+	require.False(t, iterator.Valid())
+	require.Len(t, app.timedOut, 1)
+}
@@ -12,18 +12,38 @@ const (
 	destChainIDLength     = 2
 	channelIDLength       = 1
 	sequenceLength        = 8
+	timeoutLength         = 8
 	totalPackageKeyLength = prefixLength + srcChainIdLength + destChainIDLength + channelIDLength + sequenceLength
 )
 
 var (
-	PrefixForIbcPackageKey = []byte{0x00}
-	PrefixForSequenceKey   = []byte{0x01}
+	PrefixForIbcPackageKey     = []byte{0x00}
+	PrefixForSequenceKey       = []byte{0x01}
+	PrefixForIbcPackageMetaKey = []byte{0x02}
+
+	PrefixForTimeoutHeightQueueKey = []byte{0x03}
+	PrefixForTimeoutTimeQueueKey   = []byte{0x04}
+	PrefixForPackageTimeoutKey     = []byte{0x05}
+	PrefixForTimedOutPackageKey    = []byte{0x06}
+	PrefixForTimedOutQueueKey      = []byte{0x07}
 )
 
 func buildIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	return buildPackageKey(PrefixForIbcPackageKey, srcChainID, destChainID, channelID, sequence)
+}
+
+func buildIBCPackageKeyPrefix(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	return buildPackageKeyPrefix(PrefixForIbcPackageKey, srcChainID, destChainID, channelID)
+}
+
+func buildIBCPackageMetaKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	return buildPackageKey(PrefixForIbcPackageMetaKey, srcChainID, destChainID, channelID, sequence)
+}
+
+func buildPackageKey(prefix []byte, srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
 	key := make([]byte, totalPackageKeyLength)
 
-	copy(key[:prefixLength], PrefixForIbcPackageKey)
+	copy(key[:prefixLength], prefix)
 	binary.BigEndian.PutUint16(key[prefixLength:srcChainIdLength+prefixLength], uint16(srcChainID))
 	binary.BigEndian.PutUint16(key[prefixLength+srcChainIdLength:prefixLength+srcChainIdLength+destChainIDLength], uint16(destChainID))
 	copy(key[prefixLength+srcChainIdLength+destChainIDLength:], []byte{byte(channelID)})
@@ -32,13 +52,51 @@ func buildIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.Chann
 	return key
 }
 
-func buildIBCPackageKeyPrefix(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+func buildPackageKeyPrefix(prefix []byte, srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
 	key := make([]byte, totalPackageKeyLength-sequenceLength)
 
-	copy(key[:prefixLength], PrefixForIbcPackageKey)
+	copy(key[:prefixLength], prefix)
 	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+srcChainIdLength], uint16(srcChainID))
 	binary.BigEndian.PutUint16(key[prefixLength+srcChainIdLength:prefixLength+srcChainIdLength+destChainIDLength], uint16(destChainID))
 	copy(key[prefixLength+srcChainIdLength+destChainIDLength:], []byte{byte(channelID)})
 
 	return key
-}
\ No newline at end of file
+}
+
+func sequenceFromPackageKey(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[totalPackageKeyLength-sequenceLength:])
+}
+
+func buildPackageTimeoutKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	return buildPackageKey(PrefixForPackageTimeoutKey, srcChainID, destChainID, channelID, sequence)
+}
+
+func buildTimedOutPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	return buildPackageKey(PrefixForTimedOutPackageKey, srcChainID, destChainID, channelID, sequence)
+}
+
+// the timeout queues are ordered by timeout height or time, followed by the package key without prefix
+func buildTimeoutQueueKey(prefix []byte, timeout int64, srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, prefixLength+timeoutLength, timeoutLength+totalPackageKeyLength)
+	copy(key[:prefixLength], prefix)
+	binary.BigEndian.PutUint64(key[prefixLength:], uint64(timeout))
+	packageKey := buildPackageKey(nil, srcChainID, destChainID, channelID, sequence)
+	return append(key, packageKey[prefixLength:]...)
+}
+
+// buildTimeoutQueueEndKey returns the exclusive end key of the queue entries timed out at the given timeout
+func buildTimeoutQueueEndKey(prefix []byte, timeout int64) []byte {
+	key := make([]byte, prefixLength+timeoutLength)
+	copy(key[:prefixLength], prefix)
+	binary.BigEndian.PutUint64(key[prefixLength:], uint64(timeout+1))
+	return key
+}
+
+func splitTimeoutQueueKey(key []byte) (destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	// skip the prefix and the timeout, and keep one byte in place of the package key prefix
+	packageKey := key[timeoutLength:]
+	destChainID = sdk.ChainID(binary.BigEndian.Uint16(packageKey[prefixLength+srcChainIdLength : prefixLength+srcChainIdLength+destChainIDLength]))
+	channelID = sdk.ChannelID(packageKey[prefixLength+srcChainIdLength+destChainIDLength])
+	sequence = sequenceFromPackageKey(packageKey)
+	return
+}
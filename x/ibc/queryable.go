@@ -0,0 +1,63 @@
+package ibc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryOutbox = "outbox"
+
+	DefaultOutboxLimit uint64 = 100
+	MaxOutboxLimit     uint64 = 1000
+	MaxOutboxGaps             = 1000
+)
+
+// Params for query 'custom/ibc/outbox'
+type QueryOutboxParams struct {
+	DestChainID   sdk.ChainID   `json:"dest_chain_id"`
+	ChannelID     sdk.ChannelID `json:"channel_id"`
+	StartSequence uint64        `json:"start_sequence"`
+	Limit         uint64        `json:"limit"`
+	Retention     int64         `json:"retention"` // in blocks, packages older than it are reported as expired
+}
+
+// creates a querier for ibc REST endpoints
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryOutbox:
+			var params QueryOutboxParams
+			err := json.Unmarshal(req.Data, &params)
+			if err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryOutbox(ctx, k, params)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+func queryOutbox(ctx sdk.Context, k Keeper, params QueryOutboxParams) ([]byte, sdk.Error) {
+	if _, err := k.sideKeeper.GetDestChainName(params.DestChainID); err != nil {
+		return nil, ErrInvalidChainId(DefaultCodespace, fmt.Sprintf("can not find dest chain id %d", params.DestChainID))
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultOutboxLimit
+	}
+	if params.Limit > MaxOutboxLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("limit should not be larger than %d", MaxOutboxLimit))
+	}
+
+	outbox := k.GetOutbox(ctx, params.DestChainID, params.ChannelID, params.StartSequence, params.Limit, params.Retention)
+	res, err := json.Marshal(outbox)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,235 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"runtime/debug"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// TimedOutPackageRetention is how long, in seconds, a package which has timed out is remembered to reject its late answer
+const TimedOutPackageRetention int64 = 30 * 24 * 60 * 60
+
+// CreateRawIBCPackageByIdWithTimeout writes a syn package which is reported to the application of the channel
+// through sdk.CrossChainTimeoutApplication if it is not confirmed before the timeout height or time.
+// The package is written as a TimeoutSynCrossChainPackageType package whose timeout header relays the timeout,
+// so that the side chain refuses it once the timeout is reached. It is only available after the IBCPackageTimeout upgrade.
+func (k *Keeper) CreateRawIBCPackageByIdWithTimeout(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	packageType sdk.CrossChainPackageType, packageLoad []byte, timeout PackageTimeout) (uint64, sdk.Error) {
+
+	destChainName, err := k.sideKeeper.GetDestChainName(destChainID)
+	if err != nil {
+		return 0, ErrInvalidChainId(DefaultCodespace, "can not find dest chain id")
+	}
+	relayerFee, err := k.GetRelayerFeeParam(ctx, destChainName)
+	if err != nil {
+		return 0, ErrFeeParamMismatch(DefaultCodespace, fmt.Sprintf("fail to load relayerFee, %v", err))
+	}
+	return k.CreateRawIBCPackageByIdWithFeeAndTimeout(ctx, destChainID, channelID, packageType, packageLoad, *relayerFee, timeout)
+}
+
+// CreateRawIBCPackageByIdWithFeeAndTimeout is CreateRawIBCPackageByIdWithTimeout with the given relayer fee
+func (k *Keeper) CreateRawIBCPackageByIdWithFeeAndTimeout(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	packageType sdk.CrossChainPackageType, packageLoad []byte, relayerFee big.Int, timeout PackageTimeout) (uint64, sdk.Error) {
+
+	if sdkErr := k.checkTimeout(ctx, channelID, packageType, timeout); sdkErr != nil {
+		return 0, sdkErr
+	}
+	sequence, sdkErr := k.createRawIBCPackage(ctx, destChainID, channelID, sdk.TimeoutSynCrossChainPackageType, packageLoad, relayerFee, timeout)
+	if sdkErr != nil {
+		return 0, sdkErr
+	}
+	k.setPackageTimeout(ctx, destChainID, channelID, sequence, timeout)
+	return sequence, nil
+}
+
+func (k *Keeper) checkTimeout(ctx sdk.Context, channelID sdk.ChannelID, packageType sdk.CrossChainPackageType, timeout PackageTimeout) sdk.Error {
+	if !sdk.IsUpgrade(sdk.IBCPackageTimeout) {
+		return ErrInvalidTimeout(DefaultCodespace, "package timeout is not activated yet")
+	}
+	if packageType != sdk.SynCrossChainPackageType {
+		return ErrInvalidTimeout(DefaultCodespace, "only syn package can have a timeout")
+	}
+	if timeout.IsZero() || timeout.Height < 0 || timeout.Time < 0 {
+		return ErrInvalidTimeout(DefaultCodespace, fmt.Sprintf("invalid timeout %v", timeout))
+	}
+	if timeout.Height != 0 && timeout.Height <= ctx.BlockHeight() {
+		return ErrInvalidTimeout(DefaultCodespace, fmt.Sprintf("timeout height %d is not after current height %d", timeout.Height, ctx.BlockHeight()))
+	}
+	if timeout.Time != 0 && timeout.Time <= ctx.BlockHeader().Time.Unix() {
+		return ErrInvalidTimeout(DefaultCodespace, fmt.Sprintf("timeout time %d is not after current block time", timeout.Time))
+	}
+
+	if !k.supportsTimeout(ctx, channelID) {
+		return ErrInvalidTimeout(DefaultCodespace, fmt.Sprintf("the application of channel %d does not support timeouts", channelID))
+	}
+	return nil
+}
+
+func (k *Keeper) supportsTimeout(ctx sdk.Context, channelID sdk.ChannelID) bool {
+	_, ok := k.sideKeeper.GetCrossChainApp(ctx, channelID).(sdk.CrossChainTimeoutApplication)
+	return ok
+}
+
+func (k *Keeper) GetPackageTimeout(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (PackageTimeout, bool) {
+	kvStore := ctx.KVStore(k.storeKey)
+	return decodePackageTimeout(kvStore.Get(buildPackageTimeoutKey(k.sideKeeper.GetSrcChainID(), destChainID, channelID, sequence)))
+}
+
+// ClearPackageTimeout should be called by the application once it learns that a package sent with a timeout is answered.
+// Packages removed by CleanupIBCPackage are cleared as well.
+func (k *Keeper) ClearPackageTimeout(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	timeout, found := k.GetPackageTimeout(ctx, destChainID, channelID, sequence)
+	if !found {
+		return
+	}
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Delete(buildPackageTimeoutKey(srcChainID, destChainID, channelID, sequence))
+	if timeout.Height != 0 {
+		kvStore.Delete(buildTimeoutQueueKey(PrefixForTimeoutHeightQueueKey, timeout.Height, srcChainID, destChainID, channelID, sequence))
+	}
+	if timeout.Time != 0 {
+		kvStore.Delete(buildTimeoutQueueKey(PrefixForTimeoutTimeQueueKey, timeout.Time, srcChainID, destChainID, channelID, sequence))
+	}
+}
+
+func (k *Keeper) IsPackageTimedOut(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) bool {
+	kvStore := ctx.KVStore(k.storeKey)
+	return kvStore.Has(buildTimedOutPackageKey(k.sideKeeper.GetSrcChainID(), destChainID, channelID, sequence))
+}
+
+// AcceptPackageAnswer checks a TimeoutAckCrossChainPackageType or TimeoutFailAckCrossChainPackageType package before
+// it is executed, payload does not include the package header. The answered package is identified by the timeout
+// header, its timeout is cleared. The answer is rejected if the package has timed out, since the application has
+// already settled it in ExecuteTimeoutPackage. The payload without the timeout header is returned.
+func (k *Keeper) AcceptPackageAnswer(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, payload []byte) ([]byte, sdk.Error) {
+	sequence, _, _, err := sTypes.DecodePackageTimeoutHeader(payload)
+	if err != nil {
+		return nil, ErrInvalidPackage(DefaultCodespace, err.Error())
+	}
+	if k.IsPackageTimedOut(ctx, destChainID, channelID, sequence) {
+		// a package is answered only once
+		k.clearTimedOutPackage(ctx, destChainID, channelID, sequence)
+		return nil, ErrPackageTimedOut(DefaultCodespace, fmt.Sprintf("package %d of channel %d has timed out", sequence, channelID))
+	}
+	k.ClearPackageTimeout(ctx, destChainID, channelID, sequence)
+	return payload[sTypes.PackageTimeoutHeaderLength:], nil
+}
+
+func (k *Keeper) setPackageTimeout(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, timeout PackageTimeout) {
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Set(buildPackageTimeoutKey(srcChainID, destChainID, channelID, sequence), timeout.encode())
+	if timeout.Height != 0 {
+		kvStore.Set(buildTimeoutQueueKey(PrefixForTimeoutHeightQueueKey, timeout.Height, srcChainID, destChainID, channelID, sequence), []byte{})
+	}
+	if timeout.Time != 0 {
+		kvStore.Set(buildTimeoutQueueKey(PrefixForTimeoutTimeQueueKey, timeout.Time, srcChainID, destChainID, channelID, sequence), []byte{})
+	}
+}
+
+// setTimedOutPackage remembers a package which has timed out until its answer arrives or the retention expires
+func (k *Keeper) setTimedOutPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	expiry := ctx.BlockHeader().Time.Unix() + TimedOutPackageRetention
+	bz := make([]byte, timeoutLength)
+	binary.BigEndian.PutUint64(bz, uint64(expiry))
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Set(buildTimedOutPackageKey(srcChainID, destChainID, channelID, sequence), bz)
+	kvStore.Set(buildTimeoutQueueKey(PrefixForTimedOutQueueKey, expiry, srcChainID, destChainID, channelID, sequence), []byte{})
+}
+
+func (k *Keeper) clearTimedOutPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	kvStore := ctx.KVStore(k.storeKey)
+	key := buildTimedOutPackageKey(srcChainID, destChainID, channelID, sequence)
+	bz := kvStore.Get(key)
+	if bz == nil {
+		return
+	}
+	kvStore.Delete(key)
+	kvStore.Delete(buildTimeoutQueueKey(PrefixForTimedOutQueueKey, int64(binary.BigEndian.Uint64(bz)), srcChainID, destChainID, channelID, sequence))
+}
+
+// processTimeouts executes the timeout hook of every package whose timeout height or time is reached,
+// and forgets the packages which have timed out longer than TimedOutPackageRetention ago
+func (k *Keeper) processTimeouts(ctx sdk.Context) {
+	for _, record := range k.collectTimeouts(ctx, PrefixForTimedOutQueueKey, ctx.BlockHeader().Time.Unix()) {
+		k.clearTimedOutPackage(ctx, record.destChainID, record.channelID, record.sequence)
+	}
+
+	expired := k.collectTimeouts(ctx, PrefixForTimeoutHeightQueueKey, ctx.BlockHeight())
+	expired = append(expired, k.collectTimeouts(ctx, PrefixForTimeoutTimeQueueKey, ctx.BlockHeader().Time.Unix())...)
+
+	for _, record := range expired {
+		// a package may reach both its timeout height and time in the same block
+		if _, found := k.GetPackageTimeout(ctx, record.destChainID, record.channelID, record.sequence); !found {
+			continue
+		}
+		k.ClearPackageTimeout(ctx, record.destChainID, record.channelID, record.sequence)
+		k.executeTimeout(ctx, record)
+	}
+}
+
+func (k *Keeper) collectTimeouts(ctx sdk.Context, prefix []byte, now int64) []packageRecord {
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := kvStore.Iterator(prefix, buildTimeoutQueueEndKey(prefix, now))
+	defer iterator.Close()
+
+	var records []packageRecord
+	for ; iterator.Valid(); iterator.Next() {
+		destChainID, channelID, sequence := splitTimeoutQueueKey(iterator.Key())
+		records = append(records, packageRecord{
+			destChainID: destChainID,
+			channelID:   channelID,
+			sequence:    sequence,
+		})
+	}
+	return records
+}
+
+func (k *Keeper) executeTimeout(ctx sdk.Context, record packageRecord) {
+	logger := ctx.Logger().With("module", "ibc")
+	payload, _ := k.GetIBCPackageById(ctx, record.destChainID, record.channelID, record.sequence)
+	// already confirmed and cleaned up
+	if len(payload) < sTypes.PackageHeaderLength+sTypes.PackageTimeoutHeaderLength {
+		return
+	}
+	// an ack or fail ack package arriving later must not settle the package again
+	k.setTimedOutPackage(ctx, record.destChainID, record.channelID, record.sequence)
+
+	result := sdk.ExecuteResult{}
+	app, ok := k.sideKeeper.GetCrossChainApp(ctx, record.channelID).(sdk.CrossChainTimeoutApplication)
+	if ok {
+		cacheCtx, write := ctx.CacheContext()
+		result = executeTimeoutPackage(cacheCtx, app, payload[sTypes.PackageHeaderLength+sTypes.PackageTimeoutHeaderLength:])
+		if result.IsOk() {
+			write()
+		} else {
+			logger.Error("execute timeout package failed", "channelID", record.channelID, "sequence", record.sequence, "err", result.Msg())
+		}
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(ibcTimeoutEventType,
+		sdk.NewAttribute(ibcPackageInfoAttributeKey, buildIBCPackageAttributeValue(record.destChainID, record.channelID, record.sequence)),
+		sdk.NewAttribute(ibcTimeoutResultCodeAttributeKey, strconv.FormatInt(int64(result.Code()), 10)),
+	))
+}
+
+func executeTimeoutPackage(ctx sdk.Context, app sdk.CrossChainTimeoutApplication, payload []byte) (result sdk.ExecuteResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
+			ctx.Logger().With("module", "ibc").Error("execute timeout package panic", "err_log", log)
+			result = sdk.ExecuteResult{
+				Err: sdk.ErrInternal(fmt.Sprintf("execute timeout package failed: %v", r)),
+			}
+		}
+	}()
+	return app.ExecuteTimeoutPackage(ctx, payload)
+}
@@ -1,6 +1,11 @@
 package ibc
 
 import (
+	"encoding/binary"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -19,3 +24,90 @@ func newPackageCollector() *packageCollector {
 		collectedPackages: nil,
 	}
 }
+
+const (
+	packageMetaLength    = 16
+	packageTimeoutLength = 16
+)
+
+// packageMeta records when a package was written into the outbox
+type packageMeta struct {
+	height int64
+	time   int64 // unix seconds of the block
+}
+
+func (m packageMeta) encode() []byte {
+	bz := make([]byte, packageMetaLength)
+	binary.BigEndian.PutUint64(bz[:8], uint64(m.height))
+	binary.BigEndian.PutUint64(bz[8:], uint64(m.time))
+	return bz
+}
+
+func decodePackageMeta(bz []byte) (packageMeta, bool) {
+	if len(bz) != packageMetaLength {
+		return packageMeta{}, false
+	}
+	return packageMeta{
+		height: int64(binary.BigEndian.Uint64(bz[:8])),
+		time:   int64(binary.BigEndian.Uint64(bz[8:])),
+	}, true
+}
+
+// PackageTimeout is the timeout of an outbound package, a zero height or time disables that part of the timeout
+type PackageTimeout struct {
+	Height int64 `json:"height"`
+	Time   int64 `json:"time"` // unix seconds
+}
+
+func (t PackageTimeout) IsZero() bool {
+	return t.Height == 0 && t.Time == 0
+}
+
+func (t PackageTimeout) encode() []byte {
+	bz := make([]byte, packageTimeoutLength)
+	binary.BigEndian.PutUint64(bz[:8], uint64(t.Height))
+	binary.BigEndian.PutUint64(bz[8:], uint64(t.Time))
+	return bz
+}
+
+func decodePackageTimeout(bz []byte) (PackageTimeout, bool) {
+	if len(bz) != packageTimeoutLength {
+		return PackageTimeout{}, false
+	}
+	return PackageTimeout{
+		Height: int64(binary.BigEndian.Uint64(bz[:8])),
+		Time:   int64(binary.BigEndian.Uint64(bz[8:])),
+	}, true
+}
+
+// OutboxPackage is a pending package in the outbox of a (dest chain, channel) pair.
+// CreatedHeight and CreatedTime are zero for packages written before metadata was recorded.
+type OutboxPackage struct {
+	Sequence      uint64          `json:"sequence"`
+	Key           cmn.HexBytes    `json:"key"`
+	Payload       cmn.HexBytes    `json:"payload"`
+	CreatedHeight int64           `json:"created_height"`
+	CreatedTime   int64           `json:"created_time"`
+	Age           int64           `json:"age"` // in blocks
+	Expired       bool            `json:"expired"`
+	Timeout       *PackageTimeout `json:"timeout,omitempty"`
+
+	// filled by clients which fetch the package again with a merkle proof
+	ProofHeight int64         `json:"proof_height,omitempty"`
+	Proof       *merkle.Proof `json:"proof,omitempty"`
+}
+
+// Outbox is a page of pending packages of a (dest chain, channel) pair
+type Outbox struct {
+	DestChainID   sdk.ChainID     `json:"dest_chain_id"`
+	ChannelID     sdk.ChannelID   `json:"channel_id"`
+	Height        int64           `json:"height"`
+	SendSequence  uint64          `json:"send_sequence"` // next sequence to be used
+	Packages      []OutboxPackage `json:"packages"`
+	Gaps          []uint64        `json:"gaps"`           // missing sequences between pending packages
+	GapsTruncated bool            `json:"gaps_truncated"` // more gaps than MaxOutboxGaps
+	ExpiredCount  int             `json:"expired_count"`  // packages older than the retention window
+	HasMore       bool            `json:"has_more"`
+	NextSequence  uint64          `json:"next_sequence"` // start sequence of the next page
+	Retention     int64           `json:"retention"`     // retention window in blocks
+}
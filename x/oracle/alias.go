@@ -10,6 +10,10 @@ const (
 	SuccessStatusText = types.SuccessStatusText
 	FailedStatusText  = types.FailedStatusText
 	DefaultParamSpace = keeper.DefaultParamSpace
+
+	VoteStrategyPower       = types.VoteStrategyPower
+	VoteStrategyEqualWeight = types.VoteStrategyEqualWeight
+	VoteStrategyQuorum      = types.VoteStrategyQuorum
 )
 
 var (
@@ -34,9 +38,10 @@ var (
 	StatusTextToString = types.StatusTextToString
 	StringToStatusText = types.StringToStatusText
 
-	NewClaimMsg = types.NewClaimMsg
-	RouteOracle = types.RouteOracle
-	GetClaimId  = types.GetClaimId
+	NewClaimMsg      = types.NewClaimMsg
+	NewBatchClaimMsg = types.NewBatchClaimMsg
+	RouteOracle      = types.RouteOracle
+	GetClaimId       = types.GetClaimId
 )
 
 type (
@@ -47,5 +52,10 @@ type (
 	Status     = types.Status
 	StatusText = types.StatusText
 
-	ClaimMsg = types.ClaimMsg
+	ClaimMsg      = types.ClaimMsg
+	BatchClaimMsg = types.BatchClaimMsg
+	BatchClaim    = types.BatchClaim
+
+	VoteStrategy        = keeper.VoteStrategy
+	VoteStrategySetting = types.VoteStrategySetting
 )
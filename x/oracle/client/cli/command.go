@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+const (
+	flagSideChainId = "side-chain-id"
+	flagLimit       = "limit"
+	flagValidator   = "validator"
+	flagSortBy      = "sort-by"
+	flagAscending   = "ascending"
+	flagChannelId   = "channel-id"
+	flagSequence    = "sequence"
+)
+
+func AddCommands(cmd *cobra.Command, cdc *amino.Codec) {
+	oracleCmd := &cobra.Command{
+		Use:   "oracle",
+		Short: "oracle commands",
+	}
+	oracleCmd.AddCommand(
+		client.GetCommands(
+			GetCmdQueryPendingProphecies(cdc),
+			GetCmdQueryRelayerStats(cdc),
+			GetCmdSimulatePackage(cdc))...)
+	cmd.AddCommand(oracleCmd)
+}
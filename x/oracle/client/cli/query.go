@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// GetCmdQueryPendingProphecies implements the query pending prophecies command.
+func GetCmdQueryPendingProphecies(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending-prophecies",
+		Short: "Query the prophecies that have not reached consensus yet, from the oldest",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := types.QueryPendingPropheciesParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				Limit:       viper.GetInt(flagLimit),
+			}
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s", types.RouteOracle, types.QueryPendingProphecies), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "only show the prophecies of the side chain")
+	cmd.Flags().Int(flagLimit, types.DefaultPendingPropheciesLimit, "maximum number of prophecies to return")
+	return cmd
+}
+
+// GetCmdQueryRelayerStats implements the query relayer stats command.
+func GetCmdQueryRelayerStats(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relayer-stats",
+		Short: "Query the leaderboard of oracle relayers by their claims",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := types.QueryRelayerStatsParams{
+				SortBy:    viper.GetString(flagSortBy),
+				Ascending: viper.GetBool(flagAscending),
+				Limit:     viper.GetInt(flagLimit),
+			}
+			if validator := viper.GetString(flagValidator); validator != "" {
+				valAddr, err := sdk.ValAddressFromBech32(validator)
+				if err != nil {
+					return err
+				}
+				params.Validator = valAddr
+			}
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s", types.RouteOracle, types.QueryRelayerStats), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagValidator, "", "only show the stats of the validator")
+	cmd.Flags().String(flagSortBy, types.RelayerStatsSortByMatchRate, "sort the relayers by match_rate, claims, latency or rewards")
+	cmd.Flags().Bool(flagAscending, false, "put the relayers with the lowest value first")
+	cmd.Flags().Int(flagLimit, types.DefaultRelayerStatsLimit, "maximum number of relayers to return")
+	return cmd
+}
+
+// GetCmdSimulatePackage implements the simulate package command.
+func GetCmdSimulatePackage(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-package [hex-package]",
+		Short: "Simulate the delivery of a raw package, including the package header, without committing anything",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			rawPackage, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid hex package: %v", err)
+			}
+			params := types.QuerySimulatePackageParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				ChannelId:   sdk.ChannelID(viper.GetUint(flagChannelId)),
+				Package:     rawPackage,
+			}
+			if cmd.Flags().Changed(flagSequence) {
+				sequence := viper.GetUint64(flagSequence)
+				params.Sequence = &sequence
+			}
+			queryData, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s", types.RouteOracle, types.QuerySimulatePackage), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the side chain the package comes from")
+	cmd.Flags().Uint8(flagChannelId, 0, "the channel of the package")
+	cmd.Flags().Uint64(flagSequence, 0, "the receive sequence of the package, the current receive sequence of the channel by default")
+	return cmd
+}
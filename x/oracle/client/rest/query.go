@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(
+		"/oracle/simulate_package",
+		simulatePackageHandlerFn(cliCtx, cdc),
+	).Methods("POST")
+}
+
+type simulatePackageReq struct {
+	SideChainId string        `json:"side_chain_id"`
+	ChannelId   sdk.ChannelID `json:"channel_id"`
+	Sequence    *uint64       `json:"sequence,omitempty"`
+	// Package is the hex encoded raw package, including the package header
+	Package string `json:"package"`
+}
+
+// http request handler to simulate the delivery of a raw package
+func simulatePackageHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var req simulatePackageReq
+		if err := json.Unmarshal(body, &req); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rawPackage, err := hex.DecodeString(strings.TrimPrefix(req.Package, "0x"))
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid hex package: %v", err))
+			return
+		}
+
+		params := types.QuerySimulatePackageParams{
+			SideChainId: req.SideChainId,
+			ChannelId:   req.ChannelId,
+			Sequence:    req.Sequence,
+			Package:     rawPackage,
+		}
+		bz, err := json.Marshal(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.RouteOracle, types.QuerySimulatePackage), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
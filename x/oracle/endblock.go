@@ -0,0 +1,14 @@
+package oracle
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	sdk.Upgrade(sdk.ProphecyExpiry, nil, func() {
+		keeper.IndexLegacyProphecies(ctx)
+		keeper.PruneExpiredProphecies(ctx)
+	}, func() {
+		keeper.PruneExpiredProphecies(ctx)
+	})
+}
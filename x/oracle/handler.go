@@ -2,6 +2,7 @@ package oracle
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"runtime/debug"
 	"strconv"
@@ -18,6 +19,11 @@ func NewHandler(keeper Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case types.ClaimMsg:
 			return handleClaimMsg(ctx, keeper, msg)
+		case types.BatchClaimMsg:
+			if !sdk.IsUpgrade(sdk.OracleBatchClaim) {
+				return sdk.ErrMsgNotSupported("oracle batch claim not activated yet").Result()
+			}
+			return handleBatchClaimMsg(ctx, keeper, msg)
 		default:
 			errMsg := "Unrecognized oracle msg type"
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -39,98 +45,310 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 		return sdkErr.Result()
 	}
 
+	events, effects, sdkErr := handleProphecy(ctx, oracleKeeper, msg.ChainId, prophecy, msg.Payload)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+	effects.apply(ctx, oracleKeeper)
+	return sdk.Result{
+		Events: events,
+	}
+}
+
+func handleBatchClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg types.BatchClaimMsg) sdk.Result {
+	results := make([]types.BatchClaimResult, 0, len(msg.Claims))
+	events := make([]sdk.Event, 0)
+	var firstErr sdk.Error
+	succeeded := 0
+	for _, batchClaim := range msg.Claims {
+		result := types.BatchClaimResult{Sequence: batchClaim.Sequence}
+
+		// every claim is processed in its own cache context, so that a failed claim does not revert the others
+		cacheCtx, write := ctx.CacheContext()
+		prophecy, claimEvents, effects, sdkErr := handleBatchClaim(cacheCtx, oracleKeeper, msg.ChainId, sdk.ValAddress(msg.ValidatorAddress), batchClaim)
+		if sdkErr != nil {
+			if firstErr == nil {
+				firstErr = sdkErr
+			}
+			result.Code = sdkErr.ABCICode()
+			result.Log = sdkErr.ABCILog()
+		} else {
+			write()
+			effects.apply(ctx, oracleKeeper)
+			succeeded++
+			result.Status = prophecy.Status.Text
+			events = append(events, claimEvents...)
+		}
+		results = append(results, result)
+	}
+
+	// the message fails only if none of the claims succeeds
+	if succeeded == 0 && firstErr != nil {
+		return firstErr.Result()
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+	return sdk.Result{
+		Data:   data,
+		Events: events,
+	}
+}
+
+// handleBatchClaim processes a claim of BatchClaimMsg, the claims of the sequences after the current one
+// are recorded, their prophecies are executed once the previous sequences are executed.
+func handleBatchClaim(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, validator sdk.ValAddress, batchClaim types.BatchClaim) (types.Prophecy, []sdk.Event, sideEffects, sdk.Error) {
+	sequence := oracleKeeper.ScKeeper.GetReceiveSequence(ctx, chainId, types.RelayPackagesChannelId)
+	if batchClaim.Sequence < sequence || batchClaim.Sequence >= sequence+types.MaxBatchClaims {
+		return types.Prophecy{}, nil, sideEffects{}, types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d, claims should be within %d sequences",
+			types.RelayPackagesChannelId, sequence, types.MaxBatchClaims))
+	}
+
+	claim := NewClaim(types.GetClaimId(chainId, types.RelayPackagesChannelId, batchClaim.Sequence),
+		validator, hex.EncodeToString(batchClaim.Payload))
+	prophecy, sdkErr := oracleKeeper.ProcessClaim(ctx, claim)
+	if sdkErr != nil {
+		return types.Prophecy{}, nil, sideEffects{}, sdkErr
+	}
+
+	if batchClaim.Sequence != sequence {
+		if prophecy.Status.Text == types.FailedStatusText {
+			oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
+		}
+		return prophecy, nil, sideEffects{}, nil
+	}
+
+	events, effects, sdkErr := handleProphecy(ctx, oracleKeeper, chainId, prophecy, batchClaim.Payload)
+	return prophecy, events, effects, sdkErr
+}
+
+// handleProphecy executes the packages of the prophecy of the current sequence once it succeeds, and then
+// the prophecies of the following sequences that have already succeeded.
+func handleProphecy(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, prophecy types.Prophecy, payload []byte) ([]sdk.Event, sideEffects, sdk.Error) {
 	if prophecy.Status.Text == types.FailedStatusText {
 		oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
-		return sdk.Result{}
+		return nil, sideEffects{}, nil
 	}
 
 	if prophecy.Status.Text != types.SuccessStatusText {
-		return sdk.Result{}
+		return nil, sideEffects{}, nil
+	}
+
+	events, effects, sdkErr := executePackages(ctx, oracleKeeper, chainId, prophecy, payload)
+	if sdkErr != nil {
+		return nil, sideEffects{}, sdkErr
 	}
 
+	if sdk.IsUpgrade(sdk.OracleBatchClaim) {
+		succeededEvents, succeededEffects := executeSucceededProphecies(ctx, oracleKeeper, chainId)
+		events = append(events, succeededEvents...)
+		effects.merge(succeededEffects)
+	}
+	return events, effects, nil
+}
+
+// executeSucceededProphecies executes the prophecies that succeeded before the prophecies of their previous
+// sequences, which happens when the claims of BatchClaimMsg reach consensus out of order.
+func executeSucceededProphecies(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID) ([]sdk.Event, sideEffects) {
+	events := make([]sdk.Event, 0)
+	var effects sideEffects
+	for {
+		sequence := oracleKeeper.ScKeeper.GetReceiveSequence(ctx, chainId, types.RelayPackagesChannelId)
+		prophecy, found := oracleKeeper.GetProphecy(ctx, types.GetClaimId(chainId, types.RelayPackagesChannelId, sequence))
+		if !found || prophecy.Status.Text != types.SuccessStatusText {
+			return events, effects
+		}
+
+		payload, err := hex.DecodeString(prophecy.Status.FinalClaim)
+		if err != nil {
+			oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
+			return events, effects
+		}
+		cacheCtx, write := ctx.CacheContext()
+		packageEvents, packageEffects, sdkErr := executePackages(cacheCtx, oracleKeeper, chainId, prophecy, payload)
+		if sdkErr != nil {
+			// the relayers are able to claim the sequence again
+			ctx.Logger().With("module", "oracle").Error("execute succeeded prophecy failed", "id", prophecy.ID, "err", sdkErr)
+			oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
+			return events, effects
+		}
+		write()
+		events = append(events, packageEvents...)
+		effects.merge(packageEffects)
+	}
+}
+
+// executePackages executes the packages of a succeeded prophecy and deletes the prophecy
+func executePackages(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, prophecy types.Prophecy, payload []byte) ([]sdk.Event, sideEffects, sdk.Error) {
 	packages := types.Packages{}
-	err := rlp.DecodeBytes(msg.Payload, &packages)
+	err := rlp.DecodeBytes(payload, &packages)
 	if err != nil {
-		return types.ErrInvalidPayload("decode packages error").Result()
+		return nil, sideEffects{}, types.ErrInvalidPayload("decode packages error")
 	}
 
+	// the relayers whose claims agreed with the final claim
+	relayers := prophecy.ClaimValidators[prophecy.Status.FinalClaim]
+
 	events := make([]sdk.Event, 0, len(packages))
+	var effects sideEffects
 	for _, pack := range packages {
-		event, sdkErr := handlePackage(ctx, oracleKeeper, msg.ChainId, &pack)
+		packResult, sdkErr := handlePackage(ctx, oracleKeeper, chainId, &pack, relayers)
 		if sdkErr != nil {
 			// only do log, but let reset package get chance to execute.
 			ctx.Logger().With("module", "oracle").Error(fmt.Sprintf("process package failed, channel=%d, sequence=%d, error=%v", pack.ChannelId, pack.Sequence, sdkErr))
-			return sdkErr.Result()
+			return nil, sideEffects{}, sdkErr
 		} else {
 			ctx.Logger().With("module", "oracle").Info(fmt.Sprintf("process package success, channel=%d, sequence=%d", pack.ChannelId, pack.Sequence))
 		}
-		events = append(events, event)
+		events = append(events, packResult.event)
+		effects.merge(packResult.effects)
 
 		// increase channel sequence
-		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, pack.ChannelId)
+		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, chainId, pack.ChannelId)
 	}
 
 	// delete prophecy when execute claim success
 	oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
-	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, types.RelayPackagesChannelId)
+	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, chainId, types.RelayPackagesChannelId)
 
-	return sdk.Result{
-		Events: events,
+	return events, effects, nil
+}
+
+// packageResult is the outcome of a package executed by its cross chain application
+type packageResult struct {
+	event   sdk.Event
+	crash   bool
+	result  sdk.ExecuteResult
+	effects sideEffects
+}
+
+type proposerFee struct {
+	txHash string
+	fee    sdk.Fee
+}
+
+// sideEffects are the changes made by the packages outside of the store, e.g. to the fee pool. They are
+// collected while the packages are executed in a cache context and applied only if the context is written.
+type sideEffects struct {
+	fees         []proposerFee
+	changedAddrs []sdk.AccAddress
+}
+
+func (e *sideEffects) merge(other sideEffects) {
+	e.fees = append(e.fees, other.fees...)
+	e.changedAddrs = append(e.changedAddrs, other.changedAddrs...)
+}
+
+func (e sideEffects) apply(ctx sdk.Context, oracleKeeper Keeper) {
+	if !ctx.IsDeliverTx() {
+		return
+	}
+	if len(e.changedAddrs) != 0 {
+		oracleKeeper.Pool.AddAddrs(e.changedAddrs)
+	}
+	for _, f := range e.fees {
+		fees.Pool.AddAndCommitFee(f.txHash, f.fee)
 	}
 }
 
-func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package) (sdk.Event, sdk.Error) {
+func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package, relayers []sdk.ValAddress) (packageResult, sdk.Error) {
 	logger := ctx.Logger().With("module", "x/oracle")
 
 	crossChainApp := oracleKeeper.ScKeeper.GetCrossChainApp(ctx, pack.ChannelId)
 	if crossChainApp == nil {
-		return sdk.Event{}, types.ErrChannelNotRegistered(fmt.Sprintf("channel %d not registered", pack.ChannelId))
+		return packageResult{}, types.ErrChannelNotRegistered(fmt.Sprintf("channel %d not registered", pack.ChannelId))
 	}
 
 	sequence := oracleKeeper.ScKeeper.GetReceiveSequence(ctx, chainId, pack.ChannelId)
 	if sequence != pack.Sequence {
-		return sdk.Event{}, types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", pack.ChannelId, sequence))
+		return packageResult{}, types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", pack.ChannelId, sequence))
 	}
 
 	packageType, relayFee, err := sTypes.DecodePackageHeader(pack.Payload)
 	if err != nil {
-		return sdk.Event{}, types.ErrInvalidPayloadHeader(err.Error())
+		return packageResult{}, types.ErrInvalidPayloadHeader(err.Error())
 	}
 
-	if !sdk.IsValidCrossChainPackageType(packageType) {
-		return sdk.Event{}, types.ErrInvalidPackageType()
+	// the answers to the packages sent with a timeout are accepted after the IBCPackageTimeout upgrade
+	isTimeoutAnswer := sdk.IsUpgrade(sdk.IBCPackageTimeout) &&
+		(packageType == sdk.TimeoutAckCrossChainPackageType || packageType == sdk.TimeoutFailAckCrossChainPackageType)
+	if !sdk.IsValidCrossChainPackageType(packageType) && !isTimeoutAnswer {
+		return packageResult{}, types.ErrInvalidPackageType()
+	}
+
+	// only syn packages are rate limited, the ack and fail ack packages settle the packages sent before
+	// and must not be held back by a paused channel. A refused syn package is answered with a fail ack
+	// package, so that the claim goes through, the pause sticks and the source chain refunds it.
+	var refusedErr sdk.Error
+	if packageType == sdk.SynCrossChainPackageType {
+		value := oracleKeeper.ScKeeper.GetPackageValue(pack.ChannelId, packageType, pack.Payload[sTypes.PackageHeaderLength:])
+		refusedErr = oracleKeeper.ScKeeper.ConsumeChannelQuota(ctx, chainId, pack.ChannelId, value)
 	}
 
 	feeAmount := relayFee.Int64()
 	if feeAmount < 0 {
-		return sdk.Event{}, types.ErrFeeOverflow("relayFee overflow")
+		return packageResult{}, types.ErrFeeOverflow("relayFee overflow")
 	}
 
 	fee := sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: feeAmount}}
 	_, _, sdkErr := oracleKeeper.BkKeeper.SubtractCoins(ctx, sdk.PegAccount, fee)
 	if sdkErr != nil {
-		return sdk.Event{}, sdkErr
+		return packageResult{}, sdkErr
 	}
 
-	if ctx.IsDeliverTx() {
-		// add changed accounts
-		oracleKeeper.Pool.AddAddrs([]sdk.AccAddress{sdk.PegAccount})
+	proposerTokens := fee
+	changedAddrs := []sdk.AccAddress{sdk.PegAccount}
+	if sdk.IsUpgrade(sdk.OracleRelayerReward) {
+		proposerAmount, rewardedAddrs, sdkErr := oracleKeeper.DistributeRelayerReward(ctx, relayers, feeAmount)
+		if sdkErr != nil {
+			return packageResult{}, sdkErr
+		}
+		proposerTokens = sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: proposerAmount}}
+		changedAddrs = append(changedAddrs, rewardedAddrs...)
+	}
 
-		// add fee
-		fees.Pool.AddAndCommitFee(
-			fmt.Sprintf("cross_communication:%d:%d:%v", pack.ChannelId, pack.Sequence, packageType),
-			sdk.Fee{
-				Tokens: fee,
+	// the changed accounts and the fee are applied by the caller once the package is committed
+	effects := sideEffects{
+		fees: []proposerFee{{
+			txHash: fmt.Sprintf("cross_communication:%d:%d:%v", pack.ChannelId, pack.Sequence, packageType),
+			fee: sdk.Fee{
+				Tokens: proposerTokens,
 				Type:   sdk.FeeForProposer,
 			},
-		)
+		}},
+		changedAddrs: changedAddrs,
+	}
+	// before the OracleBatchClaim upgrade the fee and the changed accounts are committed right away
+	if !sdk.IsUpgrade(sdk.OracleBatchClaim) {
+		effects.apply(ctx, oracleKeeper)
+		effects = sideEffects{}
 	}
 
-	cacheCtx, write := ctx.CacheContext()
-	crash, result := executeClaim(cacheCtx, crossChainApp, pack.Payload, packageType, feeAmount)
-	if result.IsOk() {
-		write()
-	} else if ctx.IsDeliverTx() {
+	// the answers to the packages sent with a timeout are rejected once the package has timed out,
+	// otherwise they are executed as ack or fail ack packages without the timeout header
+	payload, executeType := pack.Payload, packageType
+	if isTimeoutAnswer {
+		appPayload, sdkErr := oracleKeeper.IbcKeeper.AcceptPackageAnswer(ctx, chainId, pack.ChannelId, pack.Payload[sTypes.PackageHeaderLength:])
+		if sdkErr != nil {
+			refusedErr = sdkErr
+		} else {
+			payload = append(pack.Payload[:sTypes.PackageHeaderLength:sTypes.PackageHeaderLength], appPayload...)
+		}
+		executeType = sdk.WithoutTimeout(packageType)
+	}
+
+	// a refused syn package is answered with a fail ack package
+	crash, result := packageType == sdk.SynCrossChainPackageType, sdk.ExecuteResult{Err: refusedErr}
+	if refusedErr == nil {
+		cacheCtx, write := ctx.CacheContext()
+		crash, result = executeClaim(cacheCtx, crossChainApp, payload, executeType, feeAmount)
+		if result.IsOk() {
+			write()
+		}
+	}
+	if !result.IsOk() && ctx.IsDeliverTx() {
 		oracleKeeper.Metrics.ErrNumOfChannels.With("channel_id", fmt.Sprintf("%d", pack.ChannelId)).Add(1)
 		destChainName, err := oracleKeeper.ScKeeper.GetDestChainName(chainId)
 		if err != nil {
@@ -156,7 +374,7 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 			}
 			if ibcErr != nil {
 				logger.Error("failed to write FailAckCrossChainPackage", "err", err)
-				return sdk.Event{}, ibcErr
+				return packageResult{}, ibcErr
 			}
 			sendSequence = int64(sendSeq)
 		} else {
@@ -165,7 +383,7 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 					pack.ChannelId, sdk.AckCrossChainPackageType, result.Payload)
 				if err != nil {
 					logger.Error("failed to write AckCrossChainPackage", "err", err)
-					return sdk.Event{}, err
+					return packageResult{}, err
 				}
 				sendSequence = int64(sendSeq)
 			}
@@ -203,7 +421,7 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		Attributes: resultTags,
 	}
 
-	return event, nil
+	return packageResult{event: event, crash: crash, result: result, effects: effects}, nil
 }
 
 func executeClaim(ctx sdk.Context, app sdk.CrossChainApplication, payload []byte, packageType sdk.CrossChainPackageType, relayerFee int64) (crash bool, result sdk.ExecuteResult) {
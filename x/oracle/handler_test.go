@@ -0,0 +1,182 @@
+package oracle
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+func TestRateLimitedPackage(t *testing.T) {
+	o := setupTestOracle(t)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ChannelRateLimit, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	chainId := sdk.ChainID(1)
+	ctx := o.ctx.WithBlockHeight(1)
+	o.scKeeper.SetChannelRateLimit(ctx, chainId, o.channelId, sTypes.ChannelRateLimit{PerBlockCap: 5})
+
+	// the syn package over the cap is refused with a fail ack package and the channel is paused
+	synPackage := append(sTypes.EncodePackageHeader(sdk.SynCrossChainPackageType, *big.NewInt(10)), 7)
+	res, sdkErr := handlePackage(ctx, o.keeper, chainId, &types.Package{ChannelId: o.channelId, Payload: synPackage}, nil)
+	require.Nil(t, sdkErr)
+	require.True(t, res.crash)
+	require.Equal(t, sdk.ToABCICode(sidechain.DefaultCodespace, sidechain.CodeRateLimitExceeded), res.result.Code())
+	require.True(t, o.bk.GetCoins(ctx, o.receiver).IsZero())
+	require.EqualValues(t, 1, o.scKeeper.GetSendSequence(ctx, chainId, o.channelId))
+	pause, found := o.scKeeper.GetChannelPause(ctx, chainId, o.channelId)
+	require.True(t, found)
+	require.EqualValues(t, 1, pause.Height)
+
+	// syn packages of a paused channel are refused as well
+	synPackage = append(sTypes.EncodePackageHeader(sdk.SynCrossChainPackageType, *big.NewInt(10)), 1)
+	res, sdkErr = handlePackage(ctx, o.keeper, chainId, &types.Package{ChannelId: o.channelId, Payload: synPackage}, nil)
+	require.Nil(t, sdkErr)
+	require.True(t, res.crash)
+	require.Equal(t, sdk.ToABCICode(sidechain.DefaultCodespace, sidechain.CodeChannelPaused), res.result.Code())
+	require.EqualValues(t, 2, o.scKeeper.GetSendSequence(ctx, chainId, o.channelId))
+
+	// ack and fail ack packages settle the packages sent before and go through the paused channel
+	for _, packageType := range []sdk.CrossChainPackageType{sdk.AckCrossChainPackageType, sdk.FailAckCrossChainPackageType} {
+		ackPackage := append(sTypes.EncodePackageHeader(packageType, *big.NewInt(0)), 7)
+		res, sdkErr = handlePackage(ctx, o.keeper, chainId, &types.Package{ChannelId: o.channelId, Payload: ackPackage}, nil)
+		require.Nil(t, sdkErr)
+		require.False(t, res.crash)
+		require.True(t, res.result.IsOk())
+	}
+	require.EqualValues(t, 2, o.scKeeper.GetSendSequence(ctx, chainId, o.channelId))
+}
+
+// refundApp refunds the sender when a package fails or times out
+type refundApp struct {
+	refunds int
+}
+
+func (app *refundApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *refundApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *refundApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	app.refunds++
+	return sdk.ExecuteResult{}
+}
+
+func (app *refundApp) ExecuteTimeoutPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	app.refunds++
+	return sdk.ExecuteResult{}
+}
+
+func TestAckAfterTimeout(t *testing.T) {
+	o := setupTestOracle(t)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCPackageTimeout, 1)
+	sdk.UpgradeMgr.SetHeight(10)
+	chainId := sdk.ChainID(1)
+	channelId := sdk.ChannelID(3)
+	app := &refundApp{}
+	require.NoError(t, o.scKeeper.RegisterChannel("refund", channelId, app))
+	o.scKeeper.SetChannelSendPermission(o.ctx, chainId, channelId, sdk.ChannelAllow)
+
+	ctx := o.ctx.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	seq0, sdkErr := o.keeper.IbcKeeper.CreateRawIBCPackageByIdWithTimeout(ctx, chainId, channelId, sdk.SynCrossChainPackageType, []byte{0x01}, ibc.PackageTimeout{Height: 15})
+	require.Nil(t, sdkErr)
+	seq1, sdkErr := o.keeper.IbcKeeper.CreateRawIBCPackageByIdWithTimeout(ctx, chainId, channelId, sdk.SynCrossChainPackageType, []byte{0x02}, ibc.PackageTimeout{Height: 20})
+	require.Nil(t, sdkErr)
+
+	ctx = ctx.WithBlockHeight(15)
+	ibc.EndBlocker(ctx, o.keeper.IbcKeeper)
+	require.Equal(t, 1, app.refunds)
+
+	// the fail ack of the timed out package arrives late and is rejected
+	failAck := append(sTypes.EncodePackageHeader(sdk.TimeoutFailAckCrossChainPackageType, *big.NewInt(0)), sTypes.EncodePackageTimeoutHeader(seq0, 15, 0)...)
+	res, sdkErr := handlePackage(ctx, o.keeper, chainId, &types.Package{ChannelId: channelId, Payload: append(failAck, 0x01)}, nil)
+	require.Nil(t, sdkErr)
+	require.False(t, res.crash)
+	require.Equal(t, sdk.ToABCICode(ibc.DefaultCodespace, ibc.CodePackageTimedOut), res.result.Code())
+	require.Equal(t, 1, app.refunds)
+	require.False(t, o.keeper.IbcKeeper.IsPackageTimedOut(ctx, chainId, channelId, seq0))
+
+	// the fail ack of the other package is executed and its timeout is cleared
+	failAck = append(sTypes.EncodePackageHeader(sdk.TimeoutFailAckCrossChainPackageType, *big.NewInt(0)), sTypes.EncodePackageTimeoutHeader(seq1, 20, 0)...)
+	res, sdkErr = handlePackage(ctx, o.keeper, chainId, &types.Package{ChannelId: channelId, Payload: append(failAck, 0x02)}, nil)
+	require.Nil(t, sdkErr)
+	require.True(t, res.result.IsOk())
+	require.Equal(t, 2, app.refunds)
+	ibc.EndBlocker(ctx.WithBlockHeight(20), o.keeper.IbcKeeper)
+	require.Equal(t, 2, app.refunds)
+	require.False(t, o.keeper.IbcKeeper.IsPackageTimedOut(ctx, chainId, channelId, seq1))
+
+	// the plain answers of the channel carry no timeout header
+	res, sdkErr = handlePackage(ctx, o.keeper, chainId, &types.Package{ChannelId: channelId,
+		Payload: append(sTypes.EncodePackageHeader(sdk.FailAckCrossChainPackageType, *big.NewInt(0)), 0x03)}, nil)
+	require.Nil(t, sdkErr)
+	require.True(t, res.result.IsOk())
+	require.Equal(t, 3, app.refunds)
+}
+
+func TestTimeoutPackageTypeUpgrade(t *testing.T) {
+	o := setupTestOracle(t)
+	defer sdk.UpgradeMgr.Reset()
+	chainId := sdk.ChainID(1)
+	channelId := sdk.ChannelID(3)
+	app := &refundApp{}
+	require.NoError(t, o.scKeeper.RegisterChannel("refund", channelId, app))
+
+	failAck := append(sTypes.EncodePackageHeader(sdk.TimeoutFailAckCrossChainPackageType, *big.NewInt(0)), sTypes.EncodePackageTimeoutHeader(0, 15, 0)...)
+	_, sdkErr := handlePackage(o.ctx, o.keeper, chainId, &types.Package{ChannelId: channelId, Payload: append(failAck, 0x01)}, nil)
+	require.NotNil(t, sdkErr)
+	require.Equal(t, types.ErrInvalidPackageType().Code(), sdkErr.Code())
+	require.Equal(t, 0, app.refunds)
+}
+
+func TestBatchClaimMsgUpgrade(t *testing.T) {
+	o := setupTestOracle(t)
+	defer sdk.UpgradeMgr.Reset()
+	msg := types.NewBatchClaimMsg(sdk.ChainID(1), []types.BatchClaim{{Sequence: 0, Payload: []byte{0x01}}}, sdk.AccAddress([]byte("validator-address---")))
+	res := NewHandler(o.keeper)(o.ctx, msg)
+	require.Equal(t, sdk.ErrMsgNotSupported("").ABCICode(), res.Code)
+}
+
+func TestPackageSideEffects(t *testing.T) {
+	o := setupTestOracle(t)
+	defer fees.Pool.Clear()
+	defer sdk.UpgradeMgr.Reset()
+	ctx := o.ctx.WithRunTxMode(sdk.RunTxModeDeliver)
+
+	// the fee of the package is committed right away before the upgrade
+	synPackage := append(sTypes.EncodePackageHeader(sdk.SynCrossChainPackageType, *big.NewInt(10)), 7)
+	cacheCtx, _ := ctx.CacheContext()
+	res, sdkErr := handlePackage(cacheCtx, o.keeper, sdk.ChainID(1), &types.Package{ChannelId: o.channelId, Payload: synPackage}, nil)
+	require.Nil(t, sdkErr)
+	require.EqualValues(t, 10, fees.Pool.BlockFees().Tokens.AmountOf(sdk.NativeTokenSymbol))
+	require.Len(t, res.effects.fees, 0)
+	fees.Pool.Clear()
+
+	// the fee of the package is not added to the fee pool until the caller commits the package
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.OracleBatchClaim, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	cacheCtx, _ = ctx.CacheContext()
+	res, sdkErr = handlePackage(cacheCtx, o.keeper, sdk.ChainID(1), &types.Package{ChannelId: o.channelId, Payload: synPackage}, nil)
+	require.Nil(t, sdkErr)
+	require.True(t, fees.Pool.BlockFees().Tokens.IsZero())
+	require.Len(t, res.effects.fees, 1)
+	require.Equal(t, []sdk.AccAddress{sdk.PegAccount}, res.effects.changedAddrs)
+
+	// nothing is applied out of DeliverTx
+	res.effects.apply(o.ctx, o.keeper)
+	require.True(t, fees.Pool.BlockFees().Tokens.IsZero())
+	res.effects.apply(ctx, o.keeper)
+	require.EqualValues(t, 10, fees.Pool.BlockFees().Tokens.AmountOf(sdk.NativeTokenSymbol))
+}
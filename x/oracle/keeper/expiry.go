@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func (k Keeper) GetProphecyExpiryWindow(ctx sdk.Context) (window int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProphecyExpiryWindow, &window)
+	return
+}
+
+func (k Keeper) setProphecyExpiryQueue(ctx sdk.Context, prophecy types.Prophecy) {
+	ctx.KVStore(k.storeKey).Set(types.GetProphecyExpiryQueueKey(prophecy.CreationHeight, prophecy.ID), []byte{})
+}
+
+func (k Keeper) deleteProphecyExpiryQueue(ctx sdk.Context, prophecy types.Prophecy) {
+	ctx.KVStore(k.storeKey).Delete(types.GetProphecyExpiryQueueKey(prophecy.CreationHeight, prophecy.ID))
+}
+
+// PruneExpiredProphecies deletes the prophecies that stay pending for longer than the expiry window,
+// an event with the validators that disagreed with the leading claim is emitted for each of them.
+func (k Keeper) PruneExpiredProphecies(ctx sdk.Context) {
+	window := k.GetProphecyExpiryWindow(ctx)
+	if window <= 0 || ctx.BlockHeight() <= window {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	prefixLength := len(types.ProphecyExpiryQueuePrefix) + 8
+	iterator := store.Iterator(types.ProphecyExpiryQueuePrefix, types.GetProphecyExpiryQueueEndKey(ctx.BlockHeight()-window))
+	var ids []string
+	for ; iterator.Valid(); iterator.Next() {
+		ids = append(ids, string(iterator.Key()[prefixLength:]))
+	}
+	iterator.Close()
+
+	events := make(sdk.Events, 0, len(ids))
+	for _, id := range ids {
+		prophecy, found := k.GetProphecy(ctx, id)
+		if !found {
+			continue
+		}
+		k.DeleteProphecy(ctx, id)
+
+		leadingClaim, disagreeing := prophecy.FindDisagreeingValidators()
+		validators := make([]string, 0, len(disagreeing))
+		for _, addr := range disagreeing {
+			validators = append(validators, addr.String())
+		}
+		events = append(events, sdk.NewEvent(types.EventTypeProphecyExpired,
+			sdk.NewAttribute(types.ProphecyId, prophecy.ID),
+			sdk.NewAttribute(types.ProphecyCreationHeight, strconv.FormatInt(prophecy.CreationHeight, 10)),
+			sdk.NewAttribute(types.ProphecyLeadingClaim, leadingClaim),
+			sdk.NewAttribute(types.ProphecyDisagreeingValidators, strings.Join(validators, ",")),
+		))
+		ctx.Logger().With("module", "oracle").Info("prune expired prophecy", "id", prophecy.ID, "creationHeight", prophecy.CreationHeight)
+	}
+	ctx.EventManager().EmitEvents(events)
+}
+
+// IndexLegacyProphecies puts the prophecies created before the ProphecyExpiry upgrade into the expiry queue,
+// their expiry window starts from the current height.
+func (k Keeper) IndexLegacyProphecies(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(nil, nil)
+	var ids []string
+	for ; iterator.Valid(); iterator.Next() {
+		if types.IsProphecyKey(iterator.Key()) {
+			ids = append(ids, string(iterator.Key()))
+		}
+	}
+	iterator.Close()
+
+	for _, id := range ids {
+		prophecy, found := k.GetProphecy(ctx, id)
+		if !found || prophecy.CreationHeight != 0 {
+			continue
+		}
+		prophecy.CreationHeight = ctx.BlockHeight()
+		k.setProphecy(ctx, prophecy)
+		k.setProphecyExpiryQueue(ctx, prophecy)
+	}
+}
+
+// GetPendingProphecies returns the pending prophecies from the oldest, only the prophecies of the chain are
+// returned if chainId is not nil.
+func (k Keeper) GetPendingProphecies(ctx sdk.Context, chainId *sdk.ChainID, limit int) []types.PendingProphecy {
+	window := k.GetProphecyExpiryWindow(ctx)
+	store := ctx.KVStore(k.storeKey)
+	prefixLength := len(types.ProphecyExpiryQueuePrefix) + 8
+	iterator := sdk.KVStorePrefixIterator(store, types.ProphecyExpiryQueuePrefix)
+	defer iterator.Close()
+
+	pending := make([]types.PendingProphecy, 0)
+	for ; iterator.Valid() && len(pending) < limit; iterator.Next() {
+		id := string(iterator.Key()[prefixLength:])
+		if chainId != nil && !strings.HasPrefix(id, fmt.Sprintf("%d:", *chainId)) {
+			continue
+		}
+		prophecy, found := k.GetProphecy(ctx, id)
+		if !found {
+			continue
+		}
+		pending = append(pending, types.NewPendingProphecy(prophecy, window))
+	}
+	return pending
+}
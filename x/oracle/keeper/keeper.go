@@ -32,6 +32,8 @@ type Keeper struct {
 
 	Metrics   *metrics.Metrics
 	pubServer *pubsub.Server
+
+	voteStrategies map[string]VoteStrategy
 }
 
 // Parameter store
@@ -39,8 +41,16 @@ const (
 	DefaultParamSpace = "oracle"
 )
 
+// ParamTypeTable for oracle module, the params added by upgrades are registered
+// whether their upgrade is active or not
 func ParamTypeTable() param.TypeTable {
-	return param.NewTypeTable().RegisterParamSet(&types.Params{})
+	return param.NewTypeTable(
+		types.ParamStoreKeyProphecyParams, sdk.Dec{},
+		types.ParamStoreKeyVoteStrategies, []types.VoteStrategySetting{},
+		types.ParamStoreKeyProphecyExpiryWindow, int64(0),
+		types.ParamStoreKeyRelayerRewardPolicy, "",
+		types.ParamStoreKeyRelayerRewardRatio, sdk.Dec{},
+	)
 }
 
 // NewKeeper creates new instances of the oracle Keeper
@@ -57,6 +67,11 @@ func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace param.Subspac
 		BkKeeper:    bkKeeper,
 		Metrics:     metrics.NopMetrics(),
 		Pool:        pool,
+		voteStrategies: map[string]VoteStrategy{
+			types.VoteStrategyPower:       NewPowerWeightedStrategy(stakeKeeper),
+			types.VoteStrategyEqualWeight: NewEqualWeightStrategy(stakeKeeper),
+			types.VoteStrategyQuorum:      NewQuorumStrategy(stakeKeeper),
+		},
 	}
 }
 
@@ -73,6 +88,19 @@ func (k *Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
 
+func (k *Keeper) SetVoteStrategies(ctx sdk.Context, settings []types.VoteStrategySetting) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyVoteStrategies, settings)
+}
+
+func (k *Keeper) SetProphecyExpiryWindow(ctx sdk.Context, window int64) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyProphecyExpiryWindow, window)
+}
+
+func (k *Keeper) SetRelayerRewardPolicy(ctx sdk.Context, policy string, ratio sdk.Dec) {
+	k.paramSpace.Set(ctx, types.ParamStoreKeyRelayerRewardPolicy, policy)
+	k.paramSpace.Set(ctx, types.ParamStoreKeyRelayerRewardRatio, ratio)
+}
+
 func (k *Keeper) SetPbsbServer(p *pubsub.Server) {
 	k.pubServer = p
 }
@@ -98,6 +126,9 @@ func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
 
 // DeleteProphecy delete prophecy for a given id
 func (k Keeper) DeleteProphecy(ctx sdk.Context, id string) {
+	if prophecy, found := k.GetProphecy(ctx, id); found && prophecy.CreationHeight > 0 {
+		k.deleteProphecyExpiryQueue(ctx, prophecy)
+	}
 	store := ctx.KVStore(k.storeKey)
 	store.Delete([]byte(id))
 }
@@ -131,6 +162,10 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 	prophecy, found := k.GetProphecy(ctx, claim.ID)
 	if !found {
 		prophecy = types.NewProphecy(claim.ID)
+		if sdk.IsUpgrade(sdk.ProphecyExpiry) {
+			prophecy.CreationHeight = ctx.BlockHeight()
+			k.setProphecyExpiryQueue(ctx, prophecy)
+		}
 	}
 
 	switch prophecy.Status.Text {
@@ -143,32 +178,22 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 	prophecy.AddClaim(claim.ValidatorAddress, claim.Payload)
 	prophecy = k.processCompletion(ctx, prophecy)
 
+	if sdk.IsUpgrade(sdk.OracleRelayerReward) {
+		k.recordClaim(ctx, prophecy, claim.ValidatorAddress)
+		if prophecy.Status.Text == types.SuccessStatusText {
+			k.recordMatchedClaims(ctx, prophecy)
+		}
+	}
+
 	k.setProphecy(ctx, prophecy)
 	return prophecy, nil
 }
 
-// processCompletion looks at a given prophecy
-// and assesses whether the claim with the highest power on that prophecy has enough
-// power to be considered successful, or alternatively,
-// will never be able to become successful due to not enough validation power being
-// left to push it over the threshold required for consensus.
+// processCompletion looks at a given prophecy and assesses whether it is finalized
+// by the vote strategy selected for the chain of the prophecy.
 func (k Keeper) processCompletion(ctx sdk.Context, prophecy types.Prophecy) types.Prophecy {
-	highestClaim, highestClaimPower, totalClaimsPower, totalPower := prophecy.FindHighestClaim(ctx, k.stakeKeeper)
-
-	highestConsensusRatio := sdk.NewDec(highestClaimPower).Quo(sdk.NewDec(totalPower))
-	remainingPossibleClaimPower := totalPower - totalClaimsPower
-	highestPossibleClaimPower := highestClaimPower + remainingPossibleClaimPower
-
-	highestPossibleConsensusRatio := sdk.NewDec(highestPossibleClaimPower).Quo(sdk.NewDec(totalPower))
-
-	consensusNeeded := k.GetConsensusNeeded(ctx)
-
-	if highestConsensusRatio.GTE(consensusNeeded) {
-		prophecy.Status.Text = types.SuccessStatusText
-		prophecy.Status.FinalClaim = highestClaim
-	} else if highestPossibleConsensusRatio.LT(consensusNeeded) {
-		prophecy.Status.Text = types.FailedStatusText
-	}
+	strategy, setting := k.getVoteStrategy(ctx, prophecy.ID)
+	prophecy.Status = strategy.Tally(ctx, prophecy, setting)
 	return prophecy
 }
 
@@ -179,6 +204,9 @@ func (k *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
 			case *types.Params:
 				// do double check
 				err := change.UpdateCheck()
+				if err == nil {
+					err = k.checkVoteStrategies(change.VoteStrategies)
+				}
 				if err != nil {
 					context.Logger().Error("skip invalid param change", "err", err, "param", change)
 				} else {
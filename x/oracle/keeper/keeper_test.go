@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -278,3 +279,236 @@ func TestNonValidator(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "claim must be made by actively bonded validator"))
 }
+
+func TestVoteStrategies(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 20, 5})
+	stake.EndBlocker(ctx, sk)
+
+	require.NoError(t, keeper.ScKeeper.RegisterDestChain("bsc", sdk.ChainID(2)))
+	require.NoError(t, keeper.ScKeeper.RegisterDestChain("eth", sdk.ChainID(3)))
+	require.NoError(t, keeper.ScKeeper.RegisterDestChain("tron", sdk.ChainID(5)))
+	require.Error(t, keeper.RegisterVoteStrategy(types.VoteStrategyPower, NewPowerWeightedStrategy(sk)))
+
+	params := types.Params{
+		ConsensusNeeded: sdk.NewDecWithPrec(6, 1),
+		VoteStrategies: []types.VoteStrategySetting{
+			{DestChainName: "bsc", Strategy: types.VoteStrategyEqualWeight},
+			{DestChainName: "eth", Strategy: types.VoteStrategyQuorum, Quorum: sdk.NewDecWithPrec(6, 1), Threshold: sdk.NewDecWithPrec(7, 1)},
+			{DestChainName: "tron", Strategy: types.VoteStrategyQuorum, Quorum: sdk.NewDecWithPrec(3, 1), Threshold: sdk.NewDecWithPrec(8, 1)},
+		},
+	}
+	require.NoError(t, params.UpdateCheck())
+	defer sdk.UpgradeMgr.Reset()
+	// the vote strategies are not written before the upgrade
+	keeper.SetParams(ctx, params)
+	require.Equal(t, types.VoteStrategyPower, keeper.GetVoteStrategySetting(ctx, sdk.ChainID(2)).Strategy)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.OracleVoteStrategy, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	keeper.SetParams(ctx, params)
+
+	setting := keeper.GetVoteStrategySetting(ctx, sdk.ChainID(2))
+	require.Equal(t, types.VoteStrategyEqualWeight, setting.Strategy)
+	require.Equal(t, sdk.NewDecWithPrec(6, 1), setting.Threshold)
+	require.Equal(t, types.VoteStrategyPower, keeper.GetVoteStrategySetting(ctx, sdk.ChainID(4)).Strategy)
+
+	// every relayer has one vote, the relayer with the most power can not finalize the prophecy alone
+	bscID := types.GetClaimId(sdk.ChainID(2), types.RelayPackagesChannelId, 0)
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(bscID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(bscID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, TestString, prophecy.Status.FinalClaim)
+
+	// the quorum is not reached
+	ethID := types.GetClaimId(sdk.ChainID(3), types.RelayPackagesChannelId, 0)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(ethID, valAddrs[0], AlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	// the quorum is reached and the highest claim reaches the threshold of the claimed power,
+	// but not the threshold of the total power
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(ethID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, TestString, prophecy.Status.FinalClaim)
+
+	// with a quorum below the threshold, the quorum is reached but no claim reaches the threshold of the claimed power
+	tronID := types.GetClaimId(sdk.ChainID(5), types.RelayPackagesChannelId, 0)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(tronID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(tronID, valAddrs[2], AlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	// the last relayer disagrees as well, the highest claim can not reach the threshold any more
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(tronID, valAddrs[1], "third claim"))
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, prophecy.Status.Text)
+
+	// the quorum is reached by one relayer which has all the claimed power
+	tronID = types.GetClaimId(sdk.ChainID(5), types.RelayPackagesChannelId, 1)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(tronID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, TestString, prophecy.Status.FinalClaim)
+}
+
+func TestProphecyExpiry(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyExpiry, 10)
+	defer sdk.UpgradeMgr.Reset()
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1), ProphecyExpiryWindow: 10})
+	require.Equal(t, types.DefaultProphecyExpiryWindow, keeper.GetProphecyExpiryWindow(ctx))
+
+	// prophecy created before the upgrade has no creation height
+	sdk.UpgradeMgr.SetHeight(5)
+	ctx = ctx.WithBlockHeight(5)
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	prophecy, found := keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	require.EqualValues(t, 0, prophecy.CreationHeight)
+
+	sdk.UpgradeMgr.SetHeight(10)
+	ctx = ctx.WithBlockHeight(10)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1), ProphecyExpiryWindow: 10})
+	keeper.IndexLegacyProphecies(ctx)
+	prophecy, _ = keeper.GetProphecy(ctx, TestID)
+	require.EqualValues(t, 10, prophecy.CreationHeight)
+
+	sdk.UpgradeMgr.SetHeight(12)
+	ctx = ctx.WithBlockHeight(12)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+
+	res, sdkErr := NewQuerier(keeper)(ctx, []string{types.QueryPendingProphecies}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var pending []types.PendingProphecy
+	require.NoError(t, json.Unmarshal(res, &pending))
+	require.Len(t, pending, 2)
+	require.Equal(t, TestID, pending[0].ID)
+	require.EqualValues(t, 21, pending[0].ExpireHeight)
+	require.Equal(t, AlternateTestID, pending[1].ID)
+	require.EqualValues(t, 12, pending[1].CreationHeight)
+	require.Len(t, pending[1].Claims, 2)
+
+	// the legacy prophecy expires first
+	ctx = ctx.WithBlockHeight(20).WithEventManager(sdk.NewEventManager())
+	keeper.PruneExpiredProphecies(ctx)
+	require.Len(t, ctx.EventManager().Events(), 0)
+	ctx = ctx.WithBlockHeight(21)
+	keeper.PruneExpiredProphecies(ctx)
+	require.Len(t, ctx.EventManager().Events(), 1)
+	_, found = keeper.GetProphecy(ctx, TestID)
+	require.False(t, found)
+
+	ctx = ctx.WithBlockHeight(23).WithEventManager(sdk.NewEventManager())
+	keeper.PruneExpiredProphecies(ctx)
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeProphecyExpired, events[0].Type)
+	attributes := make(map[string]string)
+	for _, attr := range events[0].Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+	require.Equal(t, AlternateTestID, attributes[types.ProphecyId])
+	// the tie is broken by the claim, so the validator of the alternate claim disagreed
+	require.Equal(t, TestString, attributes[types.ProphecyLeadingClaim])
+	require.Equal(t, valAddrs[1].String(), attributes[types.ProphecyDisagreeingValidators])
+
+	res, sdkErr = NewQuerier(keeper)(ctx, []string{types.QueryPendingProphecies}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	require.NoError(t, json.Unmarshal(res, &pending))
+	require.Len(t, pending, 0)
+}
+
+func TestRelayerRewards(t *testing.T) {
+	mapp, bk, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 20, 5})
+	stake.EndBlocker(ctx, sk)
+
+	params := types.Params{
+		ConsensusNeeded:     sdk.NewDecWithPrec(6, 1),
+		RelayerRewardPolicy: types.RelayerRewardPolicyPower,
+		RelayerRewardRatio:  sdk.NewDecWithPrec(5, 1),
+	}
+	require.NoError(t, params.UpdateCheck())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.OracleRelayerReward, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+	keeper.SetParams(ctx, params)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], AlternateTestString))
+	require.NoError(t, err)
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+
+	stats, found := keeper.GetRelayerStats(ctx, valAddrs[0])
+	require.True(t, found)
+	require.EqualValues(t, 1, stats.ClaimsSubmitted)
+	require.EqualValues(t, 1, stats.ClaimsMatched)
+	stats, _ = keeper.GetRelayerStats(ctx, valAddrs[2])
+	require.EqualValues(t, 1, stats.ClaimsSubmitted)
+	require.EqualValues(t, 0, stats.ClaimsMatched)
+
+	// half of the fee is shared with the agreeing relayers by their power
+	powers := sk.GetOracleRelayersPower(ctx)
+	power0, power1 := powers[valAddrs[0].String()], powers[valAddrs[1].String()]
+	proposerFee, _, sdkErr := keeper.DistributeRelayerReward(ctx, prophecy.ClaimValidators[prophecy.Status.FinalClaim], 1000)
+	require.Nil(t, sdkErr)
+	share0, share1 := 500*power0/(power0+power1), 500*power1/(power0+power1)
+	require.EqualValues(t, 1000-share0-share1, proposerFee)
+	require.EqualValues(t, share0, bk.GetCoins(ctx, addrs[0]).AmountOf(sdk.NativeTokenSymbol))
+	require.EqualValues(t, share1, bk.GetCoins(ctx, addrs[1]).AmountOf(sdk.NativeTokenSymbol))
+	require.EqualValues(t, 0, bk.GetCoins(ctx, addrs[2]).AmountOf(sdk.NativeTokenSymbol))
+	stats, _ = keeper.GetRelayerStats(ctx, valAddrs[1])
+	require.EqualValues(t, share1, stats.Rewards)
+
+	// the relayer that disagreed is at the bottom of the leaderboard
+	leaderboard := keeper.GetRelayerLeaderboard(ctx, types.QueryRelayerStatsParams{})
+	require.Len(t, leaderboard, 3)
+	require.Equal(t, valAddrs[2], leaderboard[2].Validator)
+	leaderboard = keeper.GetRelayerLeaderboard(ctx, types.QueryRelayerStatsParams{Ascending: true, Limit: 1})
+	require.Len(t, leaderboard, 1)
+	require.Equal(t, valAddrs[2], leaderboard[0].Validator)
+	require.True(t, leaderboard[0].MatchRate.IsZero())
+}
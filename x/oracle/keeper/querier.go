@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// creates a querier for oracle REST endpoints
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QueryPendingProphecies:
+			var params types.QueryPendingPropheciesParams
+			if len(req.Data) != 0 {
+				if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+					return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+				}
+			}
+			return queryPendingProphecies(ctx, k, params)
+		case types.QueryRelayerStats:
+			var params types.QueryRelayerStatsParams
+			if len(req.Data) != 0 {
+				if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+					return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+				}
+			}
+			res, err := json.Marshal(k.GetRelayerLeaderboard(ctx, params))
+			if err != nil {
+				return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+			}
+			return res, nil
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown oracle query endpoint")
+		}
+	}
+}
+
+func queryPendingProphecies(ctx sdk.Context, k Keeper, params types.QueryPendingPropheciesParams) ([]byte, sdk.Error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = types.DefaultPendingPropheciesLimit
+	} else if limit > types.MaxPendingPropheciesLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("limit should not be larger than %d", types.MaxPendingPropheciesLimit))
+	}
+
+	var chainId *sdk.ChainID
+	if params.SideChainId != "" {
+		id, err := k.ScKeeper.GetDestChainID(params.SideChainId)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(err.Error())
+		}
+		chainId = &id
+	}
+
+	res, err := json.Marshal(k.GetPendingProphecies(ctx, chainId, limit))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
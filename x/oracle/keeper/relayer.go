@@ -0,0 +1,177 @@
+package keeper
+
+import (
+	"math/big"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func (k Keeper) GetRelayerRewardPolicy(ctx sdk.Context) (policy string, ratio sdk.Dec) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyRelayerRewardPolicy, &policy)
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyRelayerRewardRatio, &ratio)
+	if policy == "" {
+		policy = types.RelayerRewardPolicyProposer
+	}
+	return
+}
+
+func (k Keeper) GetRelayerStats(ctx sdk.Context, validator sdk.ValAddress) (types.RelayerStats, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetRelayerStatsKey(validator))
+	if bz == nil {
+		return types.RelayerStats{Validator: validator}, false
+	}
+	var stats types.RelayerStats
+	k.cdc.MustUnmarshalBinaryBare(bz, &stats)
+	return stats, true
+}
+
+func (k Keeper) setRelayerStats(ctx sdk.Context, stats types.RelayerStats) {
+	ctx.KVStore(k.storeKey).Set(types.GetRelayerStatsKey(stats.Validator), k.cdc.MustMarshalBinaryBare(stats))
+}
+
+// IterateRelayerStats iterates the stats of all relayers ordered by validator address
+func (k Keeper) IterateRelayerStats(ctx sdk.Context, fn func(stats types.RelayerStats) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.RelayerStatsPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var stats types.RelayerStats
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &stats)
+		if fn(stats) {
+			return
+		}
+	}
+}
+
+// recordClaim counts the claim of the validator, the latency of the claim is the number of blocks since
+// the first claim of the prophecy.
+func (k Keeper) recordClaim(ctx sdk.Context, prophecy types.Prophecy, validator sdk.ValAddress) {
+	stats, _ := k.GetRelayerStats(ctx, validator)
+	stats.ClaimsSubmitted++
+	if prophecy.CreationHeight > 0 {
+		stats.TotalLatency += ctx.BlockHeight() - prophecy.CreationHeight
+		stats.TimedClaims++
+	}
+	stats.LastClaimHeight = ctx.BlockHeight()
+	k.setRelayerStats(ctx, stats)
+}
+
+// recordMatchedClaims counts the claims that agreed with the final claim of a successful prophecy
+func (k Keeper) recordMatchedClaims(ctx sdk.Context, prophecy types.Prophecy) {
+	for _, validator := range prophecy.ClaimValidators[prophecy.Status.FinalClaim] {
+		stats, _ := k.GetRelayerStats(ctx, validator)
+		stats.ClaimsMatched++
+		k.setRelayerStats(ctx, stats)
+	}
+}
+
+// DistributeRelayerReward shares the relay fee of a package with the relayers whose claims agreed,
+// it returns the amount that is left for the block proposer and the rewarded relayer accounts.
+func (k Keeper) DistributeRelayerReward(ctx sdk.Context, relayers []sdk.ValAddress, feeAmount int64) (int64, []sdk.AccAddress, sdk.Error) {
+	policy, ratio := k.GetRelayerRewardPolicy(ctx)
+	if policy == types.RelayerRewardPolicyProposer || feeAmount <= 0 || len(relayers) == 0 {
+		return feeAmount, nil, nil
+	}
+
+	// relay fees may be large, so the shares are calculated with big.Int to avoid overflow
+	relayerPart := new(big.Int).Div(
+		new(big.Int).Mul(big.NewInt(feeAmount), big.NewInt(ratio.RawInt())),
+		big.NewInt(sdk.OneDec().RawInt())).Int64()
+	if relayerPart <= 0 {
+		return feeAmount, nil, nil
+	}
+
+	sorted := make([]sdk.ValAddress, len(relayers))
+	copy(sorted, relayers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+
+	weights := make([]int64, len(sorted))
+	totalWeight := int64(0)
+	switch policy {
+	case types.RelayerRewardPolicyPower:
+		powers := k.stakeKeeper.GetOracleRelayersPower(ctx)
+		for i, relayer := range sorted {
+			weights[i] = powers[relayer.String()]
+			totalWeight += weights[i]
+		}
+	default:
+		for i := range sorted {
+			weights[i] = 1
+		}
+		totalWeight = int64(len(sorted))
+	}
+	if totalWeight <= 0 {
+		return feeAmount, nil, nil
+	}
+
+	distributed := int64(0)
+	changedAddrs := make([]sdk.AccAddress, 0, len(sorted))
+	for i, relayer := range sorted {
+		// the remainder of the division goes to the proposer
+		share := new(big.Int).Div(
+			new(big.Int).Mul(big.NewInt(relayerPart), big.NewInt(weights[i])),
+			big.NewInt(totalWeight)).Int64()
+		if share <= 0 {
+			continue
+		}
+		addr := sdk.AccAddress(relayer)
+		if _, _, err := k.BkKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, share)}); err != nil {
+			return 0, nil, err
+		}
+		stats, _ := k.GetRelayerStats(ctx, relayer)
+		stats.Rewards += share
+		k.setRelayerStats(ctx, stats)
+
+		distributed += share
+		changedAddrs = append(changedAddrs, addr)
+	}
+	return feeAmount - distributed, changedAddrs, nil
+}
+
+// GetRelayerLeaderboard returns the relayer stats ranked by the query params
+func (k Keeper) GetRelayerLeaderboard(ctx sdk.Context, params types.QueryRelayerStatsParams) []types.RelayerPerformance {
+	all := make([]types.RelayerStats, 0)
+	if len(params.Validator) != 0 {
+		if stats, found := k.GetRelayerStats(ctx, params.Validator); found {
+			all = append(all, stats)
+		}
+	} else {
+		k.IterateRelayerStats(ctx, func(stats types.RelayerStats) bool {
+			all = append(all, stats)
+			return false
+		})
+	}
+
+	less := func(a, b types.RelayerStats) bool {
+		switch params.SortBy {
+		case types.RelayerStatsSortByClaims:
+			return a.ClaimsSubmitted < b.ClaimsSubmitted
+		case types.RelayerStatsSortByLatency:
+			return a.AverageLatency().LT(b.AverageLatency())
+		case types.RelayerStatsSortByRewards:
+			return a.Rewards < b.Rewards
+		default:
+			return a.MatchRate().LT(b.MatchRate())
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if params.Ascending {
+			return less(all[i], all[j])
+		}
+		return less(all[j], all[i])
+	})
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = types.DefaultRelayerStatsLimit
+	}
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	leaderboard := make([]types.RelayerPerformance, 0, len(all))
+	for i, stats := range all {
+		leaderboard = append(leaderboard, types.NewRelayerPerformance(i+1, stats))
+	}
+	return leaderboard
+}
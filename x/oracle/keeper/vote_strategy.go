@@ -0,0 +1,175 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// VoteStrategy decides the status of a prophecy from the claims made on it
+type VoteStrategy interface {
+	// Tally returns the new status of the prophecy, which stays pending until the outcome is decided
+	Tally(ctx sdk.Context, prophecy types.Prophecy, setting types.VoteStrategySetting) types.Status
+}
+
+// PowerWeightedStrategy weighs every claim by the power of the oracle relayer
+type PowerWeightedStrategy struct {
+	stakeKeeper types.StakingKeeper
+}
+
+func NewPowerWeightedStrategy(stakeKeeper types.StakingKeeper) PowerWeightedStrategy {
+	return PowerWeightedStrategy{stakeKeeper: stakeKeeper}
+}
+
+// Tally finalizes the prophecy once the claim with the highest power has enough power to be considered successful,
+// or alternatively, will never be able to become successful due to not enough validation power being left to push
+// it over the threshold required for consensus.
+func (s PowerWeightedStrategy) Tally(ctx sdk.Context, prophecy types.Prophecy, setting types.VoteStrategySetting) types.Status {
+	highestClaim, highestClaimPower, totalClaimsPower, totalPower := prophecy.FindHighestClaim(ctx, s.stakeKeeper)
+	return decideByThreshold(prophecy.Status, highestClaim, highestClaimPower, totalClaimsPower, totalPower, setting.Threshold)
+}
+
+// EqualWeightStrategy gives every whitelisted oracle relayer one vote
+type EqualWeightStrategy struct {
+	stakeKeeper types.StakingKeeper
+}
+
+func NewEqualWeightStrategy(stakeKeeper types.StakingKeeper) EqualWeightStrategy {
+	return EqualWeightStrategy{stakeKeeper: stakeKeeper}
+}
+
+func (s EqualWeightStrategy) Tally(ctx sdk.Context, prophecy types.Prophecy, setting types.VoteStrategySetting) types.Status {
+	relayers := s.stakeKeeper.GetOracleRelayersPower(ctx)
+	weights := make(map[string]int64, len(relayers))
+	if len(setting.Whitelist) == 0 {
+		for addr := range relayers {
+			weights[addr] = 1
+		}
+	} else {
+		// whitelisted validators that are not oracle relayers any more are not counted
+		for _, addr := range setting.Whitelist {
+			if _, ok := relayers[addr.String()]; ok {
+				weights[addr.String()] = 1
+			}
+		}
+	}
+	if len(weights) == 0 {
+		return prophecy.Status
+	}
+
+	highestClaim, highestClaimWeight, totalClaimsWeight := prophecy.TallyClaims(weights)
+	return decideByThreshold(prophecy.Status, highestClaim, highestClaimWeight, totalClaimsWeight, int64(len(weights)), setting.Threshold)
+}
+
+// QuorumStrategy weighs claims by power, the prophecy is finalized once a quorum of the total power has claimed
+// and the highest claim reaches the threshold of the claimed power.
+type QuorumStrategy struct {
+	stakeKeeper types.StakingKeeper
+}
+
+func NewQuorumStrategy(stakeKeeper types.StakingKeeper) QuorumStrategy {
+	return QuorumStrategy{stakeKeeper: stakeKeeper}
+}
+
+func (s QuorumStrategy) Tally(ctx sdk.Context, prophecy types.Prophecy, setting types.VoteStrategySetting) types.Status {
+	highestClaim, highestClaimPower, totalClaimsPower, totalPower := prophecy.FindHighestClaim(ctx, s.stakeKeeper)
+	if totalPower <= 0 {
+		return prophecy.Status
+	}
+
+	status := prophecy.Status
+	quorumRatio := sdk.NewDec(totalClaimsPower).Quo(sdk.NewDec(totalPower))
+
+	// both success and failure are decided by the share of the highest claim in the claimed power. The share is
+	// the highest when all the remaining power joins the highest claim, which also reaches any quorum.
+	remainingPossibleClaimPower := totalPower - totalClaimsPower
+	if remainingPossibleClaimPower < 0 {
+		remainingPossibleClaimPower = 0
+	}
+	highestPossibleClaimsPower := totalClaimsPower + remainingPossibleClaimPower
+	highestPossibleConsensusRatio := sdk.NewDec(highestClaimPower + remainingPossibleClaimPower).Quo(sdk.NewDec(highestPossibleClaimsPower))
+
+	if totalClaimsPower > 0 && quorumRatio.GTE(setting.Quorum) &&
+		sdk.NewDec(highestClaimPower).Quo(sdk.NewDec(totalClaimsPower)).GTE(setting.Threshold) {
+		status.Text = types.SuccessStatusText
+		status.FinalClaim = highestClaim
+	} else if highestPossibleConsensusRatio.LT(setting.Threshold) {
+		status.Text = types.FailedStatusText
+	}
+	return status
+}
+
+func decideByThreshold(status types.Status, highestClaim string, highestClaimWeight, totalClaimsWeight, totalWeight int64, threshold sdk.Dec) types.Status {
+	highestConsensusRatio := sdk.NewDec(highestClaimWeight).Quo(sdk.NewDec(totalWeight))
+	remainingPossibleClaimWeight := totalWeight - totalClaimsWeight
+	highestPossibleClaimWeight := highestClaimWeight + remainingPossibleClaimWeight
+
+	highestPossibleConsensusRatio := sdk.NewDec(highestPossibleClaimWeight).Quo(sdk.NewDec(totalWeight))
+
+	if highestConsensusRatio.GTE(threshold) {
+		status.Text = types.SuccessStatusText
+		status.FinalClaim = highestClaim
+	} else if highestPossibleConsensusRatio.LT(threshold) {
+		status.Text = types.FailedStatusText
+	}
+	return status
+}
+
+// RegisterVoteStrategy adds a vote strategy that can be selected by the vote strategies param
+func (k *Keeper) RegisterVoteStrategy(name string, strategy VoteStrategy) error {
+	if _, ok := k.voteStrategies[name]; ok {
+		return fmt.Errorf("vote strategy %s is already registered", name)
+	}
+	k.voteStrategies[name] = strategy
+	return nil
+}
+
+// GetVoteStrategySetting returns the vote strategy setting of a destination chain, the power weighted
+// strategy is used for chains not listed in the vote strategies param.
+func (k Keeper) GetVoteStrategySetting(ctx sdk.Context, chainId sdk.ChainID) types.VoteStrategySetting {
+	setting := types.VoteStrategySetting{Strategy: types.VoteStrategyPower}
+	if name, err := k.ScKeeper.GetDestChainName(chainId); err == nil {
+		var settings []types.VoteStrategySetting
+		k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyVoteStrategies, &settings)
+		for _, s := range settings {
+			if s.DestChainName == name {
+				setting = s
+				break
+			}
+		}
+	}
+	if setting.Threshold.IsZero() {
+		setting.Threshold = k.GetConsensusNeeded(ctx)
+	}
+	return setting
+}
+
+func (k Keeper) getVoteStrategy(ctx sdk.Context, prophecyId string) (VoteStrategy, types.VoteStrategySetting) {
+	var setting types.VoteStrategySetting
+	if !sdk.IsUpgrade(sdk.OracleVoteStrategy) {
+		return k.voteStrategies[types.VoteStrategyPower], types.VoteStrategySetting{Strategy: types.VoteStrategyPower, Threshold: k.GetConsensusNeeded(ctx)}
+	}
+	if chainId, err := types.GetChainIdFromClaimId(prophecyId); err == nil {
+		setting = k.GetVoteStrategySetting(ctx, chainId)
+	} else {
+		setting = types.VoteStrategySetting{Strategy: types.VoteStrategyPower, Threshold: k.GetConsensusNeeded(ctx)}
+	}
+
+	strategy, ok := k.voteStrategies[setting.Strategy]
+	if !ok {
+		ctx.Logger().With("module", "oracle").Error("vote strategy is not registered, fall back to power weighted strategy",
+			"strategy", setting.Strategy, "prophecy", prophecyId)
+		strategy = k.voteStrategies[types.VoteStrategyPower]
+	}
+	return strategy, setting
+}
+
+func (k Keeper) checkVoteStrategies(settings []types.VoteStrategySetting) error {
+	for _, setting := range settings {
+		if _, ok := k.voteStrategies[setting.Strategy]; !ok {
+			return fmt.Errorf("vote strategy %s of chain %s is not registered", setting.Strategy, setting.DestChainName)
+		}
+	}
+	return nil
+}
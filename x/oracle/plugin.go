@@ -7,7 +7,17 @@ import (
 
 func RegisterUpgradeBeginBlocker(keeper Keeper) {
 	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.LaunchBscUpgrade, func(ctx sdk.Context) {
-		keeper.SetParams(ctx, types.Params{ConsensusNeeded: types.DefaultConsensusNeeded})
+		keeper.SetParams(ctx, types.DefaultParams())
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.OracleVoteStrategy, func(ctx sdk.Context) {
+		keeper.SetVoteStrategies(ctx, types.DefaultParams().VoteStrategies)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ProphecyExpiry, func(ctx sdk.Context) {
+		keeper.SetProphecyExpiryWindow(ctx, types.DefaultProphecyExpiryWindow)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.OracleRelayerReward, func(ctx sdk.Context) {
+		params := types.DefaultParams()
+		keeper.SetRelayerRewardPolicy(ctx, params.RelayerRewardPolicy, params.RelayerRewardRatio)
 	})
 
 	err := keeper.ScKeeper.RegisterChannel(types.RelayPackagesChannelName, types.RelayPackagesChannelId, nil)
@@ -0,0 +1,140 @@
+package oracle
+
+import (
+	"encoding/json"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/keeper"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// NewQuerier creates a querier for oracle REST endpoints, the simulation of packages is served here
+// since it executes packages the same way as the handler.
+func NewQuerier(k Keeper) sdk.Querier {
+	keeperQuerier := keeper.NewQuerier(k)
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QuerySimulatePackage:
+			var params types.QuerySimulatePackageParams
+			if err := json.Unmarshal(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return querySimulatePackage(ctx, k, params)
+		default:
+			return keeperQuerier(ctx, path, req)
+		}
+	}
+}
+
+func querySimulatePackage(ctx sdk.Context, k Keeper, params types.QuerySimulatePackageParams) ([]byte, sdk.Error) {
+	simulation, sdkErr := SimulatePackage(ctx, k, params)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	res, err := json.Marshal(simulation)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
+
+// SimulatePackage executes a package against a cached context the same way as the delivery of a claim does,
+// the changes are never written.
+func SimulatePackage(ctx sdk.Context, k Keeper, params types.QuerySimulatePackageParams) (types.PackageSimulation, sdk.Error) {
+	chainId, err := k.ScKeeper.GetDestChainID(params.SideChainId)
+	if err != nil {
+		return types.PackageSimulation{}, sdk.ErrUnknownRequest(err.Error())
+	}
+	sequence := k.ScKeeper.GetReceiveSequence(ctx, chainId, params.ChannelId)
+	if params.Sequence != nil {
+		sequence = *params.Sequence
+	}
+
+	recorder := newAccountChangeRecorder(ctx.AccountCache())
+	simCtx, _ := ctx.WithAccountCache(recorder).CacheContext()
+	simCtx = simCtx.WithEventManager(sdk.NewEventManager())
+
+	pack := types.Package{
+		ChannelId: params.ChannelId,
+		Sequence:  sequence,
+		Payload:   params.Package,
+	}
+	simulation := types.PackageSimulation{
+		Sequence:       sequence,
+		Events:         sdk.StringEvents{},
+		BalanceChanges: []types.BalanceChange{},
+	}
+	packResult, sdkErr := handlePackage(simCtx, k, chainId, &pack, nil)
+	if sdkErr != nil {
+		simulation.Code = sdkErr.ABCICode()
+		simulation.Log = sdkErr.ABCILog()
+		return simulation, nil
+	}
+
+	simulation.Crash = packResult.crash
+	simulation.Code = packResult.result.Code()
+	simulation.Log = packResult.result.Msg()
+	packageType, _, _ := sTypes.DecodePackageHeader(pack.Payload)
+	if packageType == sdk.SynCrossChainPackageType {
+		if packResult.crash {
+			simulation.AckPayload = pack.Payload[sTypes.PackageHeaderLength:]
+		} else {
+			simulation.AckPayload = packResult.result.Payload
+		}
+	}
+
+	events := simCtx.EventManager().Events().AppendEvent(packResult.event)
+	simulation.Events = sdk.StringifyEvents(events.ToABCIEvents())
+
+	for _, addr := range recorder.changedAddrs() {
+		before := k.BkKeeper.GetCoins(ctx, addr)
+		after := k.BkKeeper.GetCoins(simCtx, addr)
+		if !before.IsEqual(after) {
+			simulation.BalanceChanges = append(simulation.BalanceChanges, types.BalanceChange{Address: addr, Before: before, After: after})
+		}
+	}
+	return simulation, nil
+}
+
+// accountChangeRecorder records the accounts written through an account cache and the caches derived from it
+type accountChangeRecorder struct {
+	sdk.AccountCache
+	changed map[string]sdk.AccAddress
+}
+
+func newAccountChangeRecorder(cache sdk.AccountCache) *accountChangeRecorder {
+	return &accountChangeRecorder{
+		AccountCache: cache,
+		changed:      make(map[string]sdk.AccAddress),
+	}
+}
+
+func (r *accountChangeRecorder) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
+	r.changed[string(addr)] = addr
+	r.AccountCache.SetAccount(addr, acc)
+}
+
+func (r *accountChangeRecorder) Delete(addr sdk.AccAddress) {
+	r.changed[string(addr)] = addr
+	r.AccountCache.Delete(addr)
+}
+
+func (r *accountChangeRecorder) Cache() sdk.AccountCache {
+	return &accountChangeRecorder{
+		AccountCache: r.AccountCache.Cache(),
+		changed:      r.changed,
+	}
+}
+
+func (r *accountChangeRecorder) changedAddrs() []sdk.AccAddress {
+	addrs := make([]sdk.AccAddress, 0, len(r.changed))
+	for _, addr := range r.changed {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].String() < addrs[j].String() })
+	return addrs
+}
@@ -0,0 +1,156 @@
+package oracle
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// transferApp adds 100 to the receiver for every syn package, and panics on an empty syn package
+type transferApp struct {
+	bk       bank.Keeper
+	receiver sdk.AccAddress
+}
+
+func (app transferApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	if len(payload) == 0 {
+		panic("empty package")
+	}
+	_, tags, err := app.bk.AddCoins(ctx, app.receiver, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100)})
+	if err != nil {
+		return sdk.ExecuteResult{Err: err}
+	}
+	return sdk.ExecuteResult{Payload: []byte{1}, Tags: tags}
+}
+
+func (app transferApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app transferApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+type testOracle struct {
+	ctx       sdk.Context
+	keeper    Keeper
+	bk        bank.Keeper
+	scKeeper  sidechain.Keeper
+	receiver  sdk.AccAddress
+	channelId sdk.ChannelID
+}
+
+func setupTestOracle(t *testing.T) testOracle {
+	mapp := mock.NewApp()
+	stake.RegisterCodec(mapp.Cdc)
+
+	keyGlobalParams := sdk.NewKVStoreKey("params")
+	tkeyGlobalParams := sdk.NewTransientStoreKey("transient_params")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyStakeReward := sdk.NewKVStoreKey("stake_reward")
+	tkeyStake := sdk.NewTransientStoreKey("transient_stake")
+	keyOracle := sdk.NewKVStoreKey("oracle")
+	keyIbc := sdk.NewKVStoreKey("ibc")
+	keySideChain := sdk.NewKVStoreKey("side")
+
+	pk := params.NewKeeper(mapp.Cdc, keyGlobalParams, tkeyGlobalParams)
+	bk := bank.NewBaseKeeper(mapp.AccountKeeper)
+	sk := stake.NewKeeper(mapp.Cdc, keyStake, keyStakeReward, tkeyStake, bk, nil, pk.Subspace(stake.DefaultParamspace), mapp.RegisterCodespace(stake.DefaultCodespace), sdk.ChainID(0), "")
+	scK := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), mapp.Cdc)
+	ibcKeeper := ibc.NewKeeper(keyIbc, pk.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scK)
+	keeper := NewKeeper(mapp.Cdc, keyOracle, pk.Subspace("testoracle"), sk, scK, ibcKeeper, bk, &sdk.Pool{})
+	require.NoError(t, mapp.CompleteSetup(keyStake, keyStakeReward, tkeyStake, keyOracle, keyIbc, keySideChain, keyGlobalParams, tkeyGlobalParams))
+	mock.SetGenesis(mapp, nil)
+
+	receiver := sdk.AccAddress([]byte("receiver-address----"))
+	channelId := sdk.ChannelID(2)
+	require.NoError(t, scK.RegisterDestChain("bsc", sdk.ChainID(1)))
+	require.NoError(t, scK.RegisterChannel("transfer", channelId, transferApp{bk: bk, receiver: receiver}))
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeCheck, abci.Header{})
+	scK.SetParams(ctx, sidechain.DefaultParams())
+	scK.SetSideChainIdAndStorePrefix(ctx, "bsc", []byte{0x01})
+	ibcKeeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x01}), ibc.Params{RelayerFee: ibc.DefaultRelayerFeeParam})
+	require.Nil(t, bk.SetCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1000)}))
+
+	return testOracle{
+		ctx:       ctx,
+		keeper:    keeper,
+		bk:        bk,
+		scKeeper:  scK,
+		receiver:  receiver,
+		channelId: channelId,
+	}
+}
+
+func (app transferApp) PackageValue(packageType sdk.CrossChainPackageType, payload []byte) int64 {
+	if len(payload) == 0 {
+		return 0
+	}
+	return int64(payload[0])
+}
+
+func TestSimulatePackage(t *testing.T) {
+	o := setupTestOracle(t)
+	ctx, keeper, bk, scK, receiver, channelId := o.ctx, o.keeper, o.bk, o.scKeeper, o.receiver, o.channelId
+
+	rawPackage := append(sTypes.EncodePackageHeader(sdk.SynCrossChainPackageType, *big.NewInt(10)), 7)
+	params := types.QuerySimulatePackageParams{SideChainId: "bsc", ChannelId: channelId, Package: rawPackage}
+	queryData, err := json.Marshal(params)
+	require.NoError(t, err)
+	res, sdkErr := NewQuerier(keeper)(ctx, []string{types.QuerySimulatePackage}, abci.RequestQuery{Data: queryData})
+	require.Nil(t, sdkErr)
+
+	var simulation types.PackageSimulation
+	require.NoError(t, json.Unmarshal(res, &simulation))
+	require.False(t, simulation.Crash)
+	require.Equal(t, sdk.ABCICodeOK, simulation.Code)
+	require.Equal(t, []byte{1}, simulation.AckPayload)
+	require.Len(t, simulation.Events, 1)
+	require.Equal(t, types.EventTypeClaim, simulation.Events[0].Type)
+	require.Len(t, simulation.BalanceChanges, 2)
+	for _, change := range simulation.BalanceChanges {
+		switch {
+		case change.Address.Equals(sdk.PegAccount):
+			require.EqualValues(t, 990, change.After.AmountOf(sdk.NativeTokenSymbol))
+		case change.Address.Equals(receiver):
+			require.EqualValues(t, 100, change.After.AmountOf(sdk.NativeTokenSymbol))
+		default:
+			t.Fatalf("unexpected balance change of %s", change.Address)
+		}
+	}
+
+	// nothing is committed
+	require.EqualValues(t, 1000, bk.GetCoins(ctx, sdk.PegAccount).AmountOf(sdk.NativeTokenSymbol))
+	require.True(t, bk.GetCoins(ctx, receiver).IsZero())
+	require.EqualValues(t, 0, scK.GetSendSequence(ctx, sdk.ChainID(1), channelId))
+
+	// the application crashes, the package is sent back as fail ack
+	params.Package = sTypes.EncodePackageHeader(sdk.SynCrossChainPackageType, *big.NewInt(0))
+	simulation, sdkErr = SimulatePackage(ctx, keeper, params)
+	require.Nil(t, sdkErr)
+	require.True(t, simulation.Crash)
+	require.NotEqual(t, sdk.ABCICodeOK, simulation.Code)
+	require.Empty(t, simulation.AckPayload)
+
+	// the package is rejected before it is executed
+	sequence := uint64(5)
+	params.Sequence = &sequence
+	simulation, sdkErr = SimulatePackage(ctx, keeper, params)
+	require.Nil(t, sdkErr)
+	require.Equal(t, sdk.ToABCICode(types.DefaultCodespace, types.CodeInvalidSequence), simulation.Code)
+}
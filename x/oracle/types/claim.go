@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -16,6 +18,19 @@ func GetClaimId(chainId sdk.ChainID, channelId sdk.ChannelID, sequence uint64) s
 	return fmt.Sprintf("%d:%d:%d", chainId, channelId, sequence)
 }
 
+// GetChainIdFromClaimId returns the chain id a claim id is built for by GetClaimId
+func GetChainIdFromClaimId(id string) (sdk.ChainID, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid claim id %s", id)
+	}
+	chainId, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid chain id of claim id %s", id)
+	}
+	return sdk.ChainID(chainId), nil
+}
+
 // Claim contains an arbitrary claim with arbitrary content made by a given validator
 type Claim struct {
 	ID               string         `json:"id"`
@@ -24,6 +24,7 @@ const (
 	CodeInvalidLengthOfPayload        sdk.CodeType = 1011
 	CodeFeeOverflow                   sdk.CodeType = 1012
 	CodeInvalidPayload                sdk.CodeType = 1013
+	CodeInvalidBatchClaim             sdk.CodeType = 1014
 )
 
 func ErrProphecyNotFound() sdk.Error {
@@ -85,3 +86,7 @@ func ErrFeeOverflow(msg string) sdk.Error {
 func ErrInvalidPayload(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidPayload, msg)
 }
+
+func ErrInvalidBatchClaim(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidBatchClaim, msg)
+}
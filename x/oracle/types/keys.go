@@ -1,5 +1,12 @@
 package types
 
+import (
+	"bytes"
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	EventTypeClaim = "claim"
 
@@ -10,4 +17,39 @@ const (
 	ClaimSendSequence    = "ClaimSendSequence"
 	ClaimCrash           = "ClaimCrash"
 	ClaimPackageType     = "ClaimPackageType"
+
+	EventTypeProphecyExpired = "prophecyExpired"
+
+	ProphecyId                    = "ProphecyId"
+	ProphecyCreationHeight        = "ProphecyCreationHeight"
+	ProphecyLeadingClaim          = "ProphecyLeadingClaim"
+	ProphecyDisagreeingValidators = "ProphecyDisagreeingValidators"
 )
+
+// prophecies are stored under their id, the ids built by GetClaimId never start with the prefixes below
+var (
+	ProphecyExpiryQueuePrefix = []byte{0x01}
+	RelayerStatsPrefix        = []byte{0x02}
+)
+
+// IsProphecyKey tells whether a key of the oracle store is the key of a prophecy
+func IsProphecyKey(key []byte) bool {
+	return !bytes.HasPrefix(key, ProphecyExpiryQueuePrefix) && !bytes.HasPrefix(key, RelayerStatsPrefix)
+}
+
+// GetProphecyExpiryQueueKey returns the key of a prophecy in the queue ordered by creation height
+func GetProphecyExpiryQueueKey(creationHeight int64, id string) []byte {
+	return append(GetProphecyExpiryQueueEndKey(creationHeight), []byte(id)...)
+}
+
+// GetProphecyExpiryQueueEndKey returns the key to iterate the prophecies created before the height
+func GetProphecyExpiryQueueEndKey(creationHeight int64) []byte {
+	key := make([]byte, len(ProphecyExpiryQueuePrefix)+8)
+	copy(key, ProphecyExpiryQueuePrefix)
+	binary.BigEndian.PutUint64(key[len(ProphecyExpiryQueuePrefix):], uint64(creationHeight))
+	return key
+}
+
+func GetRelayerStatsKey(validator sdk.ValAddress) []byte {
+	return append(RelayerStatsPrefix, validator.Bytes()...)
+}
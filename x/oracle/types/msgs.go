@@ -11,7 +11,11 @@ import (
 const (
 	RouteOracle = "oracle"
 
-	ClaimMsgType = "oracleClaim"
+	ClaimMsgType      = "oracleClaim"
+	BatchClaimMsgType = "oracleBatchClaim"
+
+	// MaxBatchClaims is the maximum number of claims carried by a BatchClaimMsg
+	MaxBatchClaims = 50
 )
 
 var _ sdk.Msg = ClaimMsg{}
@@ -75,3 +79,83 @@ func (msg ClaimMsg) ValidateBasic() sdk.Error {
 	}
 	return nil
 }
+
+var _ sdk.Msg = BatchClaimMsg{}
+
+// BatchClaim is a claim on the packages of one sequence of the relay packages channel
+type BatchClaim struct {
+	Sequence uint64 `json:"sequence"`
+	Payload  []byte `json:"payload"`
+}
+
+// BatchClaimMsg carries the claims of consecutive sequences, every claim is processed on its own
+// so that the failure of one claim does not revert the others.
+type BatchClaimMsg struct {
+	ChainId          sdk.ChainID    `json:"chain_id"`
+	Claims           []BatchClaim   `json:"claims"`
+	ValidatorAddress sdk.AccAddress `json:"validator_address"`
+}
+
+func NewBatchClaimMsg(chainId sdk.ChainID, claims []BatchClaim, validatorAddr sdk.AccAddress) BatchClaimMsg {
+	return BatchClaimMsg{
+		ChainId:          chainId,
+		Claims:           claims,
+		ValidatorAddress: validatorAddr,
+	}
+}
+
+// nolint
+func (msg BatchClaimMsg) Route() string { return RouteOracle }
+func (msg BatchClaimMsg) Type() string  { return BatchClaimMsgType }
+func (msg BatchClaimMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ValidatorAddress}
+}
+
+func (msg BatchClaimMsg) String() string {
+	if len(msg.Claims) == 0 {
+		return fmt.Sprintf("BatchClaim{%v#%v}", msg.ChainId, msg.ValidatorAddress.String())
+	}
+	return fmt.Sprintf("BatchClaim{%v#%v-%v#%v}", msg.ChainId, msg.Claims[0].Sequence,
+		msg.Claims[len(msg.Claims)-1].Sequence, msg.ValidatorAddress.String())
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg BatchClaimMsg) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (msg BatchClaimMsg) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg BatchClaimMsg) ValidateBasic() sdk.Error {
+	if len(msg.Claims) == 0 || len(msg.Claims) > MaxBatchClaims {
+		return ErrInvalidBatchClaim(fmt.Sprintf("number of claims should be between 1 and %d", MaxBatchClaims))
+	}
+	for i, claim := range msg.Claims {
+		if claim.Sequence != msg.Claims[0].Sequence+uint64(i) {
+			return ErrInvalidBatchClaim("sequences of claims should be consecutive")
+		}
+		if len(claim.Payload) < types.PackageHeaderLength {
+			return ErrInvalidPayloadHeader(fmt.Sprintf("length of payload is less than %d", types.PackageHeaderLength))
+		}
+	}
+	if len(msg.ValidatorAddress) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.ValidatorAddress.String())
+	}
+	return nil
+}
+
+// BatchClaimResult is the result of a claim of BatchClaimMsg
+type BatchClaimResult struct {
+	Sequence uint64           `json:"sequence"`
+	Code     sdk.ABCICodeType `json:"code"`
+	Log      string           `json:"log,omitempty"`
+	// Status is the status of the prophecy after the claim
+	Status StatusText `json:"status"`
+}
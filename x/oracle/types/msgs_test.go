@@ -38,3 +38,48 @@ func TestClaimMsg(t *testing.T) {
 		}
 	}
 }
+
+func TestBatchClaimMsg(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+
+	newClaims := func(start uint64, count int) []BatchClaim {
+		claims := make([]BatchClaim, 0, count)
+		for i := 0; i < count; i++ {
+			claims = append(claims, BatchClaim{Sequence: start + uint64(i), Payload: common.RandBytes(types.PackageHeaderLength)})
+		}
+		return claims
+	}
+
+	tests := []struct {
+		claimMsg     BatchClaimMsg
+		expectedPass bool
+	}{
+		{
+			NewBatchClaimMsg(1, newClaims(1, 3), addrs[0]),
+			true,
+		}, {
+			NewBatchClaimMsg(1, nil, addrs[0]),
+			false,
+		}, {
+			NewBatchClaimMsg(1, newClaims(1, MaxBatchClaims+1), addrs[0]),
+			false,
+		}, {
+			NewBatchClaimMsg(1, append(newClaims(1, 2), newClaims(4, 1)...), addrs[0]),
+			false,
+		}, {
+			NewBatchClaimMsg(1, append(newClaims(1, 1), BatchClaim{Sequence: 2, Payload: []byte("test")}), addrs[0]),
+			false,
+		}, {
+			NewBatchClaimMsg(1, newClaims(1, 1), sdk.AccAddress{1}),
+			false,
+		},
+	}
+
+	for i, test := range tests {
+		if test.expectedPass {
+			require.Nil(t, test.claimMsg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, test.claimMsg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
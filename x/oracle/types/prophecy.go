@@ -11,18 +11,46 @@ import (
 var (
 	// DefaultConsensusNeeded defines the default consensus value required for a
 	// prophecy to be finalized
-	DefaultConsensusNeeded      sdk.Dec = sdk.NewDecWithPrec(7, 1)
-	ParamStoreKeyProphecyParams         = []byte("prophecyParams")
+	DefaultConsensusNeeded            sdk.Dec = sdk.NewDecWithPrec(7, 1)
+	ParamStoreKeyProphecyParams               = []byte("prophecyParams")
+	ParamStoreKeyProphecyExpiryWindow         = []byte("prophecyExpiryWindow")
 )
 
+// DefaultProphecyExpiryWindow keeps the prophecies until governance sets an expiry window
+const DefaultProphecyExpiryWindow int64 = 0
+
 type Params struct {
 	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"` //  Minimum deposit for a proposal to enter voting period.
+	// VoteStrategies overrides the power weighted strategy for the listed destination chains
+	VoteStrategies []VoteStrategySetting `json:"vote_strategies,omitempty"`
+	// ProphecyExpiryWindow is the number of blocks a prophecy can stay pending before it is pruned, 0 disables pruning
+	ProphecyExpiryWindow int64 `json:"prophecy_expiry_window,omitempty"`
+	// RelayerRewardPolicy decides how RelayerRewardRatio of relay fees is shared with the relayers whose claims
+	// agreed, the rest goes to the block proposer.
+	RelayerRewardPolicy string  `json:"relayer_reward_policy,omitempty"`
+	RelayerRewardRatio  sdk.Dec `json:"relayer_reward_ratio"`
 }
 
 func (p *Params) UpdateCheck() error {
 	if p.ConsensusNeeded.IsNil() || p.ConsensusNeeded.GT(sdk.OneDec()) || p.ConsensusNeeded.LT(sdk.NewDecWithPrec(5, 1)) {
 		return fmt.Errorf("the value should be in range 0.5 to 1")
 	}
+	if p.ProphecyExpiryWindow < 0 {
+		return fmt.Errorf("the prophecy expiry window can not be negative")
+	}
+	if err := checkRelayerRewardParams(p.RelayerRewardPolicy, p.RelayerRewardRatio); err != nil {
+		return err
+	}
+	chains := make(map[string]bool, len(p.VoteStrategies))
+	for _, setting := range p.VoteStrategies {
+		if err := setting.Check(); err != nil {
+			return err
+		}
+		if chains[setting.DestChainName] {
+			return fmt.Errorf("duplicated vote strategy of chain %s", setting.DestChainName)
+		}
+		chains[setting.DestChainName] = true
+	}
 	return nil
 }
 
@@ -30,10 +58,34 @@ func (p *Params) GetParamAttribute() (string, bool) {
 	return "oracle", true
 }
 
+// KeyValuePairs only includes the params whose upgrade is active, so that they are not written before it
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
-	return params.KeyValuePairs{
+	pairs := params.KeyValuePairs{
 		{ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
 	}
+	if sdk.IsUpgrade(sdk.OracleVoteStrategy) {
+		pairs = append(pairs, params.KeyValuePairs{{ParamStoreKeyVoteStrategies, &p.VoteStrategies}}...)
+	}
+	if sdk.IsUpgrade(sdk.ProphecyExpiry) {
+		pairs = append(pairs, params.KeyValuePairs{{ParamStoreKeyProphecyExpiryWindow, &p.ProphecyExpiryWindow}}...)
+	}
+	if sdk.IsUpgrade(sdk.OracleRelayerReward) {
+		pairs = append(pairs, params.KeyValuePairs{
+			{ParamStoreKeyRelayerRewardPolicy, &p.RelayerRewardPolicy},
+			{ParamStoreKeyRelayerRewardRatio, &p.RelayerRewardRatio},
+		}...)
+	}
+	return pairs
+}
+
+func DefaultParams() Params {
+	return Params{
+		ConsensusNeeded:      DefaultConsensusNeeded,
+		VoteStrategies:       []VoteStrategySetting{},
+		ProphecyExpiryWindow: DefaultProphecyExpiryWindow,
+		RelayerRewardPolicy:  RelayerRewardPolicyProposer,
+		RelayerRewardRatio:   sdk.ZeroDec(),
+	}
 }
 
 // Prophecy is a struct that contains all the metadata of an oracle ritual.
@@ -45,6 +97,8 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 type Prophecy struct {
 	ID     string `json:"id"`
 	Status Status `json:"status"`
+	// CreationHeight is zero for prophecies created before the ProphecyExpiry upgrade
+	CreationHeight int64 `json:"creation_height"`
 
 	//WARNING: Mappings are nondeterministic in Amino,
 	// an so iterating over them could result in consensus failure. New code should not iterate over the below 2 mappings.
@@ -56,11 +110,13 @@ type Prophecy struct {
 }
 
 // DBProphecy is what the prophecy becomes when being saved to the database.
-//  Tendermint/Amino does not support maps so we must serialize those variables into bytes.
+//
+//	Tendermint/Amino does not support maps so we must serialize those variables into bytes.
 type DBProphecy struct {
 	ID              string `json:"id"`
 	Status          Status `json:"status"`
 	ValidatorClaims []byte `json:"validator_claims"`
+	CreationHeight  int64  `json:"creation_height"`
 }
 
 // SerializeForDB serializes a prophecy into a DBProphecy
@@ -74,6 +130,7 @@ func (prophecy Prophecy) SerializeForDB() (DBProphecy, error) {
 		ID:              prophecy.ID,
 		Status:          prophecy.Status,
 		ValidatorClaims: validatorClaims,
+		CreationHeight:  prophecy.CreationHeight,
 	}, nil
 }
 
@@ -96,6 +153,7 @@ func (dbProphecy DBProphecy) DeserializeFromDB() (Prophecy, error) {
 	return Prophecy{
 		ID:              dbProphecy.ID,
 		Status:          dbProphecy.Status,
+		CreationHeight:  dbProphecy.CreationHeight,
 		ClaimValidators: claimValidators,
 		ValidatorClaims: validatorClaims,
 	}, nil
@@ -105,34 +163,42 @@ func (dbProphecy DBProphecy) DeserializeFromDB() (Prophecy, error) {
 // all claims and returns the highest claim, power for that claim, and total power claimed on the prophecy overall.
 func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKeeper) (string, int64, int64, int64) {
 	validatorsPowerMap := stakeKeeper.GetOracleRelayersPower(ctx)
-	totalClaimsPower := int64(0)
-	highestClaimPower := int64(-1)
+	highestClaim, highestClaimPower, totalClaimsPower := prophecy.TallyClaims(validatorsPowerMap)
+	totalPower := int64(0)
+	if !sdk.IsUpgrade(sdk.BEP159Phase2) {
+		totalPower = stakeKeeper.GetLastTotalPower(ctx)
+	} else {
+		for _, power := range validatorsPowerMap {
+			totalPower += power
+		}
+	}
+	return highestClaim, highestClaimPower, totalClaimsPower, totalPower
+}
+
+// TallyClaims adds up the weights of the validators of every claim, validators absent from weights are ignored.
+// It returns the highest claim, weight for that claim, and total weight claimed on the prophecy overall.
+func (prophecy Prophecy) TallyClaims(weights map[string]int64) (string, int64, int64) {
+	totalClaimsWeight := int64(0)
+	highestClaimWeight := int64(-1)
 	highestClaim := ""
 	for claim, validatorAddrs := range prophecy.ClaimValidators {
-		claimPower := int64(0)
+		claimWeight := int64(0)
 		for _, validatorAddr := range validatorAddrs {
-			power, found := validatorsPowerMap[validatorAddr.String()]
+			weight, found := weights[validatorAddr.String()]
 			if found {
 				// Note: If claim validator is not found in the current validator set, we assume it is no longer
 				// an active validator and so can silently ignore it's claim and no longer count it towards total power.
-				claimPower += power
+				claimWeight += weight
 			}
 		}
-		totalClaimsPower += claimPower
-		if claimPower > highestClaimPower {
-			highestClaimPower = claimPower
+		totalClaimsWeight += claimWeight
+		// break ties by the claim itself so that the result does not depend on the map iteration order
+		if claimWeight > highestClaimWeight || (claimWeight == highestClaimWeight && claim < highestClaim) {
+			highestClaimWeight = claimWeight
 			highestClaim = claim
 		}
 	}
-	totalPower := int64(0)
-	if !sdk.IsUpgrade(sdk.BEP159Phase2) {
-		totalPower = stakeKeeper.GetLastTotalPower(ctx)
-	} else {
-		for _, power := range validatorsPowerMap {
-			totalPower += power
-		}
-	}
-	return highestClaim, highestClaimPower, totalClaimsPower, totalPower
+	return highestClaim, highestClaimWeight, totalClaimsWeight
 }
 
 // AddClaim adds a given claim to this prophecy
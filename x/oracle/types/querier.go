@@ -0,0 +1,108 @@
+package types
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryPendingProphecies = "pendingProphecies"
+
+	DefaultPendingPropheciesLimit = 100
+	MaxPendingPropheciesLimit     = 1000
+)
+
+type QueryPendingPropheciesParams struct {
+	// SideChainId filters the prophecies of the side chain, prophecies of all chains are returned if it is empty
+	SideChainId string `json:"side_chain_id"`
+	Limit       int    `json:"limit"`
+}
+
+// ProphecyClaim is a claim and the validators that made it
+type ProphecyClaim struct {
+	Claim      string           `json:"claim"`
+	Validators []sdk.ValAddress `json:"validators"`
+}
+
+type PendingProphecy struct {
+	ID             string `json:"id"`
+	CreationHeight int64  `json:"creation_height"`
+	// ExpireHeight is the height the prophecy is pruned at, it is zero if pruning is disabled
+	ExpireHeight int64           `json:"expire_height"`
+	Claims       []ProphecyClaim `json:"claims"`
+}
+
+func NewPendingProphecy(prophecy Prophecy, expiryWindow int64) PendingProphecy {
+	pending := PendingProphecy{
+		ID:             prophecy.ID,
+		CreationHeight: prophecy.CreationHeight,
+		Claims:         prophecy.SortedClaims(),
+	}
+	if expiryWindow > 0 {
+		pending.ExpireHeight = prophecy.CreationHeight + expiryWindow + 1
+	}
+	return pending
+}
+
+// SortedClaims returns the claims of the prophecy ordered by the number of validators that made them
+func (prophecy Prophecy) SortedClaims() []ProphecyClaim {
+	claims := make([]ProphecyClaim, 0, len(prophecy.ClaimValidators))
+	for claim, validators := range prophecy.ClaimValidators {
+		sorted := make([]sdk.ValAddress, len(validators))
+		copy(sorted, validators)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+		claims = append(claims, ProphecyClaim{Claim: claim, Validators: sorted})
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		if len(claims[i].Validators) != len(claims[j].Validators) {
+			return len(claims[i].Validators) > len(claims[j].Validators)
+		}
+		return claims[i].Claim < claims[j].Claim
+	})
+	return claims
+}
+
+// FindDisagreeingValidators returns the claim made by the most validators and the validators that made other claims
+func (prophecy Prophecy) FindDisagreeingValidators() (string, []sdk.ValAddress) {
+	claims := prophecy.SortedClaims()
+	if len(claims) == 0 {
+		return "", nil
+	}
+	disagreeing := make([]sdk.ValAddress, 0)
+	for _, claim := range claims[1:] {
+		disagreeing = append(disagreeing, claim.Validators...)
+	}
+	return claims[0].Claim, disagreeing
+}
+
+const QuerySimulatePackage = "simulatePackage"
+
+type QuerySimulatePackageParams struct {
+	SideChainId string        `json:"side_chain_id"`
+	ChannelId   sdk.ChannelID `json:"channel_id"`
+	// Sequence is the receive sequence of the package, the current receive sequence of the channel is used if it is nil
+	Sequence *uint64 `json:"sequence,omitempty"`
+	// Package is the raw package, including the package header
+	Package []byte `json:"package"`
+}
+
+// BalanceChange is the balance of an account changed by the execution of a package
+type BalanceChange struct {
+	Address sdk.AccAddress `json:"address"`
+	Before  sdk.Coins      `json:"before"`
+	After   sdk.Coins      `json:"after"`
+}
+
+// PackageSimulation is what the delivery of a package would do, nothing of it is committed
+type PackageSimulation struct {
+	Sequence uint64 `json:"sequence"`
+	// Crash is true if the cross chain application would panic on the package
+	Crash bool             `json:"crash"`
+	Code  sdk.ABCICodeType `json:"code"`
+	Log   string           `json:"log"`
+	// AckPayload is the ack or fail ack package that would be sent back, it is empty if there is none
+	AckPayload     []byte           `json:"ack_payload,omitempty"`
+	Events         sdk.StringEvents `json:"events"`
+	BalanceChanges []BalanceChange  `json:"balance_changes"`
+}
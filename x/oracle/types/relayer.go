@@ -0,0 +1,102 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// RelayerRewardPolicyProposer gives all relay fees to the block proposer, it is the default policy.
+	RelayerRewardPolicyProposer = "proposer"
+	// RelayerRewardPolicyEqual splits the relayer share of relay fees equally among the relayers whose claims agreed.
+	RelayerRewardPolicyEqual = "equal"
+	// RelayerRewardPolicyPower splits the relayer share of relay fees among the relayers whose claims agreed
+	// by their power.
+	RelayerRewardPolicyPower = "power"
+)
+
+var (
+	ParamStoreKeyRelayerRewardPolicy = []byte("relayerRewardPolicy")
+	ParamStoreKeyRelayerRewardRatio  = []byte("relayerRewardRatio")
+)
+
+func checkRelayerRewardParams(policy string, ratio sdk.Dec) error {
+	switch policy {
+	case "", RelayerRewardPolicyProposer, RelayerRewardPolicyEqual, RelayerRewardPolicyPower:
+	default:
+		return fmt.Errorf("unknown relayer reward policy %s", policy)
+	}
+	// the ratio is not set in the param changes made before the OracleRelayerReward upgrade
+	if !ratio.IsNil() && (ratio.LT(sdk.ZeroDec()) || ratio.GT(sdk.OneDec())) {
+		return fmt.Errorf("the relayer reward ratio should be in range 0 to 1")
+	}
+	return nil
+}
+
+// RelayerStats records the claims of an oracle relayer
+type RelayerStats struct {
+	Validator       sdk.ValAddress `json:"validator"`
+	ClaimsSubmitted int64          `json:"claims_submitted"`
+	// ClaimsMatched is the number of claims that agreed with the final claim of a successful prophecy
+	ClaimsMatched int64 `json:"claims_matched"`
+	// TotalLatency is the sum of the blocks between the first claim of a prophecy and the claims of the relayer,
+	// TimedClaims is the number of claims it is known for.
+	TotalLatency    int64 `json:"total_latency"`
+	TimedClaims     int64 `json:"timed_claims"`
+	LastClaimHeight int64 `json:"last_claim_height"`
+	// Rewards is the amount of relay fees shared with the relayer
+	Rewards int64 `json:"rewards"`
+}
+
+func (s RelayerStats) MatchRate() sdk.Dec {
+	if s.ClaimsSubmitted == 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDec(s.ClaimsMatched).Quo(sdk.NewDec(s.ClaimsSubmitted))
+}
+
+func (s RelayerStats) AverageLatency() sdk.Dec {
+	if s.TimedClaims == 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDec(s.TotalLatency).Quo(sdk.NewDec(s.TimedClaims))
+}
+
+const (
+	QueryRelayerStats = "relayerStats"
+
+	RelayerStatsSortByMatchRate = "match_rate"
+	RelayerStatsSortByClaims    = "claims"
+	RelayerStatsSortByLatency   = "latency"
+	RelayerStatsSortByRewards   = "rewards"
+
+	DefaultRelayerStatsLimit = 100
+)
+
+type QueryRelayerStatsParams struct {
+	// Validator selects the stats of one relayer, stats of all relayers are returned if it is empty
+	Validator sdk.ValAddress `json:"validator"`
+	// SortBy is one of match_rate, claims, latency and rewards, relayers are sorted by match_rate by default
+	SortBy string `json:"sort_by"`
+	// Ascending puts the relayers with the lowest value first, which helps to find the underperforming ones
+	Ascending bool `json:"ascending"`
+	Limit     int  `json:"limit"`
+}
+
+// RelayerPerformance is an entry of the relayer leaderboard
+type RelayerPerformance struct {
+	Rank           int     `json:"rank"`
+	MatchRate      sdk.Dec `json:"match_rate"`
+	AverageLatency sdk.Dec `json:"average_latency"`
+	RelayerStats
+}
+
+func NewRelayerPerformance(rank int, stats RelayerStats) RelayerPerformance {
+	return RelayerPerformance{
+		Rank:           rank,
+		MatchRate:      stats.MatchRate(),
+		AverageLatency: stats.AverageLatency(),
+		RelayerStats:   stats,
+	}
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// VoteStrategyPower weighs every claim by the power of the oracle relayer, it is the default strategy.
+	VoteStrategyPower = "power"
+	// VoteStrategyEqualWeight gives every whitelisted oracle relayer one vote.
+	VoteStrategyEqualWeight = "equal_weight"
+	// VoteStrategyQuorum weighs claims by power, but a prophecy is only finalized after a quorum of the
+	// total power has claimed and the winning claim reaches the threshold of the claimed power.
+	VoteStrategyQuorum = "quorum"
+)
+
+var ParamStoreKeyVoteStrategies = []byte("voteStrategies")
+
+// VoteStrategySetting selects the vote strategy used for the prophecies of a destination chain
+type VoteStrategySetting struct {
+	DestChainName string `json:"dest_chain_name"`
+	Strategy      string `json:"strategy"`
+
+	// Whitelist is the set of relayers counted by the equal_weight strategy, all oracle relayers are
+	// counted if it is empty.
+	Whitelist []sdk.ValAddress `json:"whitelist,omitempty"`
+	// Quorum is the ratio of the total power that must have claimed, only used by the quorum strategy.
+	Quorum sdk.Dec `json:"quorum"`
+	// Threshold is the ratio the winning claim needs, ConsensusNeeded is used if it is zero.
+	Threshold sdk.Dec `json:"threshold"`
+}
+
+func (s VoteStrategySetting) Check() error {
+	if len(s.DestChainName) == 0 {
+		return fmt.Errorf("dest chain name of vote strategy can not be empty")
+	}
+	if len(s.Strategy) == 0 {
+		return fmt.Errorf("vote strategy of chain %s can not be empty", s.DestChainName)
+	}
+	if !s.Threshold.IsZero() && (s.Threshold.GT(sdk.OneDec()) || s.Threshold.LT(sdk.NewDecWithPrec(5, 1))) {
+		return fmt.Errorf("the threshold of chain %s should be in range 0.5 to 1", s.DestChainName)
+	}
+
+	switch s.Strategy {
+	case VoteStrategyEqualWeight:
+		seen := make(map[string]bool, len(s.Whitelist))
+		for _, addr := range s.Whitelist {
+			if len(addr) != sdk.AddrLen {
+				return fmt.Errorf("invalid whitelist address %s of chain %s", addr, s.DestChainName)
+			}
+			if seen[addr.String()] {
+				return fmt.Errorf("duplicated whitelist address %s of chain %s", addr, s.DestChainName)
+			}
+			seen[addr.String()] = true
+		}
+	case VoteStrategyQuorum:
+		if !s.Quorum.GT(sdk.ZeroDec()) || s.Quorum.GT(sdk.OneDec()) {
+			return fmt.Errorf("the quorum of chain %s should be in range 0 to 1", s.DestChainName)
+		}
+	}
+	return nil
+}
@@ -12,5 +12,6 @@ func RegisterWire(cdc *codec.Codec) {
 	cdc.RegisterConcrete(Status{}, "oracle/Status", nil)
 	cdc.RegisterConcrete(DBProphecy{}, "oracle/DBProphecy", nil)
 	cdc.RegisterConcrete(ClaimMsg{}, "oracle/ClaimMsg", nil)
+	cdc.RegisterConcrete(BatchClaimMsg{}, "oracle/BatchClaimMsg", nil)
 	cdc.RegisterConcrete(&types.Params{}, "params/OracleParamSet", nil)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/tendermint/go-amino"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/x/paramHub"
 	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
 )
@@ -25,6 +26,10 @@ func GetFeesParamHandler(cdc *amino.Codec, ctx context.CLIContext) http.HandlerF
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, ok := utils.ParseQueryHeightOrReturnBadRequest(w, ctx, r)
+		if !ok {
+			return
+		}
 
 		bz, err := ctx.Query(fmt.Sprintf("%s/fees", paramHub.AbciQueryPrefix), nil)
 		if err != nil {
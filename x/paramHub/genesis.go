@@ -37,6 +37,14 @@ const (
 	SideChainDelegateFee        = 1e5
 	SideChainRedelegateFee      = 3e5
 	SideChainUndelegateFee      = 2e5
+	SideChainLiquidDelegateFee  = 1e5
+	SideChainRedeemReceiptFee   = 1e5
+	SideChainSetAutoCompoundFee = 1e5
+
+	SideChainCancelCommissionChangeFee = 1e5
+
+	SetInsurancePoolFee     = 1e5
+	DepositInsurancePoolFee = 1e5
 
 	// beacon chain stake fee
 	EditChainValidatorFee = 1e8
@@ -44,8 +52,12 @@ const (
 	ChainRedelegateFee    = 3e5
 	ChainUndelegateFee    = 2e5
 
+	// side chain fee
+	ResumeChannelFee = 1e6
+
 	// slashing fee
 	BscSubmitEvidenceFee = 10e8
+	SideChainEvidenceFee = 10e8
 	SideChainUnjail      = 1e8
 	Unjail               = 1e8
 
@@ -82,6 +94,9 @@ const (
 	// Cross stake fee
 	CrossDistributeRewardRelayFee      = 6e5 // 0.006 BNB
 	CrossDistributeUndelegatedRelayFee = 6e5 // 0.006 BNB
+
+	// Max gas the txs of a block can use, set when gas metering is enabled
+	DefaultBlockGasLimit = 1e9
 )
 
 var DefaultGenesisState = param.GenesisState{
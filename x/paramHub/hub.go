@@ -133,6 +133,53 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 		}
 		paramHub.UpdateFeeParams(ctx, updateFeeParams)
 	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.LiquidStaking, func(ctx sdk.Context) {
+		liquidStakeFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "side_liquid_delegate", Fee: SideChainLiquidDelegateFee, FeeFor: sdk.FeeForProposer},
+			&param.FixedFeeParams{MsgType: "side_redeem_receipt", Fee: SideChainRedeemReceiptFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, liquidStakeFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.AutoCompound, func(ctx sdk.Context) {
+		autoCompoundFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "side_set_auto_compound", Fee: SideChainSetAutoCompoundFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, autoCompoundFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.CommissionSchedule, func(ctx sdk.Context) {
+		commissionScheduleFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "side_cancel_commission_change", Fee: SideChainCancelCommissionChangeFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, commissionScheduleFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.SlashInsurance, func(ctx sdk.Context) {
+		slashInsuranceFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "set_insurance_pool", Fee: SetInsurancePoolFee, FeeFor: sdk.FeeForProposer},
+			&param.FixedFeeParams{MsgType: "deposit_insurance_pool", Fee: DepositInsurancePoolFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, slashInsuranceFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ChannelRateLimit, func(ctx sdk.Context) {
+		channelRateLimitFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "resumeChannel", Fee: ResumeChannelFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, channelRateLimitFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.OracleBatchClaim, func(ctx sdk.Context) {
+		oracleBatchClaimFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "oracleBatchClaim", Fee: sdk.ZeroFee, FeeFor: sdk.FeeFree},
+		}
+		paramHub.UpdateFeeParams(ctx, oracleBatchClaimFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.SideChainEvidence, func(ctx sdk.Context) {
+		sideChainEvidenceFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "side_chain_submit_evidence", Fee: SideChainEvidenceFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, sideChainEvidenceFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.GasMetering, func(ctx sdk.Context) {
+		paramHub.SetBlockGasLimit(ctx, DefaultBlockGasLimit)
+	})
 }
 
 func EndBreatheBlock(ctx sdk.Context, paramHub *ParamHub) {
@@ -162,7 +209,15 @@ func init() {
 		"side_delegate":                      fees.FixedFeeCalculatorGen,
 		"side_redelegate":                    fees.FixedFeeCalculatorGen,
 		"side_undelegate":                    fees.FixedFeeCalculatorGen,
+		"side_liquid_delegate":               fees.FixedFeeCalculatorGen,
+		"side_redeem_receipt":                fees.FixedFeeCalculatorGen,
+		"side_set_auto_compound":             fees.FixedFeeCalculatorGen,
+		"side_cancel_commission_change":      fees.FixedFeeCalculatorGen,
+		"set_insurance_pool":                 fees.FixedFeeCalculatorGen,
+		"deposit_insurance_pool":             fees.FixedFeeCalculatorGen,
 		"bsc_submit_evidence":                fees.FixedFeeCalculatorGen,
+		"side_chain_submit_evidence":         fees.FixedFeeCalculatorGen,
+		"resumeChannel":                      fees.FixedFeeCalculatorGen,
 		"side_chain_unjail":                  fees.FixedFeeCalculatorGen,
 		"dexList":                            fees.FixedFeeCalculatorGen,
 		"orderNew":                           fees.FixedFeeCalculatorGen,
@@ -188,6 +243,7 @@ func init() {
 		"crossUnbindRelayFee":                fees.FixedFeeCalculatorGen,
 		"crossTransferOutRelayFee":           fees.FixedFeeCalculatorGen,
 		"oracleClaim":                        fees.FixedFeeCalculatorGen,
+		"oracleBatchClaim":                   fees.FixedFeeCalculatorGen,
 		"miniTokensSetURI":                   fees.FixedFeeCalculatorGen,
 		"dexListMini":                        fees.FixedFeeCalculatorGen,
 		"tinyIssueMsg":                       fees.FixedFeeCalculatorGen,
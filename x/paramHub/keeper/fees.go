@@ -94,7 +94,13 @@ func (keeper *Keeper) updateFeeCalculator(updates []types.FeeParam) {
 				if err != nil {
 					panic(err)
 				}
-				fees.RegisterCalculator(u.GetMsgType(), generator(u))
+				if gasParams, ok := u.(*types.GasFeeParams); ok {
+					calculator := generator(&gasParams.FixedFeeParams)
+					fees.RegisterCalculator(u.GetMsgType(), calculator)
+					fees.RegisterGasCalculator(u.GetMsgType(), fees.FixedAndGasFeeCalculator(calculator, gasParams.GasPrice))
+				} else {
+					fees.RegisterCalculator(u.GetMsgType(), generator(u))
+				}
 			}
 		}
 	}
@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
+)
+
+// GetBlockGasLimit returns the max gas the txs of a block can use, 0 meaning no
+// limit. It is the block gas limit getter of the BaseApp.
+func (keeper *Keeper) GetBlockGasLimit(ctx sdk.Context) (limit uint64) {
+	keeper.paramSpace.GetIfExists(ctx, types.KeyBlockGasLimit, &limit)
+	return
+}
+
+func (keeper *Keeper) SetBlockGasLimit(ctx sdk.Context, limit uint64) {
+	keeper.paramSpace.Set(ctx, types.KeyBlockGasLimit, limit)
+}
+
+// registerGasParamsCallBack lets governance change the block gas limit through
+// the beacon chain param changes, once gas is metered
+func (keeper *Keeper) registerGasParamsCallBack() {
+	keeper.SubscribeBCParamChange(
+		func(context sdk.Context, iChange interface{}) {
+			switch change := iChange.(type) {
+			case *types.GasParams:
+				if !sdk.IsUpgrade(sdk.GasMetering) {
+					break
+				}
+				keeper.SetBlockGasLimit(context, change.BlockGasLimit)
+			default:
+				context.Logger().Debug("[bc] skip unknown bc param change")
+			}
+		},
+		&types.BCParamSpaceProto{ParamSpace: keeper.paramSpace, Proto: func() types.BCParam {
+			return new(types.GasParams)
+		}},
+	)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
@@ -38,6 +37,7 @@ func ParamTypeTable() params.TypeTable {
 		ParamStoreKeyFees, []types.FeeParam{},
 		ParamStoreKeySCLastParamsChangeProposalID, types.LastProposalID{},
 		ParamStoreKeyBCLastParamsChangeProposalID, types.LastProposalID{},
+		types.KeyBlockGasLimit, uint64(0),
 	)
 }
 
@@ -73,6 +73,7 @@ func NewKeeper(cdc *codec.Codec, key *sdk.KVStoreKey, tkey *sdk.TransientStoreKe
 	// Add global callback(belongs to no other plugin) here
 	keeper.registerFeeParamCallBack()
 	keeper.registerCSCParamsCallBack()
+	keeper.registerGasParamsCallBack()
 	return &keeper
 }
 
@@ -228,18 +229,22 @@ func (keeper *Keeper) SubscribeLoadEvent(c func(sdk.Context, interface{})) {
 	keeper.loadCallBacks = append(keeper.loadCallBacks, c)
 }
 
+func (keeper *Keeper) RegisterPackageTypes(codec *sdk.CrossChainPackageCodec, channelID sdk.ChannelID) error {
+	return codec.RegisterPackageType(channelID, sdk.AckCrossChainPackageType, sTypes.CommonAckPackage{})
+}
+
 // implement cross chain app
 func (keeper *Keeper) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
 	panic("receive unexpected package")
 }
 
 func (keeper *Keeper) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
-	var ackPackage sTypes.CommonAckPackage
-	err := rlp.DecodeBytes(payload, &ackPackage)
+	decoded, err := keeper.ScKeeper.DecodePackagePayload(ChannelId, sdk.AckCrossChainPackageType, payload)
 	if err != nil {
 		keeper.Logger(ctx).Error("fail to decode ack package", "payload", payload)
 		return sdk.ExecuteResult{Err: types.ErrInvalidCrossChainPackage(types.DefaultCodespace)}
 	}
+	ackPackage := decoded.(*sTypes.CommonAckPackage)
 	if !ackPackage.IsOk() {
 		keeper.Logger(ctx).Error("side chain failed to process param package", "code", ackPackage.Code)
 	}
@@ -14,6 +14,7 @@ const (
 	OperateFeeType  = "operate"
 	TransferFeeType = "transfer"
 	DexFeeType      = "dex"
+	GasFeeType      = "gas"
 
 	JSONFORMAT  = "json"
 	AMINOFORMAT = "amino"
@@ -46,20 +47,31 @@ var (
 		"crossUnbindRelayFee":      {},
 		"crossTransferOutRelayFee": {},
 		"oracleClaim":              {},
+		"oracleBatchClaim":         {},
+		"resumeChannel":            {},
 
 		"HTLT":        {},
 		"depositHTLT": {},
 		"claimHTLT":   {},
 		"refundHTLT":  {},
 
-		"side_create_validator": {},
-		"side_edit_validator":   {},
-		"side_delegate":         {},
-		"side_redelegate":       {},
-		"side_undelegate":       {},
+		"side_create_validator":  {},
+		"side_edit_validator":    {},
+		"side_delegate":          {},
+		"side_redelegate":        {},
+		"side_undelegate":        {},
+		"side_liquid_delegate":   {},
+		"side_redeem_receipt":    {},
+		"side_set_auto_compound": {},
 
-		"bsc_submit_evidence": {},
-		"side_chain_unjail":   {},
+		"side_cancel_commission_change": {},
+
+		"set_insurance_pool":     {},
+		"deposit_insurance_pool": {},
+
+		"bsc_submit_evidence":        {},
+		"side_chain_submit_evidence": {},
+		"side_chain_unjail":          {},
 
 		"side_submit_proposal": {},
 		"side_deposit":         {},
@@ -180,6 +192,32 @@ func (p *TransferFeeParam) Check() error {
 	return nil
 }
 
+var _ MsgFeeParams = (*GasFeeParams)(nil)
+
+// GasFeeParams charges GasPrice per gas used by a msg on top of its fixed fee,
+// once gas is metered
+type GasFeeParams struct {
+	FixedFeeParams `json:"fixed_fee_params"`
+	GasPrice       int64 `json:"gas_price"`
+}
+
+func (p *GasFeeParams) GetParamType() string {
+	return GasFeeType
+}
+
+func (p *GasFeeParams) Check() error {
+	if !sdk.IsUpgrade(sdk.GasMetering) {
+		return fmt.Errorf("gas fee params are not supported before gas metering is enabled")
+	}
+	if err := p.FixedFeeParams.Check(); err != nil {
+		return err
+	}
+	if p.GasPrice < 0 {
+		return fmt.Errorf("gas_price(%d) should not be negative", p.GasPrice)
+	}
+	return nil
+}
+
 type DexFeeField struct {
 	FeeName  string `json:"fee_name"`
 	FeeValue int64  `json:"fee_value"`
@@ -350,6 +388,12 @@ type BCChangeParams struct {
 func (s *BCChangeParams) Check() error {
 	// use literal string to avoid import cycle
 	supportParams := []string{"staking"}
+	if sdk.IsUpgrade(sdk.ChannelRateLimit) {
+		supportParams = append(supportParams, "sidechain")
+	}
+	if sdk.IsUpgrade(sdk.GasMetering) {
+		supportParams = append(supportParams, GasParamsAttribute)
+	}
 
 	if len(s.BCParams) != len(supportParams) {
 		return fmt.Errorf("the bc_params length mismatch, suppose %d", len(supportParams))
@@ -377,3 +421,38 @@ func (s *BCChangeParams) Check() error {
 	}
 	return nil
 }
+
+// GasParamsAttribute is the attribute of GasParams in the beacon chain param changes
+const GasParamsAttribute = "gas"
+
+var KeyBlockGasLimit = []byte("blockGasLimit")
+
+var _ BCParam = (*GasParams)(nil)
+
+// GasParams are the params of the gas metered from the GasMetering upgrade,
+// they are set by governance through the beacon chain param changes
+type GasParams struct {
+	// BlockGasLimit is the max gas the txs of a block can use, 0 meaning no limit
+	BlockGasLimit uint64 `json:"block_gas_limit"`
+}
+
+// Implements params.ParamSet, the params are only stored once gas is metered
+func (p *GasParams) KeyValuePairs() subspace.KeyValuePairs {
+	if !sdk.IsUpgrade(sdk.GasMetering) {
+		return subspace.KeyValuePairs{}
+	}
+	return subspace.KeyValuePairs{
+		{KeyBlockGasLimit, &p.BlockGasLimit},
+	}
+}
+
+func (p *GasParams) GetBCParamAttribute() string {
+	return GasParamsAttribute
+}
+
+func (p *GasParams) UpdateCheck() error {
+	if !sdk.IsUpgrade(sdk.GasMetering) {
+		return fmt.Errorf("gas params are not supported before gas metering is enabled")
+	}
+	return nil
+}
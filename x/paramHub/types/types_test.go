@@ -38,6 +38,32 @@ func TestFixedFeeParamTypeCheck(t *testing.T) {
 	}
 }
 
+func TestGasFeeParamTypeCheck(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+	fp := fTypes.GasFeeParams{fTypes.FixedFeeParams{"tokensBurn", 100, sdk.FeeForProposer}, 10}
+	assert.Error(t, fp.Check())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GasMetering, 10)
+	sdk.UpgradeMgr.SetHeight(10)
+	testCases := []struct {
+		fp          fTypes.GasFeeParams
+		expectError bool
+	}{
+		{fp, false},
+		{fTypes.GasFeeParams{fTypes.FixedFeeParams{"tokensBurn", 0, sdk.FeeForProposer}, 0}, false},
+		{fTypes.GasFeeParams{fTypes.FixedFeeParams{"send", 100, sdk.FeeForProposer}, 10}, true},
+		{fTypes.GasFeeParams{fTypes.FixedFeeParams{"tokensBurn", 100, sdk.FeeForProposer}, -1}, true},
+	}
+	for _, testCase := range testCases {
+		err := testCase.fp.Check()
+		if testCase.expectError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func TestTransferFeeParamTypeCheck(t *testing.T) {
 	testCases := []struct {
 		fp          fTypes.TransferFeeParam
@@ -171,6 +197,24 @@ func TestSCParamCheck(t *testing.T) {
 
 }
 
+func TestBCParamChangeGasParams(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+	stakeParams := stake.DefaultParams()
+	stakeParams.BondDenom = sdk.NativeTokenSymbol
+	gasParams := fTypes.GasParams{BlockGasLimit: 1e8}
+	withoutGas := fTypes.BCChangeParams{BCParams: []fTypes.BCParam{&stakeParams}}
+	withGas := fTypes.BCChangeParams{BCParams: []fTypes.BCParam{&stakeParams, &gasParams}}
+
+	// the gas params can't be changed before gas is metered
+	assert.NoError(t, withoutGas.Check())
+	assert.Error(t, withGas.Check())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GasMetering, 10)
+	sdk.UpgradeMgr.SetHeight(10)
+	assert.Error(t, withoutGas.Check())
+	assert.NoError(t, withGas.Check())
+}
+
 func generatCSCParamChange() fTypes.CSCParamChange {
 	return fTypes.CSCParamChange{
 		Key:    common.RandStr(common.RandIntn(255) + 1),
@@ -12,6 +12,8 @@ func RegisterWire(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&types.FixedFeeParams{}, "params/FixedFeeParams", nil)
 	cdc.RegisterConcrete(&types.TransferFeeParam{}, "params/TransferFeeParams", nil)
 	cdc.RegisterConcrete(&types.DexFeeParam{}, "params/DexFeeParam", nil)
+	cdc.RegisterConcrete(&types.GasFeeParams{}, "params/GasFeeParams", nil)
 	cdc.RegisterInterface((*types.SCParam)(nil), nil)
 	cdc.RegisterInterface((*types.BCParam)(nil), nil)
+	cdc.RegisterConcrete(&types.GasParams{}, "params/GasParamSet", nil)
 }
@@ -23,6 +23,9 @@ import (
 const (
 	flagChannelId     = "channel-id"
 	flagChannelEnable = "enable"
+	flagPerBlockCap   = "per-block-cap"
+	flagWindowCap     = "window-cap"
+	flagWindowBlocks  = "window-blocks"
 )
 
 func SubmitChannelManageProposalCmd(cdc *codec.Codec) *cobra.Command {
@@ -52,6 +55,13 @@ func SubmitChannelManageProposalCmd(cdc *codec.Codec) *cobra.Command {
 			} else {
 				channelSetting.Permission = sdk.ChannelForbidden
 			}
+			if cmd.Flags().Changed(flagPerBlockCap) || cmd.Flags().Changed(flagWindowCap) || cmd.Flags().Changed(flagWindowBlocks) {
+				channelSetting.RateLimit = &types.ChannelRateLimit{
+					PerBlockCap:  viper.GetInt64(flagPerBlockCap),
+					WindowCap:    viper.GetInt64(flagWindowCap),
+					WindowBlocks: viper.GetInt64(flagWindowBlocks),
+				}
+			}
 
 			err := channelSetting.Check()
 			if err != nil {
@@ -92,7 +102,10 @@ func SubmitChannelManageProposalCmd(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 	cmd.Flags().Uint8(flagChannelId, 0, "the the channel id that want to manage")
-	cmd.Flags().Bool(flagChannelEnable, true, "enable the channel or not")
+	cmd.Flags().Bool(flagChannelEnable, true, "enable the channel or not, enabling also resumes a paused channel")
+	cmd.Flags().Int64(flagPerBlockCap, 0, "max value of packages in a block, 0 means unlimited")
+	cmd.Flags().Int64(flagWindowCap, 0, "max value of packages in a window, 0 means unlimited")
+	cmd.Flags().Int64(flagWindowBlocks, 0, "number of blocks of a window")
 	cmd.Flags().String(flagTitle, "", "title of proposal")
 	cmd.Flags().Int64(flagVotingPeriod, 7*24*60*60, "voting period in seconds")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
@@ -130,3 +143,68 @@ func ShowChannelPermissionCmd(cdc *amino.Codec) *cobra.Command {
 	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
 	return cmd
 }
+
+func ShowChannelStatusCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-channel-status",
+		Short: "Show rate limit and pause status of the channels of side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+
+			queryData, err := cdc.MarshalJSON(sideChainId)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/sideChain/channelStatus"), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	return cmd
+}
+
+func ResumeChannelCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume-channel",
+		Short: "Resume a channel paused by the circuit breaker, only the guardian is allowed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+			fromAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgResumeChannel(fromAddr, sideChainId, sdk.ChannelID(viper.GetUint(flagChannelId)))
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Uint8(flagChannelId, 0, "the id of the paused channel")
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	return cmd
+}
@@ -22,9 +22,12 @@ func AddCommands(cmd *cobra.Command, cdc *amino.Codec) {
 	}
 	dexCmd.AddCommand(
 		client.PostCommands(
-			SubmitChannelManageProposalCmd(cdc))...)
+			SubmitChannelManageProposalCmd(cdc),
+			ResumeChannelCmd(cdc))...)
 	dexCmd.AddCommand(
 		client.GetCommands(
-			ShowChannelPermissionCmd(cdc))...)
+			ShowChannelPermissionCmd(cdc),
+			ShowChannelStatusCmd(cdc),
+			DecodePackageCmd(cdc))...)
 	cmd.AddCommand(dexCmd)
 }
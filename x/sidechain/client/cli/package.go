@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+func DecodePackageCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode-package [hex-package]",
+		Short: "Decode a raw cross chain package of a channel, including the package header",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			rawPackage, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid hex package: %v", err)
+			}
+			params := types.QueryDecodePackageParams{
+				ChannelId: sdk.ChannelID(viper.GetUint(flagChannelId)),
+				Package:   rawPackage,
+			}
+			queryData, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/sideChain/decodePackage"), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint8(flagChannelId, 0, "the id of the channel the package belongs to")
+	return cmd
+}
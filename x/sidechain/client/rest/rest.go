@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// REST Variable names
+// nolint
+const (
+	RestChannelID = "channelId"
+	RestPackage   = "package"
+)
+
+// RegisterRoutes - Central function to define routes that get registered by the main application
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(fmt.Sprintf("/sidechain/channels/{%s}/packages/{%s}/decode", RestChannelID, RestPackage), decodePackageHandlerFn(cdc, cliCtx)).Methods("GET")
+}
+
+// decodePackageHandlerFn decodes a hex encoded raw package of a channel, including the package header
+func decodePackageHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		channelID, err := strconv.ParseUint(vars[RestChannelID], 10, 8)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid channel id", vars[RestChannelID]))
+			return
+		}
+		rawPackage, err := hex.DecodeString(strings.TrimPrefix(vars[RestPackage], "0x"))
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid hex package: %v", err))
+			return
+		}
+
+		params := types.QueryDecodePackageParams{
+			ChannelId: sdk.ChannelID(channelID),
+			Package:   rawPackage,
+		}
+		queryData, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/sideChain/decodePackage", queryData)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -0,0 +1,56 @@
+package sidechain
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// RegisterPackageType registers the struct that the packages of a channel and package type are RLP decoded into
+func (k *Keeper) RegisterPackageType(channelID sdk.ChannelID, packageType sdk.CrossChainPackageType, pack interface{}) error {
+	return k.cfg.packageCodec.RegisterPackageType(channelID, packageType, pack)
+}
+
+// RegisterPackageDecoder registers a decode function for the packages of a channel and package type
+func (k *Keeper) RegisterPackageDecoder(channelID sdk.ChannelID, packageType sdk.CrossChainPackageType, decode sdk.CrossChainPackageDecodeFunc) error {
+	return k.cfg.packageCodec.RegisterPackageDecoder(channelID, packageType, decode)
+}
+
+// DecodePackagePayload decodes the payload of a package, which does not include the package header, into its
+// registered type. The cross chain applications decode their packages with it, so that the packages are executed
+// the same way as they are decoded by the queries.
+func (k *Keeper) DecodePackagePayload(channelID sdk.ChannelID, packageType sdk.CrossChainPackageType, payload []byte) (interface{}, error) {
+	return k.cfg.packageCodec.Decode(channelID, packageType, payload)
+}
+
+// DecodePackage decodes a raw package of a channel, which includes the package header, into its registered type
+func (k *Keeper) DecodePackage(channelID sdk.ChannelID, rawPackage []byte) (types.DecodedPackage, error) {
+	channelName, ok := k.cfg.channelIDToName[channelID]
+	if !ok {
+		return types.DecodedPackage{}, fmt.Errorf("channel %d is not registered", channelID)
+	}
+	packageType, relayFee, err := types.DecodePackageHeader(rawPackage)
+	if err != nil {
+		return types.DecodedPackage{}, err
+	}
+	payload := rawPackage[types.PackageHeaderLength:]
+	// the packages sent with a timeout are decoded as the packages of their base type
+	if sdk.IsTimeoutCrossChainPackageType(packageType) {
+		if len(payload) < types.PackageTimeoutHeaderLength {
+			return types.DecodedPackage{}, fmt.Errorf("package of type %d is too short", packageType)
+		}
+		payload = payload[types.PackageTimeoutHeaderLength:]
+	}
+	pack, err := k.cfg.packageCodec.Decode(channelID, sdk.WithoutTimeout(packageType), payload)
+	if err != nil {
+		return types.DecodedPackage{}, err
+	}
+	return types.DecodedPackage{
+		ChannelId:   channelID,
+		ChannelName: channelName,
+		PackageType: types.PackageTypeName(packageType),
+		RelayFee:    relayFee.String(),
+		Package:     pack,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package sidechain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+type typedApp struct {
+	valuedApp
+}
+
+func (typedApp) RegisterPackageTypes(codec *sdk.CrossChainPackageCodec, channelID sdk.ChannelID) error {
+	return codec.RegisterPackageType(channelID, sdk.AckCrossChainPackageType, types.CommonAckPackage{})
+}
+
+func TestDecodePackage(t *testing.T) {
+	_, keeper := CreateTestInput(t, false)
+	channelID := sdk.ChannelID(3)
+	require.NoError(t, keeper.RegisterChannel("typed", channelID, typedApp{}))
+
+	payload, err := types.GenCommonAckPackage(5)
+	require.NoError(t, err)
+	rawPackage := append(types.EncodePackageHeader(sdk.AckCrossChainPackageType, *big.NewInt(100)), payload...)
+
+	decoded, err := keeper.DecodePackage(channelID, rawPackage)
+	require.NoError(t, err)
+	require.Equal(t, "typed", decoded.ChannelName)
+	require.Equal(t, "ack", decoded.PackageType)
+	require.Equal(t, "100", decoded.RelayFee)
+	require.Equal(t, &types.CommonAckPackage{Code: 5}, decoded.Package)
+
+	// the applications decode the payloads with the same codec
+	pack, err := keeper.DecodePackagePayload(channelID, sdk.AckCrossChainPackageType, payload)
+	require.NoError(t, err)
+	require.Equal(t, &types.CommonAckPackage{Code: 5}, pack)
+
+	// syn packages of the channel are not registered
+	rawPackage = append(types.EncodePackageHeader(sdk.SynCrossChainPackageType, *big.NewInt(0)), payload...)
+	_, err = keeper.DecodePackage(channelID, rawPackage)
+	require.Error(t, err)
+
+	// the channel is not registered
+	_, err = keeper.DecodePackage(sdk.ChannelID(4), rawPackage)
+	require.Error(t, err)
+
+	// the package types can be registered once only
+	require.Error(t, keeper.RegisterPackageType(channelID, sdk.AckCrossChainPackageType, types.CommonAckPackage{}))
+}
@@ -8,6 +8,7 @@ type crossChainConfig struct {
 	nameToChannelID map[string]sdk.ChannelID
 	channelIDToName map[sdk.ChannelID]string
 	channelIDToApp  map[sdk.ChannelID]sdk.CrossChainApplication
+	packageCodec    *sdk.CrossChainPackageCodec
 
 	destChainNameToID map[string]sdk.ChainID
 	destChainIDToName map[sdk.ChainID]string
@@ -21,6 +22,7 @@ func newCrossChainCfg() *crossChainConfig {
 		destChainNameToID: make(map[string]sdk.ChainID),
 		destChainIDToName: make(map[sdk.ChainID]string),
 		channelIDToApp:    make(map[sdk.ChannelID]sdk.CrossChainApplication),
+		packageCodec:      sdk.NewCrossChainPackageCodec(),
 	}
 	return config
 }
@@ -7,9 +7,34 @@ import (
 const (
 	DefaultCodespace sdk.CodespaceType = 31
 
-	CodeInvalidSideChainId sdk.CodeType = 101
+	CodeInvalidSideChainId   sdk.CodeType = 101
+	CodeChannelPaused        sdk.CodeType = 102
+	CodeRateLimitExceeded    sdk.CodeType = 103
+	CodeUnauthorizedGuardian sdk.CodeType = 104
+	CodeInvalidChannelId     sdk.CodeType = 105
+	CodeInvalidPackage       sdk.CodeType = 106
 )
 
 func ErrInvalidSideChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidSideChainId, msg)
 }
+
+func ErrChannelPaused(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeChannelPaused, msg)
+}
+
+func ErrRateLimitExceeded(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeRateLimitExceeded, msg)
+}
+
+func ErrUnauthorizedGuardian(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnauthorizedGuardian, msg)
+}
+
+func ErrInvalidChannelId(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidChannelId, msg)
+}
+
+func ErrInvalidPackage(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPackage, msg)
+}
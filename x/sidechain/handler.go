@@ -0,0 +1,51 @@
+package sidechain
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case types.MsgResumeChannel:
+			if !sdk.IsUpgrade(sdk.ChannelRateLimit) {
+				return sdk.ErrMsgNotSupported("channel rate limit not activated yet").Result()
+			}
+			return handleMsgResumeChannel(ctx, k, msg)
+		default:
+			errMsg := "Unrecognized side chain msg type"
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgResumeChannel(ctx sdk.Context, k Keeper, msg types.MsgResumeChannel) sdk.Result {
+	guardian := k.ChannelGuardian(ctx)
+	if len(guardian) == 0 || !guardian.Equals(msg.Guardian) {
+		return ErrUnauthorizedGuardian(DefaultCodespace, fmt.Sprintf("%s is not the channel guardian", msg.Guardian)).Result()
+	}
+	destChainID, err := k.GetDestChainID(msg.SideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(DefaultCodespace, err.Error()).Result()
+	}
+	if _, ok := k.cfg.channelIDToName[msg.ChannelId]; !ok {
+		return ErrInvalidChannelId(DefaultCodespace, fmt.Sprintf("channel %d does not exist", msg.ChannelId)).Result()
+	}
+	if !k.IsChannelPaused(ctx, destChainID, msg.ChannelId) {
+		return ErrInvalidChannelId(DefaultCodespace, fmt.Sprintf("channel %d is not paused", msg.ChannelId)).Result()
+	}
+
+	k.ResumeChannel(ctx, destChainID, msg.ChannelId)
+	event := sdk.NewEvent(EventTypeChannelResumed,
+		sdk.NewAttribute(AttributeKeyDestChainID, strconv.FormatUint(uint64(destChainID), 10)),
+		sdk.NewAttribute(AttributeKeyChannelID, strconv.FormatUint(uint64(msg.ChannelId), 10)),
+		sdk.NewAttribute(AttributeKeyResumedBy, msg.Guardian.String()),
+	)
+	return sdk.Result{
+		Events: sdk.Events{event},
+	}
+}
@@ -3,6 +3,7 @@ package sidechain
 import (
 	"encoding/binary"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -93,6 +94,11 @@ func (k *Keeper) RegisterChannel(name string, id sdk.ChannelID, app sdk.CrossCha
 	if ok {
 		return fmt.Errorf("duplicated channel id")
 	}
+	if registrar, ok := app.(sdk.CrossChainPackageTypesRegistrar); ok {
+		if err := registrar.RegisterPackageTypes(k.cfg.packageCodec, id); err != nil {
+			return err
+		}
+	}
 	k.cfg.nameToChannelID[name] = id
 	k.cfg.channelIDToName[id] = name
 	k.cfg.channelIDToApp[id] = app
@@ -233,6 +239,18 @@ func EndBlock(ctx sdk.Context, k Keeper) {
 			// must exist
 			id, _ := k.cfg.destChainNameToID[change.SideChainId]
 			k.SetChannelSendPermission(ctx, id, change.ChannelId, change.Permission)
+			if change.RateLimit != nil && sdk.IsUpgrade(sdk.ChannelRateLimit) {
+				k.SetChannelRateLimit(ctx, id, change.ChannelId, *change.RateLimit)
+			}
+			// governance enabling a channel also resumes it from the circuit breaker
+			if change.Permission == sdk.ChannelAllow && sdk.IsUpgrade(sdk.ChannelRateLimit) && k.IsChannelPaused(ctx, id, change.ChannelId) {
+				k.ResumeChannel(ctx, id, change.ChannelId)
+				ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeChannelResumed,
+					sdk.NewAttribute(AttributeKeyDestChainID, strconv.FormatUint(uint64(id), 10)),
+					sdk.NewAttribute(AttributeKeyChannelID, strconv.FormatUint(uint64(change.ChannelId), 10)),
+					sdk.NewAttribute(AttributeKeyResumedBy, "gov"),
+				))
+			}
 			_, err := k.SaveChannelSettingChangeToIbc(ctx, id, change.ChannelId, change.Permission)
 			if err != nil {
 				ctx.Logger().With("module", "side_chain").Error("failed to write cross chain channel permission change message ",
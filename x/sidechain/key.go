@@ -20,6 +20,9 @@ var (
 	PrefixForReceiveSequenceKey = []byte{0xf1}
 
 	PrefixForChannelPermissionKey = []byte{0xc0}
+	PrefixForChannelRateLimitKey  = []byte{0xc1}
+	PrefixForChannelUsageKey      = []byte{0xc2}
+	PrefixForChannelPausedKey     = []byte{0xc3}
 )
 
 func GetSideChainStorePrefixKey(sideChainId string) []byte {
@@ -36,9 +39,25 @@ func buildChannelSequenceKey(destChainID sdk.ChainID, channelID sdk.ChannelID, p
 }
 
 func buildChannelPermissionKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	return buildChannelKey(destChainID, channelID, PrefixForChannelPermissionKey)
+}
+
+func buildChannelRateLimitKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	return buildChannelKey(destChainID, channelID, PrefixForChannelRateLimitKey)
+}
+
+func buildChannelUsageKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	return buildChannelKey(destChainID, channelID, PrefixForChannelUsageKey)
+}
+
+func buildChannelPausedKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	return buildChannelKey(destChainID, channelID, PrefixForChannelPausedKey)
+}
+
+func buildChannelKey(destChainID sdk.ChainID, channelID sdk.ChannelID, prefix []byte) []byte {
 	key := make([]byte, prefixLength+destChainIDLength+channelIDLength)
 
-	copy(key[:prefixLength], PrefixForChannelPermissionKey)
+	copy(key[:prefixLength], prefix)
 	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
 	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
 	return key
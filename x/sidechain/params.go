@@ -1,31 +1,59 @@
 package sidechain
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	pTypes "github.com/cosmos/cosmos-sdk/x/paramHub/types"
 )
 
 // Default parameter namespace
 const DefaultParamspace = "sidechain"
 
 var (
-	KeyBscSideChainId = []byte("BscSideChainId")
+	KeyBscSideChainId  = []byte("BscSideChainId")
+	KeyChannelGuardian = []byte("ChannelGuardian")
 )
 
-// ParamTypeTable for sidechain module
+// ParamTypeTable for sidechain module, the params added by upgrades are registered
+// whether their upgrade is active or not
 func ParamTypeTable() params.TypeTable {
-	return params.NewTypeTable().RegisterParamSet(&Params{})
+	return params.NewTypeTable(
+		KeyBscSideChainId, "",
+		KeyChannelGuardian, sdk.AccAddress{},
+	)
 }
 
+var _ pTypes.BCParam = (*Params)(nil)
+
 type Params struct {
 	BscSideChainId string `json:"bsc_side_chain_id"`
+	// ChannelGuardian is allowed to resume the channels paused by the circuit breaker,
+	// added in ChannelRateLimit
+	ChannelGuardian sdk.AccAddress `json:"channel_guardian"`
 }
 
 // Implements params.ParamStruct
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
-	return params.KeyValuePairs{
+	pairs := params.KeyValuePairs{
 		{KeyBscSideChainId, &p.BscSideChainId},
 	}
+	if sdk.IsUpgrade(sdk.ChannelRateLimit) {
+		pairs = append(pairs, params.KeyValuePairs{{KeyChannelGuardian, &p.ChannelGuardian}}...)
+	}
+	return pairs
+}
+
+func (p *Params) GetBCParamAttribute() string {
+	return "sidechain"
+}
+
+func (p *Params) UpdateCheck() error {
+	if len(p.ChannelGuardian) != 0 && len(p.ChannelGuardian) != sdk.AddrLen {
+		return fmt.Errorf("the channel_guardian should be empty or a valid address")
+	}
+	return nil
 }
 
 // Default parameters used by Cosmos Hub
@@ -40,7 +68,49 @@ func (k Keeper) BscSideChainId(ctx sdk.Context) (sideChainId string) {
 	return
 }
 
+// ChannelGuardian returns the guardian of the circuit breaker, it is empty if no guardian is set
+func (k Keeper) ChannelGuardian(ctx sdk.Context) (guardian sdk.AccAddress) {
+	k.paramspace.GetIfExists(ctx, KeyChannelGuardian, &guardian)
+	return
+}
+
+func (k Keeper) SetChannelGuardian(ctx sdk.Context, guardian sdk.AccAddress) {
+	k.paramspace.Set(ctx, KeyChannelGuardian, guardian)
+}
+
+// get the params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return
+}
+
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params Params) {
 	k.paramspace.SetParamSet(ctx, &params)
 }
+
+// SubscribeBCParamChange lets governance set the channel guardian through the
+// beacon chain param change proposals, once the ChannelRateLimit upgrade is active
+func (k *Keeper) SubscribeBCParamChange(hub pTypes.BCParamChangePublisher) {
+	hub.SubscribeBCParamChange(
+		func(context sdk.Context, iChange interface{}) {
+			switch change := iChange.(type) {
+			case *Params:
+				if !sdk.IsUpgrade(sdk.ChannelRateLimit) {
+					break
+				}
+				if err := change.UpdateCheck(); err != nil {
+					context.Logger().Error("[bc] skip invalid param change", "err", err, "param", change)
+					break
+				}
+				// the side chain id of BSC can't be changed by governance
+				k.SetChannelGuardian(context, change.ChannelGuardian)
+			default:
+				context.Logger().Debug("[bc] skip unknown bc param change")
+			}
+		},
+		&pTypes.BCParamSpaceProto{ParamSpace: k.paramspace, Proto: func() pTypes.BCParam {
+			return new(Params)
+		}},
+	)
+}
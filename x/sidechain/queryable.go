@@ -2,12 +2,17 @@ package sidechain
 
 import (
 	"encoding/json"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 const (
 	QuerychannelSettings = "channelSettings"
+	QueryChannelStatus   = "channelStatus"
+	QueryDecodePackage   = "decodePackage"
 )
 
 // creates a querier for staking REST endpoints
@@ -24,6 +29,23 @@ func NewQuerier(k Keeper) sdk.Querier {
 				return nil, ErrInvalidSideChainId(DefaultCodespace, "SideChainId is missing")
 			}
 			return queryChannelSettings(ctx, k, sideChainId)
+		case QueryChannelStatus:
+			var sideChainId string
+			err := k.cdc.UnmarshalJSON(req.Data, &sideChainId)
+			if err != nil {
+				return nil, ErrInvalidSideChainId(DefaultCodespace, err.Error())
+			}
+			if len(sideChainId) == 0 {
+				return nil, ErrInvalidSideChainId(DefaultCodespace, "SideChainId is missing")
+			}
+			return queryChannelStatus(ctx, k, sideChainId)
+		case QueryDecodePackage:
+			var params types.QueryDecodePackageParams
+			err := k.cdc.UnmarshalJSON(req.Data, &params)
+			if err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryDecodePackage(k, params)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown side chain query endpoint")
 		}
@@ -44,3 +66,41 @@ func queryChannelSettings(ctx sdk.Context, k Keeper, sideChainId string) ([]byte
 
 	return res, nil
 }
+
+func queryChannelStatus(ctx sdk.Context, k Keeper, sideChainId string) ([]byte, sdk.Error) {
+	id, err := k.GetDestChainID(sideChainId)
+	if err != nil {
+		return nil, ErrInvalidSideChainId(DefaultCodespace, err.Error())
+	}
+	channelIds := make([]sdk.ChannelID, 0, len(k.cfg.channelIDToName))
+	for channelId := range k.cfg.channelIDToName {
+		channelIds = append(channelIds, channelId)
+	}
+	sort.Slice(channelIds, func(i, j int) bool { return channelIds[i] < channelIds[j] })
+
+	statuses := make([]types.ChannelStatus, 0, len(channelIds))
+	for _, channelId := range channelIds {
+		statuses = append(statuses, k.GetChannelStatus(ctx, id, channelId))
+	}
+
+	res, resErr := json.Marshal(statuses)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
+
+func queryDecodePackage(k Keeper, params types.QueryDecodePackageParams) ([]byte, sdk.Error) {
+	decoded, err := k.DecodePackage(params.ChannelId, params.Package)
+	if err != nil {
+		return nil, ErrInvalidPackage(DefaultCodespace, err.Error())
+	}
+
+	res, resErr := json.Marshal(decoded)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,162 @@
+package sidechain
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const (
+	EventTypeChannelPaused  = "ChannelPaused"
+	EventTypeChannelResumed = "ChannelResumed"
+
+	AttributeKeyDestChainID = "DestChainID"
+	AttributeKeyChannelID   = "ChannelID"
+	AttributeKeyReason      = "Reason"
+	AttributeKeyResumedBy   = "ResumedBy"
+)
+
+func (k *Keeper) SetChannelRateLimit(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, limit types.ChannelRateLimit) {
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Set(buildChannelRateLimitKey(destChainID, channelID), k.cdc.MustMarshalBinaryLengthPrefixed(limit))
+}
+
+func (k *Keeper) GetChannelRateLimit(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (limit types.ChannelRateLimit) {
+	kvStore := ctx.KVStore(k.storeKey)
+	bz := kvStore.Get(buildChannelRateLimitKey(destChainID, channelID))
+	if bz == nil {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &limit)
+	return
+}
+
+func (k *Keeper) GetChannelUsage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (usage types.ChannelUsage) {
+	kvStore := ctx.KVStore(k.storeKey)
+	bz := kvStore.Get(buildChannelUsageKey(destChainID, channelID))
+	if bz == nil {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &usage)
+	return
+}
+
+func (k *Keeper) setChannelUsage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, usage types.ChannelUsage) {
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Set(buildChannelUsageKey(destChainID, channelID), k.cdc.MustMarshalBinaryLengthPrefixed(usage))
+}
+
+func (k *Keeper) IsChannelPaused(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) bool {
+	kvStore := ctx.KVStore(k.storeKey)
+	return kvStore.Has(buildChannelPausedKey(destChainID, channelID))
+}
+
+func (k *Keeper) GetChannelPause(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (pause types.ChannelPause, found bool) {
+	kvStore := ctx.KVStore(k.storeKey)
+	bz := kvStore.Get(buildChannelPausedKey(destChainID, channelID))
+	if bz == nil {
+		return pause, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &pause)
+	return pause, true
+}
+
+// PauseChannel trips the circuit breaker of a channel, the pause is kept in the store until
+// the channel is resumed by governance or the guardian.
+func (k *Keeper) PauseChannel(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, reason string) {
+	kvStore := ctx.KVStore(k.storeKey)
+	pause := types.ChannelPause{
+		Height: ctx.BlockHeight(),
+		Reason: reason,
+	}
+	kvStore.Set(buildChannelPausedKey(destChainID, channelID), k.cdc.MustMarshalBinaryLengthPrefixed(pause))
+
+	ctx.Logger().With("module", "side_chain").Info("channel paused by circuit breaker",
+		"destChainID", destChainID, "channelID", channelID, "reason", reason)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeChannelPaused,
+		sdk.NewAttribute(AttributeKeyDestChainID, strconv.FormatUint(uint64(destChainID), 10)),
+		sdk.NewAttribute(AttributeKeyChannelID, strconv.FormatUint(uint64(channelID), 10)),
+		sdk.NewAttribute(AttributeKeyReason, reason),
+	))
+}
+
+// ResumeChannel lifts the pause of a channel and resets its usage, so that the traffic held back
+// by the circuit breaker does not trip it again right away.
+func (k *Keeper) ResumeChannel(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) {
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Delete(buildChannelPausedKey(destChainID, channelID))
+	kvStore.Delete(buildChannelUsageKey(destChainID, channelID))
+}
+
+// GetPackageValue asks the application of the channel for the value carried by a package,
+// the value is 0 if the application does not implement sdk.CrossChainPackageValuer.
+func (k *Keeper) GetPackageValue(channelID sdk.ChannelID, packageType sdk.CrossChainPackageType, payload []byte) int64 {
+	valuer, ok := k.cfg.channelIDToApp[channelID].(sdk.CrossChainPackageValuer)
+	if !ok {
+		return 0
+	}
+	return valuer.PackageValue(packageType, payload)
+}
+
+// ConsumeChannelQuota accounts the value of a syn package against the rate limit of the channel.
+// Once a cap is exceeded, the channel is paused in the store of ctx and stays paused until it is
+// resumed by governance or the guardian. The pause is discarded together with ctx if ctx is not
+// committed, so the callers which need it to stick must commit ctx when the quota is exceeded.
+func (k *Keeper) ConsumeChannelQuota(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, value int64) sdk.Error {
+	if !sdk.IsUpgrade(sdk.ChannelRateLimit) {
+		return nil
+	}
+	if k.IsChannelPaused(ctx, destChainID, channelID) {
+		return ErrChannelPaused(DefaultCodespace, fmt.Sprintf("channel %d of chain %d is paused", channelID, destChainID))
+	}
+	limit := k.GetChannelRateLimit(ctx, destChainID, channelID)
+	if limit.IsUnlimited() || value <= 0 {
+		return nil
+	}
+
+	height := ctx.BlockHeight()
+	usage := k.GetChannelUsage(ctx, destChainID, channelID)
+	if usage.Height != height {
+		usage.Height = height
+		usage.BlockUsed = 0
+	}
+	if limit.WindowBlocks > 0 {
+		windowStart := height - height%limit.WindowBlocks
+		if usage.WindowStart != windowStart {
+			usage.WindowStart = windowStart
+			usage.WindowUsed = 0
+		}
+	}
+
+	var reason string
+	if limit.PerBlockCap > 0 && usage.BlockUsed+value > limit.PerBlockCap {
+		reason = fmt.Sprintf("per block cap %d exceeded, used %d, value %d", limit.PerBlockCap, usage.BlockUsed, value)
+	} else if limit.WindowCap > 0 && usage.WindowUsed+value > limit.WindowCap {
+		reason = fmt.Sprintf("window cap %d exceeded, used %d, value %d", limit.WindowCap, usage.WindowUsed, value)
+	}
+	if reason != "" {
+		k.PauseChannel(ctx, destChainID, channelID, reason)
+		return ErrRateLimitExceeded(DefaultCodespace, fmt.Sprintf("channel %d of chain %d: %s", channelID, destChainID, reason))
+	}
+
+	usage.BlockUsed += value
+	usage.WindowUsed += value
+	k.setChannelUsage(ctx, destChainID, channelID, usage)
+	return nil
+}
+
+func (k *Keeper) GetChannelStatus(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) types.ChannelStatus {
+	status := types.ChannelStatus{
+		ChannelId:  channelID,
+		Permission: k.GetChannelSendPermission(ctx, destChainID, channelID),
+		RateLimit:  k.GetChannelRateLimit(ctx, destChainID, channelID),
+		Usage:      k.GetChannelUsage(ctx, destChainID, channelID),
+	}
+	if pause, found := k.GetChannelPause(ctx, destChainID, channelID); found {
+		status.Paused = true
+		status.Pause = &pause
+	}
+	return status
+}
@@ -0,0 +1,134 @@
+package sidechain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	pTypes "github.com/cosmos/cosmos-sdk/x/paramHub/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+type valuedApp struct{}
+
+func (valuedApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (valuedApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (valuedApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (valuedApp) PackageValue(packageType sdk.CrossChainPackageType, payload []byte) int64 {
+	return int64(payload[0])
+}
+
+func TestChannelRateLimit(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	defer sdk.UpgradeMgr.Reset()
+	destChainID := sdk.ChainID(1)
+	channelID := sdk.ChannelID(2)
+	require.NoError(t, keeper.RegisterDestChain("bsc", destChainID))
+	require.NoError(t, keeper.RegisterChannel("transfer", channelID, valuedApp{}))
+	require.Equal(t, int64(7), keeper.GetPackageValue(channelID, sdk.SynCrossChainPackageType, []byte{7}))
+
+	// not limited before the upgrade
+	keeper.SetChannelRateLimit(ctx, destChainID, channelID, types.ChannelRateLimit{PerBlockCap: 1})
+	require.NoError(t, keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 1000))
+	require.Equal(t, int64(0), keeper.GetChannelUsage(ctx, destChainID, channelID).BlockUsed)
+	guardian := sdk.AccAddress([]byte("guardian-address----"))
+	keeper.SetChannelGuardian(ctx, guardian)
+	handler := NewHandler(keeper)
+	res := handler(ctx, types.NewMsgResumeChannel(guardian, "bsc", channelID))
+	require.Equal(t, sdk.ErrMsgNotSupported("").ABCICode(), res.Code)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ChannelRateLimit, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	keeper.SetChannelRateLimit(ctx, destChainID, channelID, types.ChannelRateLimit{})
+
+	// no limit configured
+	require.NoError(t, keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 1000))
+
+	keeper.SetChannelRateLimit(ctx, destChainID, channelID, types.ChannelRateLimit{PerBlockCap: 100, WindowCap: 150, WindowBlocks: 10})
+	ctx = ctx.WithBlockHeight(10)
+	require.NoError(t, keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 60))
+	require.NoError(t, keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 40))
+	err := keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 1)
+	require.Error(t, err)
+	require.Equal(t, CodeRateLimitExceeded, err.Code())
+	// the failed package is not accounted
+	require.Equal(t, int64(100), keeper.GetChannelUsage(ctx, destChainID, channelID).BlockUsed)
+
+	// the pause is kept in the store
+	pause, found := keeper.GetChannelPause(ctx, destChainID, channelID)
+	require.True(t, found)
+	require.Equal(t, int64(10), pause.Height)
+	require.Contains(t, pause.Reason, "per block cap 100 exceeded")
+	require.True(t, keeper.GetChannelStatus(ctx, destChainID, channelID).Paused)
+	require.Len(t, ctx.EventManager().Events(), 1)
+	require.Equal(t, EventTypeChannelPaused, ctx.EventManager().Events()[0].Type)
+
+	ctx = ctx.WithBlockHeight(11)
+	err = keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 1)
+	require.Error(t, err)
+	require.Equal(t, CodeChannelPaused, err.Code())
+
+	// only the guardian can resume
+	require.Equal(t, guardian, keeper.ChannelGuardian(ctx))
+	res = handler(ctx, types.NewMsgResumeChannel(sdk.AccAddress([]byte("someone-else--------")), "bsc", channelID))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnauthorizedGuardian), res.Code)
+	res = handler(ctx, types.NewMsgResumeChannel(guardian, "bsc", channelID))
+	require.True(t, res.IsOK(), res.Log)
+	require.False(t, keeper.IsChannelPaused(ctx, destChainID, channelID))
+
+	// window cap across blocks
+	require.NoError(t, keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 100))
+	ctx = ctx.WithBlockHeight(12)
+	require.NoError(t, keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 50))
+	ctx = ctx.WithBlockHeight(13)
+	err = keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 1)
+	require.Error(t, err)
+	require.Equal(t, CodeRateLimitExceeded, err.Code())
+
+	// a new window starts
+	ctx = ctx.WithBlockHeight(20)
+	keeper.ResumeChannel(ctx, destChainID, channelID)
+	require.NoError(t, keeper.ConsumeChannelQuota(ctx, destChainID, channelID, 100))
+}
+
+type testBCParamHub struct {
+	updateCb func(sdk.Context, interface{})
+}
+
+func (hub *testBCParamHub) SubscribeBCParamChange(updateCb func(sdk.Context, interface{}), spaceProto *pTypes.BCParamSpaceProto) {
+	hub.updateCb = updateCb
+}
+
+func TestChannelGuardianParam(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	defer sdk.UpgradeMgr.Reset()
+	hub := &testBCParamHub{}
+	keeper.SubscribeBCParamChange(hub)
+	guardian := sdk.AccAddress([]byte("guardian-address----"))
+
+	// the guardian is neither written nor changed by governance before the upgrade
+	keeper.SetParams(ctx, Params{BscSideChainId: "bsc", ChannelGuardian: guardian})
+	require.Empty(t, keeper.ChannelGuardian(ctx))
+	hub.updateCb(ctx, &Params{BscSideChainId: "bsc", ChannelGuardian: guardian})
+	require.Empty(t, keeper.ChannelGuardian(ctx))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ChannelRateLimit, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	hub.updateCb(ctx, &Params{BscSideChainId: "other", ChannelGuardian: guardian})
+	require.Equal(t, guardian, keeper.ChannelGuardian(ctx))
+	require.Equal(t, Params{BscSideChainId: "bsc", ChannelGuardian: guardian}, keeper.GetParams(ctx))
+
+	// an invalid guardian is skipped
+	hub.updateCb(ctx, &Params{BscSideChainId: "bsc", ChannelGuardian: sdk.AccAddress{1}})
+	require.Equal(t, guardian, keeper.ChannelGuardian(ctx))
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	RouteSideChain = "sideChain"
+
+	ResumeChannelMsgType = "resumeChannel"
+)
+
+var _ sdk.Msg = MsgResumeChannel{}
+
+// MsgResumeChannel lets the guardian resume a channel paused by the circuit breaker
+type MsgResumeChannel struct {
+	Guardian    sdk.AccAddress `json:"guardian"`
+	SideChainId string         `json:"side_chain_id"`
+	ChannelId   sdk.ChannelID  `json:"channel_id"`
+}
+
+func NewMsgResumeChannel(guardian sdk.AccAddress, sideChainId string, channelId sdk.ChannelID) MsgResumeChannel {
+	return MsgResumeChannel{
+		Guardian:    guardian,
+		SideChainId: sideChainId,
+		ChannelId:   channelId,
+	}
+}
+
+// nolint
+func (msg MsgResumeChannel) Route() string { return RouteSideChain }
+func (msg MsgResumeChannel) Type() string  { return ResumeChannelMsgType }
+func (msg MsgResumeChannel) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Guardian}
+}
+
+func (msg MsgResumeChannel) String() string {
+	return fmt.Sprintf("ResumeChannel{%v#%s#%d}", msg.Guardian, msg.SideChainId, msg.ChannelId)
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgResumeChannel) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgResumeChannel) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgResumeChannel) ValidateBasic() sdk.Error {
+	if len(msg.Guardian) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.Guardian.String())
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > MaxSideChainIdLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("invalid side chain id %s", msg.SideChainId))
+	}
+	return nil
+}
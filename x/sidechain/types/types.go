@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 
@@ -18,6 +19,9 @@ const (
 	CrossChainFeeLength = 32
 	PackageTypeLength   = 1
 	PackageHeaderLength = CrossChainFeeLength + PackageTypeLength
+
+	// PackageTimeoutHeaderLength is the length of the sequence, timeout height and timeout time
+	PackageTimeoutHeaderLength = 24
 )
 
 func EncodePackageHeader(packageType sdk.CrossChainPackageType, relayerFee big.Int) []byte {
@@ -38,6 +42,28 @@ func DecodePackageHeader(packageHeader []byte) (packageType sdk.CrossChainPackag
 	return
 }
 
+// EncodePackageTimeoutHeader encodes the header following the package header in the packages of the timeout package
+// types. A zero timeout height or time disables that part of the timeout. The side chain refuses the packages whose
+// timeout is reached, and starts the ack and fail ack packages answering them with the same header.
+func EncodePackageTimeoutHeader(sequence uint64, timeoutHeight, timeoutTime int64) []byte {
+	header := make([]byte, PackageTimeoutHeaderLength)
+	binary.BigEndian.PutUint64(header[:8], sequence)
+	binary.BigEndian.PutUint64(header[8:16], uint64(timeoutHeight))
+	binary.BigEndian.PutUint64(header[16:], uint64(timeoutTime))
+	return header
+}
+
+func DecodePackageTimeoutHeader(header []byte) (sequence uint64, timeoutHeight, timeoutTime int64, err error) {
+	if len(header) < PackageTimeoutHeaderLength {
+		err = fmt.Errorf("length of package timeout header is less than %d", PackageTimeoutHeaderLength)
+		return
+	}
+	sequence = binary.BigEndian.Uint64(header[:8])
+	timeoutHeight = int64(binary.BigEndian.Uint64(header[8:16]))
+	timeoutTime = int64(binary.BigEndian.Uint64(header[16:PackageTimeoutHeaderLength]))
+	return
+}
+
 type CommonAckPackage struct {
 	Code uint32
 }
@@ -54,6 +80,8 @@ type ChanPermissionSetting struct {
 	SideChainId string                `json:"side_chain_id"`
 	ChannelId   sdk.ChannelID         `json:"channel_id"`
 	Permission  sdk.ChannelPermission `json:"permission"`
+	// optional, replaces the rate limit of the channel if present
+	RateLimit *ChannelRateLimit `json:"rate_limit,omitempty"`
 }
 
 func (c *ChanPermissionSetting) Check() error {
@@ -66,5 +94,90 @@ func (c *ChanPermissionSetting) Check() error {
 	if c.Permission != sdk.ChannelAllow && c.Permission != sdk.ChannelForbidden {
 		return fmt.Errorf("permission %d is invalid", c.Permission)
 	}
+	if c.RateLimit != nil {
+		return c.RateLimit.Check()
+	}
+	return nil
+}
+
+// ChannelRateLimit caps the value of packages that go through a channel, a zero cap means unlimited.
+type ChannelRateLimit struct {
+	PerBlockCap  int64 `json:"per_block_cap"`
+	WindowCap    int64 `json:"window_cap"`
+	WindowBlocks int64 `json:"window_blocks"`
+}
+
+func (l ChannelRateLimit) Check() error {
+	if l.PerBlockCap < 0 {
+		return fmt.Errorf("per block cap should not be negative")
+	}
+	if l.WindowCap < 0 {
+		return fmt.Errorf("window cap should not be negative")
+	}
+	if l.WindowCap > 0 && l.WindowBlocks <= 0 {
+		return fmt.Errorf("window blocks should be positive when window cap is set")
+	}
 	return nil
 }
+
+func (l ChannelRateLimit) IsUnlimited() bool {
+	return l.PerBlockCap == 0 && l.WindowCap == 0
+}
+
+// ChannelUsage is the value of packages that went through a channel in the current block and window
+type ChannelUsage struct {
+	Height      int64 `json:"height"`
+	BlockUsed   int64 `json:"block_used"`
+	WindowStart int64 `json:"window_start"`
+	WindowUsed  int64 `json:"window_used"`
+}
+
+// ChannelPause records when and why the circuit breaker paused a channel
+type ChannelPause struct {
+	Height int64  `json:"height"`
+	Reason string `json:"reason"`
+}
+
+// ChannelStatus is the rate limit status of a channel
+type ChannelStatus struct {
+	ChannelId  sdk.ChannelID         `json:"channel_id"`
+	Permission sdk.ChannelPermission `json:"permission"`
+	Paused     bool                  `json:"paused"`
+	Pause      *ChannelPause         `json:"pause,omitempty"`
+	RateLimit  ChannelRateLimit      `json:"rate_limit"`
+	Usage      ChannelUsage          `json:"usage"`
+}
+
+func PackageTypeName(packageType sdk.CrossChainPackageType) string {
+	switch packageType {
+	case sdk.SynCrossChainPackageType:
+		return "syn"
+	case sdk.AckCrossChainPackageType:
+		return "ack"
+	case sdk.FailAckCrossChainPackageType:
+		return "fail_ack"
+	case sdk.TimeoutSynCrossChainPackageType:
+		return "timeout_syn"
+	case sdk.TimeoutAckCrossChainPackageType:
+		return "timeout_ack"
+	case sdk.TimeoutFailAckCrossChainPackageType:
+		return "timeout_fail_ack"
+	default:
+		return fmt.Sprintf("unknown(%d)", packageType)
+	}
+}
+
+// DecodedPackage is the human-readable form of a raw package
+type DecodedPackage struct {
+	ChannelId   sdk.ChannelID `json:"channel_id"`
+	ChannelName string        `json:"channel_name"`
+	PackageType string        `json:"package_type"`
+	RelayFee    string        `json:"relay_fee"`
+	Package     interface{}   `json:"package"`
+}
+
+type QueryDecodePackageParams struct {
+	ChannelId sdk.ChannelID `json:"channel_id"`
+	// Package is the raw package, including the package header
+	Package []byte `json:"package"`
+}
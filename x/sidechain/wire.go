@@ -0,0 +1,12 @@
+package sidechain
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// Register concrete types on codec codec
+func RegisterWire(cdc *codec.Codec) {
+	cdc.RegisterConcrete(types.MsgResumeChannel{}, "sidechain/MsgResumeChannel", nil)
+	cdc.RegisterConcrete(&Params{}, "params/SideChainParamSet", nil)
+}
@@ -19,6 +19,7 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 		client.PostCommands(
 			GetCmdUnjail(cdc),
 			GetCmdBscSubmitEvidence(cdc),
+			GetCmdSideChainSubmitEvidence(cdc),
 			GetCmdSideChainUnjail(cdc),
 		)...)
 
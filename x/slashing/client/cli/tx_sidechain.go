@@ -45,38 +45,60 @@ func GetCmdBscSubmitEvidence(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			filePath := viper.GetString(flagEvidenceFile)
-			evidenceBytes := make([]byte, 0)
-			if filePath != "" {
-				evidenceBytes, err = os.ReadFile(filePath)
-				if err != nil {
-					return err
-				}
-			} else {
-				txStr := viper.GetString(flagEvidence)
-				if txStr == "" {
-					return errors.New(fmt.Sprintf("either %s or %s is required", flagEvidenceFile, flagEvidence))
-				}
-				evidenceBytes = []byte(txStr)
+			headers, err := readEvidenceHeaders()
+			if err != nil {
+				return err
+			}
+
+			msg := slashing.NewMsgBscSubmitEvidence(from, headers)
+
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagEvidence, "", "Evidence details, including two headers with json format, e.g. [{\"difficulty\":\"0x2\",\"extraData\":\"0xd98301...},{\"difficulty\":\"0x3\",\"extraData\":\"0xd64372...}]")
+	cmd.Flags().String(flagEvidenceFile, "", "File of evidence details, if evidence-file is not empty, --evidence will be ignored")
+	return cmd
+}
+
+// GetCmdSideChainSubmitEvidence implements the submit double sign evidence command for any side chain
+func GetCmdSideChainSubmitEvidence(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "side-submit-evidence",
+		Args:  cobra.NoArgs,
+		Short: "submit evidence against the malicious validator on a side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
 			}
 
-			headers := make([]bsc.Header, 0)
-			err = json.Unmarshal(evidenceBytes, &headers)
+			from, err := cliCtx.GetFromAddress()
 			if err != nil {
 				return err
 			}
 
-			if len(headers) != 2 {
-				return errors.New(fmt.Sprintf("must have 2 headers exactly"))
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
 			}
 
-			msg := slashing.NewMsgBscSubmitEvidence(from, headers)
+			headers, err := readEvidenceHeaders()
+			if err != nil {
+				return err
+			}
+
+			msg := slashing.NewMsgSideChainSubmitEvidence(from, sideChainId, headers)
 
 			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
 		},
 	}
 	cmd.Flags().String(flagEvidence, "", "Evidence details, including two headers with json format, e.g. [{\"difficulty\":\"0x2\",\"extraData\":\"0xd98301...},{\"difficulty\":\"0x3\",\"extraData\":\"0xd64372...}]")
 	cmd.Flags().String(flagEvidenceFile, "", "File of evidence details, if evidence-file is not empty, --evidence will be ignored")
+	cmd.Flags().String(FlagSideChainId, "", "chain-id of the side chain the validator belongs to")
 	return cmd
 }
 
@@ -118,3 +140,29 @@ func getSideChainId() (sideChainId string, err error) {
 	}
 	return
 }
+
+func readEvidenceHeaders() ([]bsc.Header, error) {
+	var evidenceBytes []byte
+	if filePath := viper.GetString(flagEvidenceFile); filePath != "" {
+		bz, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		evidenceBytes = bz
+	} else {
+		txStr := viper.GetString(flagEvidence)
+		if txStr == "" {
+			return nil, errors.New(fmt.Sprintf("either %s or %s is required", flagEvidenceFile, flagEvidence))
+		}
+		evidenceBytes = []byte(txStr)
+	}
+
+	headers := make([]bsc.Header, 0)
+	if err := json.Unmarshal(evidenceBytes, &headers); err != nil {
+		return nil, err
+	}
+	if len(headers) != 2 {
+		return nil, errors.New(fmt.Sprintf("must have 2 headers exactly"))
+	}
+	return headers, nil
+}
@@ -78,6 +78,8 @@ type EvidenceSubmitReq struct {
 	BaseReq   utils.BaseReq `json:"base_req"`
 	Submitter string        `json:"submitter"` // in bech 32
 	Headers   []bsc.Header  `json:"headers"`
+	// SideChainId is optional, evidence is submitted against bsc if it is empty
+	SideChainId string `json:"side_chain_id,omitempty"`
 }
 
 func bscEvidenceSubmitRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.CLIContext) func(http.ResponseWriter, *http.Request) {
@@ -123,7 +125,12 @@ func bscEvidenceSubmitRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx
 			return
 		}
 
-		msg := slashing.NewMsgBscSubmitEvidence(submitter, req.Headers)
+		var msg sdk.Msg
+		if req.SideChainId != "" {
+			msg = slashing.NewMsgSideChainSubmitEvidence(submitter, req.SideChainId, req.Headers)
+		} else {
+			msg = slashing.NewMsgBscSubmitEvidence(submitter, req.Headers)
+		}
 
 		txBldr := authtxb.TxBuilder{
 			Codec:   cdc,
@@ -9,6 +9,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgUnjail{}, "cosmos-sdk/MsgUnjail", nil)
 	cdc.RegisterConcrete(MsgSideChainUnjail{}, "cosmos-sdk/MsgSideChainUnjail", nil)
 	cdc.RegisterConcrete(MsgBscSubmitEvidence{}, "cosmos-sdk/MsgBscSubmitEvidence", nil)
+	cdc.RegisterConcrete(MsgSideChainSubmitEvidence{}, "cosmos-sdk/MsgSideChainSubmitEvidence", nil)
 	cdc.RegisterConcrete(&Params{}, "params/SlashParamSet", nil)
 }
 
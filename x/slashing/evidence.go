@@ -0,0 +1,61 @@
+package slashing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SideChainHeaderVerifier extracts the consensus address of the signer of a side chain block header,
+// every side chain that accepts double sign evidence registers one with Keeper.RegisterHeaderVerifier.
+type SideChainHeaderVerifier interface {
+	ExtractSigner(ctx sdk.Context, header *bsc.Header) ([]byte, error)
+}
+
+// BscHeaderVerifier verifies headers of parlia based side chains like bsc
+type BscHeaderVerifier struct {
+	ChainID *big.Int
+}
+
+var _ SideChainHeaderVerifier = BscHeaderVerifier{}
+
+func NewBscHeaderVerifier(chainID *big.Int) BscHeaderVerifier {
+	return BscHeaderVerifier{ChainID: chainID}
+}
+
+func (v BscHeaderVerifier) ExtractSigner(ctx sdk.Context, header *bsc.Header) ([]byte, error) {
+	var signer bsc.Address
+	var err error
+	if sdk.IsUpgrade(sdk.FixDoubleSignChainId) {
+		signer, err = header.ExtractSignerFromHeader(v.ChainID)
+	} else {
+		signer, err = header.ExtractSignerFromHeader(nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return signer.Bytes(), nil
+}
+
+// RegisterHeaderVerifier sets the header verifier of a side chain, side chains of bsc, chapel and rialto
+// fall back to BscHeaderVerifier if none is registered.
+func (k *Keeper) RegisterHeaderVerifier(sideChainId string, verifier SideChainHeaderVerifier) error {
+	if _, ok := k.headerVerifiers[sideChainId]; ok {
+		return fmt.Errorf("header verifier of side chain %s is already registered", sideChainId)
+	}
+	k.headerVerifiers[sideChainId] = verifier
+	return nil
+}
+
+func (k Keeper) getHeaderVerifier(sideChainId string) (SideChainHeaderVerifier, error) {
+	if verifier, ok := k.headerVerifiers[sideChainId]; ok {
+		return verifier, nil
+	}
+	chainID, err := SideChainIdFromText(sideChainId)
+	if err != nil {
+		return nil, err
+	}
+	return NewBscHeaderVerifier(chainID), nil
+}
@@ -11,48 +11,49 @@ import (
 )
 
 func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Keeper) sdk.Result {
-	sideChainId := k.ScKeeper.BscSideChainId(ctx)
+	return handleDoubleSignEvidence(ctx, k, msg.Submitter, k.ScKeeper.BscSideChainId(ctx), msg.Headers)
+}
+
+func handleMsgSideChainSubmitEvidence(ctx sdk.Context, msg MsgSideChainSubmitEvidence, k Keeper) sdk.Result {
+	if !sdk.IsUpgrade(sdk.SideChainEvidence) {
+		return sdk.ErrMsgNotSupported("side chain evidence is not activated yet").Result()
+	}
+	return handleDoubleSignEvidence(ctx, k, msg.Submitter, msg.SideChainId, msg.Headers)
+}
+
+func handleDoubleSignEvidence(ctx sdk.Context, k Keeper, submitter sdk.AccAddress, sideChainId string, headers []bsc.Header) sdk.Result {
 	sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
 	if err != nil {
 		return ErrInvalidSideChainId(DefaultCodespace).Result()
 	}
 
-	chainID, err := SideChainIdFromText(sideChainId)
+	verifier, err := k.getHeaderVerifier(sideChainId)
 	if err != nil {
 		return ErrInvalidEvidence(DefaultCodespace, err.Error()).Result()
 	}
 
 	header := ctx.BlockHeader()
 
-	var sideConsAddr bsc.Address
-	var sideConsAddr2 bsc.Address
-	var err2 error
-
-	if sdk.IsUpgrade(sdk.FixDoubleSignChainId) {
-		sideConsAddr, err = msg.Headers[0].ExtractSignerFromHeader(chainID)
-		sideConsAddr2, err2 = msg.Headers[1].ExtractSignerFromHeader(chainID)
-	} else {
-		sideConsAddr, err = msg.Headers[0].ExtractSignerFromHeader(nil)
-		sideConsAddr2, err2 = msg.Headers[1].ExtractSignerFromHeader(nil)
-	}
+	sideConsAddr, err := verifier.ExtractSigner(ctx, &headers[0])
 	if err != nil {
 		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error())).Result()
 	}
-	if err2 != nil {
+	sideConsAddr2, err := verifier.ExtractSigner(ctx, &headers[1])
+	if err != nil {
 		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error())).Result()
 	}
-	if bytes.Compare(sideConsAddr.Bytes(), sideConsAddr2.Bytes()) != 0 {
+	if bytes.Compare(sideConsAddr, sideConsAddr2) != 0 {
 		return ErrInvalidEvidence(DefaultCodespace, "The signers of two block headers are not the same").Result()
 	}
 
-	if k.hasSlashRecord(sideCtx, sideConsAddr.Bytes(), DoubleSign, uint64(msg.Headers[0].Number)) {
+	if k.hasSlashRecord(sideCtx, sideConsAddr, DoubleSign, uint64(headers[0].Number)) {
 		return ErrEvidenceHasBeenHandled(k.Codespace).Result()
 	}
 
 	//verify evidence age
-	evidenceTime := msg.Headers[0].Time
-	if msg.Headers[0].Time < msg.Headers[1].Time {
-		evidenceTime = msg.Headers[1].Time
+	evidenceTime := headers[0].Time
+	if headers[0].Time < headers[1].Time {
+		evidenceTime = headers[1].Time
 	}
 	age := sideCtx.BlockHeader().Time.Sub(time.Unix(int64(evidenceTime), 0))
 	if age > k.MaxEvidenceAge(sideCtx) {
@@ -60,7 +61,7 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	}
 
 	slashAmount := k.DoubleSignSlashAmount(sideCtx)
-	validator, slashedAmount, slashErr := k.validatorSet.SlashSideChain(ctx, sideChainId, sideConsAddr.Bytes(), sdk.NewDec(slashAmount))
+	validator, slashedAmount, slashErr := k.validatorSet.SlashSideChain(ctx, sideChainId, sideConsAddr, sdk.NewDec(slashAmount))
 	if slashErr != nil {
 		return ErrFailedToSlash(k.Codespace, slashErr.Error()).Result()
 	}
@@ -71,8 +72,8 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	submitterRewardCoin := sdk.NewCoin(bondDenom, submitterRewardReal)
 
 	if submitterRewardReal > 0 {
-		submitterBalance := k.BankKeeper.GetCoins(ctx, msg.Submitter)
-		if err := k.BankKeeper.SetCoins(ctx, msg.Submitter, submitterBalance.Plus(sdk.Coins{submitterRewardCoin})); err != nil {
+		submitterBalance := k.BankKeeper.GetCoins(ctx, submitter)
+		if err := k.BankKeeper.SetCoins(ctx, submitter, submitterBalance.Plus(sdk.Coins{submitterRewardCoin})); err != nil {
 			return ErrFailedToSlash(k.Codespace, err.Error()).Result()
 		}
 	}
@@ -82,7 +83,7 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	var validatorsCompensation map[string]int64
 	var found bool
 	if remainingReward > 0 {
-		found, validatorsCompensation, err = k.validatorSet.AllocateSlashAmtToValidators(sideCtx, sideConsAddr.Bytes(), sdk.NewDec(remainingReward))
+		found, validatorsCompensation, err = k.validatorSet.AllocateSlashAmtToValidators(sideCtx, sideConsAddr, sdk.NewDec(remainingReward))
 		if err != nil {
 			return ErrFailedToSlash(k.Codespace, err.Error()).Result()
 		}
@@ -95,9 +96,9 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 
 	jailUntil := header.Time.Add(k.DoubleSignUnbondDuration(sideCtx))
 	sr := SlashRecord{
-		ConsAddr:         sideConsAddr.Bytes(),
+		ConsAddr:         sideConsAddr,
 		InfractionType:   DoubleSign,
-		InfractionHeight: uint64(msg.Headers[0].Number),
+		InfractionHeight: uint64(headers[0].Number),
 		SlashHeight:      header.Height,
 		JailUntil:        jailUntil,
 		SlashAmt:         slashedAmount.RawInt(),
@@ -106,24 +107,24 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	k.setSlashRecord(sideCtx, sr)
 
 	// Set or updated validator jail duration
-	signInfo, found := k.getValidatorSigningInfo(sideCtx, sideConsAddr.Bytes())
+	signInfo, found := k.getValidatorSigningInfo(sideCtx, sideConsAddr)
 	if !found {
-		panic(fmt.Sprintf("Expected signing info for validator %s but not found", sideConsAddr.Hex()))
+		panic(fmt.Sprintf("Expected signing info for validator %X but not found", sideConsAddr))
 	}
 	signInfo.JailedUntil = jailUntil
-	k.setValidatorSigningInfo(sideCtx, sideConsAddr.Bytes(), signInfo)
+	k.setValidatorSigningInfo(sideCtx, sideConsAddr, signInfo)
 
 	if ctx.IsDeliverTx() && k.PbsbServer != nil {
 		event := SideSlashEvent{
 			Validator:              validator.GetOperator(),
 			InfractionType:         DoubleSign,
-			InfractionHeight:       msg.Headers[0].Number,
+			InfractionHeight:       headers[0].Number,
 			SlashHeight:            header.Height,
 			JailUtil:               jailUntil,
 			SlashAmt:               slashedAmount.RawInt(),
 			SideChainId:            sideChainId,
 			ToFeePool:              toFeePool,
-			Submitter:              msg.Submitter,
+			Submitter:              submitter,
 			SubmitterReward:        submitterRewardReal,
 			ValidatorsCompensation: validatorsCompensation,
 		}
@@ -2,6 +2,7 @@ package slashing
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"testing"
 	"time"
@@ -190,3 +191,37 @@ func TestSideChainSlashDoubleSignUBD(t *testing.T) {
 	require.EqualValues(t, 4000e8, stakingPoolBalance)
 
 }
+
+type rejectingHeaderVerifier struct{}
+
+func (rejectingHeaderVerifier) ExtractSigner(ctx sdk.Context, header *bsc.Header) ([]byte, error) {
+	return nil, fmt.Errorf("rejected header %d", header.Number)
+}
+
+func TestSideChainSubmitEvidenceVerifier(t *testing.T) {
+	submitter := sdk.AccAddress(addrs[2])
+	ctx, _, _, _, _, keeper := createSideTestInput(t, DefaultParams())
+	headers := []bsc.Header{{Number: 1}, {Number: 1}}
+	defer sdk.UpgradeMgr.Reset()
+
+	// not activated yet
+	got := NewHandler(keeper)(ctx, NewMsgSideChainSubmitEvidence(submitter, "bsc", headers))
+	require.EqualValues(t, sdk.ErrMsgNotSupported("").ABCICode(), got.Code)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SideChainEvidence, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	// side chain is not registered
+	got = NewHandler(keeper)(ctx, NewMsgSideChainSubmitEvidence(submitter, "unknown", headers))
+	require.EqualValues(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidSideChain), got.Code)
+
+	require.Nil(t, keeper.RegisterHeaderVerifier("bsc", rejectingHeaderVerifier{}))
+	require.NotNil(t, keeper.RegisterHeaderVerifier("bsc", rejectingHeaderVerifier{}))
+
+	got = NewHandler(keeper)(ctx, NewMsgSideChainSubmitEvidence(submitter, "bsc", headers))
+	require.EqualValues(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+	require.Contains(t, got.Log, "rejected header 1")
+
+	// the bsc evidence msg goes through the registered verifier as well
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitEvidence(submitter, headers))
+	require.Contains(t, got.Log, "rejected header 1")
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	tmtypes "github.com/tendermint/tendermint/types"
 
-	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -36,6 +35,8 @@ type Keeper struct {
 	ScKeeper   *sidechain.Keeper
 
 	PbsbServer *pubsub.Server
+
+	headerVerifiers map[string]SideChainHeaderVerifier
 }
 
 // NewKeeper creates a slashing keeper
@@ -47,6 +48,8 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, paramspa
 		paramspace:   paramspace.WithTypeTable(ParamTypeTable()),
 		Codespace:    codespace,
 		BankKeeper:   bk,
+
+		headerVerifiers: make(map[string]SideChainHeaderVerifier),
 	}
 	return keeper
 }
@@ -263,12 +266,16 @@ func (k *Keeper) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.Exec
 	panic("receive unexpected fail ack package")
 }
 
+func (k *Keeper) RegisterPackageTypes(codec *sdk.CrossChainPackageCodec, channelID sdk.ChannelID) error {
+	return codec.RegisterPackageType(channelID, sdk.SynCrossChainPackageType, SideDowntimeSlashPackage{})
+}
+
 func (k *Keeper) checkSideDowntimeSlashPackage(payload []byte) (*SideDowntimeSlashPackage, sdk.Error) {
-	var slashEvent SideDowntimeSlashPackage
-	err := rlp.DecodeBytes(payload, &slashEvent)
+	decoded, err := k.ScKeeper.DecodePackagePayload(ChannelId, sdk.SynCrossChainPackageType, payload)
 	if err != nil {
 		return nil, ErrInvalidInput(k.Codespace, "failed to parse the payload")
 	}
+	slashEvent := *decoded.(*SideDowntimeSlashPackage)
 	if len(slashEvent.SideConsAddr) != sdk.AddrLen {
 		return nil, ErrInvalidClaim(k.Codespace, fmt.Sprintf("wrong sideConsAddr length, expected=%d", slashEvent.SideConsAddr))
 	}
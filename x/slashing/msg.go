@@ -18,6 +18,8 @@ const (
 	TypeMsgUnjail            = "unjail"
 	TypeMsgSideChainUnjail   = "side_chain_unjail"
 	TypeMsgBscSubmitEvidence = "bsc_submit_evidence"
+
+	TypeMsgSideChainSubmitEvidence = "side_chain_submit_evidence"
 )
 
 // verify interface at compile time
@@ -137,26 +139,30 @@ func (msg MsgBscSubmitEvidence) ValidateBasic() sdk.Error {
 	if len(msg.Submitter) != sdk.AddrLen {
 		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.Submitter)))
 	}
-	if len(msg.Headers) != 2 {
+	return doubleSignHeadersCheck(msg.Headers)
+}
+
+func doubleSignHeadersCheck(headers []bsc.Header) sdk.Error {
+	if len(headers) != 2 {
 		return ErrInvalidEvidence(DefaultCodespace, "Must have 2 headers exactly")
 	}
-	if err := headerEmptyCheck(msg.Headers[0]); err != nil {
+	if err := headerEmptyCheck(headers[0]); err != nil {
 		return err
 	}
-	if err := headerEmptyCheck(msg.Headers[1]); err != nil {
+	if err := headerEmptyCheck(headers[1]); err != nil {
 		return err
 	}
-	if msg.Headers[0].Number != msg.Headers[1].Number {
+	if headers[0].Number != headers[1].Number {
 		return ErrInvalidEvidence(DefaultCodespace, "The numbers of two block headers are not the same")
 	}
-	if msg.Headers[0].ParentHash.Cmp(msg.Headers[1].ParentHash) != 0 {
+	if headers[0].ParentHash.Cmp(headers[1].ParentHash) != 0 {
 		return ErrInvalidEvidence(DefaultCodespace, "The parent hash of two block headers are not the same")
 	}
-	signature1, err := msg.Headers[0].GetSignature()
+	signature1, err := headers[0].GetSignature()
 	if err != nil {
 		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to get signature from block header, %s", err.Error()))
 	}
-	signature2, err := msg.Headers[1].GetSignature()
+	signature2, err := headers[1].GetSignature()
 	if err != nil {
 		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Failed to get signature from block header, %s", err.Error()))
 	}
@@ -193,3 +199,53 @@ func (msg MsgBscSubmitEvidence) GetSigners() []sdk.AccAddress {
 func (msg MsgBscSubmitEvidence) GetInvolvedAddresses() []sdk.AccAddress {
 	return msg.GetSigners()
 }
+
+//__________________________________________________________________
+
+// MsgSideChainSubmitEvidence - struct for submitting double sign evidence for any registered side chain
+var _ sdk.Msg = &MsgSideChainSubmitEvidence{}
+
+type MsgSideChainSubmitEvidence struct {
+	Submitter   sdk.AccAddress `json:"submitter"`
+	SideChainId string         `json:"side_chain_id"`
+	Headers     []bsc.Header   `json:"headers"`
+}
+
+func NewMsgSideChainSubmitEvidence(submitter sdk.AccAddress, sideChainId string, headers []bsc.Header) MsgSideChainSubmitEvidence {
+	return MsgSideChainSubmitEvidence{
+		Submitter:   submitter,
+		SideChainId: sideChainId,
+		Headers:     headers,
+	}
+}
+
+func (MsgSideChainSubmitEvidence) Route() string {
+	return MsgRoute
+}
+
+func (MsgSideChainSubmitEvidence) Type() string {
+	return TypeMsgSideChainSubmitEvidence
+}
+
+func (msg MsgSideChainSubmitEvidence) ValidateBasic() sdk.Error {
+	if len(msg.Submitter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected submitter address length is %d, actual length is %d", sdk.AddrLen, len(msg.Submitter)))
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("side chain id must be included and max length is %d bytes", types.MaxSideChainIdLength))
+	}
+	return doubleSignHeadersCheck(msg.Headers)
+}
+
+func (msg MsgSideChainSubmitEvidence) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgSideChainSubmitEvidence) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Submitter}
+}
+
+func (msg MsgSideChainSubmitEvidence) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
@@ -24,6 +24,8 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdDelegate(cdc),
 			GetCmdRedelegate(storeKey, cdc),
 			GetCmdUnbond(storeKey, cdc),
+			GetCmdSetInsurancePool(cdc),
+			GetCmdDepositInsurancePool(cdc),
 		)...,
 	)
 	stakingCmd.AddCommand(client.LineBreak)
@@ -40,6 +42,8 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdQueryRedelegations(storeKey, cdc),
 			GetCmdQueryUnbondingDelegation(storeKey, cdc),
 			GetCmdQueryUnbondingDelegations(storeKey, cdc),
+			GetCmdQueryInsurancePool(cdc),
+			GetCmdQueryInsuranceClaims(cdc),
 		)...,
 	)
 	stakingCmd.AddCommand(client.LineBreak)
@@ -51,6 +55,10 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdSideChainDelegate(cdc),
 			GetCmdSideChainRedelegate(cdc),
 			GetCmdSideChainUnbond(cdc),
+			GetCmdSideChainLiquidDelegate(cdc),
+			GetCmdSideChainRedeemReceipt(cdc),
+			GetCmdSideChainSetAutoCompound(cdc),
+			GetCmdSideChainCancelCommissionChange(cdc),
 		)...,
 	)
 	stakingCmd.AddCommand(client.LineBreak)
@@ -70,6 +78,9 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdQuerySideChainTopValidators(cdc),
 			GetCmdQuerySideAllValidatorsCount(cdc),
 			GetCmdQueryCrossStakeInfoByBscAddress(cdc),
+			GetCmdQuerySideChainLiquidStakeReceipt(cdc),
+			GetCmdQuerySideChainAutoCompound(cdc),
+			GetCmdQuerySideChainPendingCommissionChanges(cdc),
 		)...,
 	)
 
@@ -40,6 +40,11 @@ const (
 	FlagSideChainId  = "side-chain-id"
 	FlagSideConsAddr = "side-cons-addr"
 	FlagSideFeeAddr  = "side-fee-addr"
+
+	FlagAutoCompound = "auto-compound"
+
+	FlagCommissionPledge = "commission-pledge"
+	FlagCoverageRatio    = "coverage-ratio"
 )
 
 // common flagsets to add to various functions
@@ -57,6 +62,7 @@ var (
 	fsSideChainFull     = flag.NewFlagSet("", flag.ContinueOnError)
 	fsSideChainEdit     = flag.NewFlagSet("", flag.ContinueOnError)
 	fsSideChainId       = flag.NewFlagSet("", flag.ContinueOnError)
+	fsInsurancePool     = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -87,4 +93,6 @@ func init() {
 	fsSideChainEdit.String(FlagSideFeeAddr, "", "address that validator collects fee rewards on side chain, please use hex format prefixed with 0x")
 	fsSideChainEdit.String(FlagSideConsAddr, "", "consensus address of the validator on side chain, please use hex format prefixed with 0x")
 	fsSideChainId.String(FlagSideChainId, "", "chain-id of the side chain the validator belongs to")
+	fsInsurancePool.String(FlagCommissionPledge, "", "The share of the commission paid to the insurance pool")
+	fsInsurancePool.String(FlagCoverageRatio, "", "The share of the slash losses of the delegators reimbursed by the insurance pool")
 }
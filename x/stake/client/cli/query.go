@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -511,3 +512,104 @@ func GetCmdQueryParams(storeName string, cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryInsurancePool implements the insurance pool query command.
+func GetCmdQueryInsurancePool(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "insurance-pool [operator-addr]",
+		Short: "Query the insurance pool of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := stake.QueryValidatorParams{
+				ValidatorAddr: valAddr,
+				BaseParams:    stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryInsurancePool, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var pool types.InsurancePool
+				if err = cdc.UnmarshalJSON(response, &pool); err != nil {
+					return err
+				}
+				human, err := pool.HumanReadableString()
+				if err != nil {
+					return err
+				}
+				fmt.Println(human)
+			case "json":
+				fmt.Println(string(response))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+// GetCmdQueryInsuranceClaims implements the insurance claims query command.
+func GetCmdQueryInsuranceClaims(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "insurance-claims [operator-addr]",
+		Short: "Query the reimbursements paid by the insurance pool of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := stake.QueryValidatorParams{
+				ValidatorAddr: valAddr,
+				BaseParams:    stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryInsuranceClaims, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var claims []types.InsuranceClaim
+				if err = cdc.UnmarshalJSON(response, &claims); err != nil {
+					return err
+				}
+				for _, claim := range claims {
+					human, err := claim.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(human)
+					fmt.Println()
+				}
+			case "json":
+				fmt.Println(string(response))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
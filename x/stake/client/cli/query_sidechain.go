@@ -49,6 +49,14 @@ func GetCmdQuerySideValidator(storeName string, cdc *codec.Codec) *cobra.Command
 				return err
 			}
 
+			res, err = cliCtx.QueryStore(append(sideChainStorePrefix, stake.GetCommissionChangeKey(addr)...), storeName)
+			if err != nil {
+				return err
+			} else if len(res) != 0 {
+				change := types.MustUnmarshalPendingCommissionChange(cdc, res)
+				validator.PendingCommissionChange = &change
+			}
+
 			switch viper.Get(cli.OutputFlag) {
 			case "text":
 				human, err := validator.HumanReadableString()
@@ -846,6 +854,162 @@ func GetCmdQueryCrossStakeInfoByBscAddress(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+func GetCmdQuerySideChainLiquidStakeReceipt(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "side-liquid-stake-receipt [denom]",
+		Short: "Query the validator, supply and value of the liquid stake receipts of a denom",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId, _, err := getSideChainConfig(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			params := stake.QueryLiquidStakeReceiptParams{
+				BaseParams: stake.NewBaseParams(sideChainId),
+				Denom:      args[0],
+			}
+
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryLiquidStakeReceipt, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var receiptResp types.LiquidStakeReceiptResponse
+				if err = cdc.UnmarshalJSON(response, &receiptResp); err != nil {
+					return err
+				}
+				resp, err := receiptResp.HumanReadableString()
+				if err != nil {
+					return err
+				}
+				fmt.Println(resp)
+			case "json":
+				fmt.Println(string(response))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
+func GetCmdQuerySideChainAutoCompound(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "side-auto-compound [delegator-addr] [operator-addr]",
+		Short: "Query the auto compound and the pending rewards of a delegation",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := sdk.ValAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId, _, err := getSideChainConfig(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			params := stake.QueryBondsParams{
+				DelegatorAddr: delAddr,
+				ValidatorAddr: valAddr,
+				BaseParams:    stake.NewBaseParams(sideChainId),
+			}
+
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryAutoCompound, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var autoCompound types.AutoCompound
+				if err = cdc.UnmarshalJSON(response, &autoCompound); err != nil {
+					return err
+				}
+				resp, err := autoCompound.HumanReadableString()
+				if err != nil {
+					return err
+				}
+				fmt.Println(resp)
+			case "json":
+				fmt.Println(string(response))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
+func GetCmdQuerySideChainPendingCommissionChanges(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "side-pending-commission-changes",
+		Short: "Query the commission rate changes of the validators which have not taken effect yet",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId, _, err := getSideChainConfig(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			bz, err := json.Marshal(stake.NewBaseParams(sideChainId))
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryPendingCommissionChanges, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var changes []types.PendingCommissionChange
+				if err = cdc.UnmarshalJSON(response, &changes); err != nil {
+					return err
+				}
+				for _, change := range changes {
+					fmt.Printf("Validator: %s\nPending Commission Change: {%s}\n", change.ValidatorAddr, change)
+				}
+			case "json":
+				fmt.Println(string(response))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
 func getSideChainConfig(cliCtx context.CLIContext) (sideChainId string, prefix []byte, error error) {
 	sideChainId, error = getSideChainId()
 	if error != nil {
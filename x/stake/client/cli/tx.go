@@ -465,6 +465,78 @@ func GetCmdUnbond(storeName string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdSetInsurancePool implements the set insurance pool command.
+func GetCmdSetInsurancePool(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-insurance-pool",
+		Short: "open the insurance pool of a validator or update its rules, the validator is on the Beacon Chain if --side-chain-id is not set",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			valAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			commissionPledge, err := sdk.NewDecFromStr(viper.GetString(FlagCommissionPledge))
+			if err != nil {
+				return fmt.Errorf("invalid commission pledge: %v", err)
+			}
+			coverageRatio, err := sdk.NewDecFromStr(viper.GetString(FlagCoverageRatio))
+			if err != nil {
+				return fmt.Errorf("invalid coverage ratio: %v", err)
+			}
+
+			sideChainId := viper.GetString(FlagSideChainId)
+			msg := stake.NewMsgSetInsurancePool(sideChainId, sdk.ValAddress(valAddr), commissionPledge, coverageRatio)
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsInsurancePool)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	cmd.MarkFlagRequired(FlagCommissionPledge)
+	cmd.MarkFlagRequired(FlagCoverageRatio)
+
+	return cmd
+}
+
+// GetCmdDepositInsurancePool implements the deposit insurance pool command.
+func GetCmdDepositInsurancePool(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit-insurance-pool",
+		Short: "pledge tokens to the insurance pool of a validator, the tokens cannot be withdrawn",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			amount, err := getAmount()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			sideChainId := viper.GetString(FlagSideChainId)
+			msg := stake.NewMsgDepositInsurancePool(sideChainId, sdk.ValAddress(valAddr), amount)
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsAmount)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+
+	return cmd
+}
+
 func getAmount() (sdk.Coin, error) {
 	amountStr := viper.GetString(FlagAmount)
 	if amountStr == "" {
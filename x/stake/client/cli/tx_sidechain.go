@@ -268,6 +268,118 @@ func GetCmdSideChainUnbond(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+func GetCmdSideChainLiquidDelegate(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-liquid-delegate",
+		Short: "delegate liquid tokens to a side chain validator and receive transferable receipts of the delegation",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			amount, err := getAmount()
+			if err != nil {
+				return err
+			}
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := getValidatorAddr(FlagAddressValidator)
+			if err != nil {
+				return err
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSideChainLiquidDelegate(sideChainId, delAddr, valAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsAmount)
+	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+func GetCmdSideChainRedeemReceipt(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-redeem-receipt",
+		Short: "redeem liquid stake receipts for a delegation to their side chain validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			amount, err := getAmount()
+			if err != nil {
+				return err
+			}
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSideChainRedeemReceipt(sideChainId, delAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsAmount)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+func GetCmdSideChainSetAutoCompound(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-set-auto-compound",
+		Short: "enable or disable the delegation of the rewards of a delegation back to its side chain validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := getValidatorAddr(FlagAddressValidator)
+			if err != nil {
+				return err
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSideChainSetAutoCompound(sideChainId, delAddr, valAddr, viper.GetBool(FlagAutoCompound))
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Bool(FlagAutoCompound, true, "enable the auto compound, false to disable it and get the pending rewards")
+	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
 func getSideChainId() (sideChainId string, err error) {
 	sideChainId = viper.GetString(FlagSideChainId)
 	if len(sideChainId) == 0 {
@@ -318,3 +430,32 @@ func getValidatorAddr(flagName string) (valAddr sdk.ValAddress, err error) {
 	}
 	return sdk.ValAddressFromBech32(valAddrStr)
 }
+
+func GetCmdSideChainCancelCommissionChange(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-cancel-commission-change",
+		Short: "cancel the pending commission rate change of a side chain validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			valAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSideChainCancelCommissionChange(sideChainId, sdk.ValAddress(valAddr))
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
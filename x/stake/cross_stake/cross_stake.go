@@ -1,6 +1,7 @@
 package cross_stake
 
 import (
+	"math"
 	"math/big"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
@@ -11,8 +12,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
+var _ sdk.CrossChainTimeoutApplication = (*CrossStakeApp)(nil)
+
 type CrossStakeApp struct {
 	stakeKeeper Keeper
+
+	// the codec and the channel that the packages of the app are registered to
+	packageCodec *sdk.CrossChainPackageCodec
+	channelID    sdk.ChannelID
 }
 
 func NewCrossStakeApp(stakeKeeper Keeper) *CrossStakeApp {
@@ -21,6 +28,64 @@ func NewCrossStakeApp(stakeKeeper Keeper) *CrossStakeApp {
 	}
 }
 
+func (app *CrossStakeApp) RegisterPackageTypes(codec *sdk.CrossChainPackageCodec, channelID sdk.ChannelID) error {
+	app.packageCodec = codec
+	app.channelID = channelID
+	if err := codec.RegisterPackageDecoder(channelID, sdk.SynCrossChainPackageType, DeserializeCrossStakeSynPackage); err != nil {
+		return err
+	}
+	if err := codec.RegisterPackageType(channelID, sdk.AckCrossChainPackageType, types.CrossStakeRefundPackage{}); err != nil {
+		return err
+	}
+	return codec.RegisterPackageDecoder(channelID, sdk.FailAckCrossChainPackageType, DeserializeCrossStakeFailAckPackage)
+}
+
+// PackageValue implements sdk.CrossChainPackageValuer, the value of a syn package is the amount of BNB it
+// moves between the chains: the delegations coming from BSC and the rewards and undelegated tokens sent back.
+func (app *CrossStakeApp) PackageValue(packageType sdk.CrossChainPackageType, payload []byte) int64 {
+	if packageType != sdk.SynCrossChainPackageType {
+		return 0
+	}
+	// the syn packages from BSC carry BC amounts
+	if pack, err := DeserializeCrossStakeSynPackage(payload); err == nil {
+		if p, ok := pack.(*types.CrossStakeDelegateSynPackage); ok {
+			return bigAmountValue(p.Amount)
+		}
+		return 0
+	}
+	// the syn packages to BSC carry BSC amounts
+	pack, err := DeserializeCrossStakeFailAckPackage(payload)
+	if err != nil {
+		return 0
+	}
+	switch p := pack.(type) {
+	case *types.CrossStakeDistributeRewardSynPackage:
+		return bscAmountValue(p.Amount)
+	case *types.CrossStakeDistributeUndelegatedSynPackage:
+		return bscAmountValue(p.Amount)
+	default:
+		return 0
+	}
+}
+
+func bscAmountValue(bscAmount *big.Int) int64 {
+	if bscAmount == nil {
+		return 0
+	}
+	return bigAmountValue(new(big.Int).Div(bscAmount, bsc.ConvertBCAmountToBSCAmount(1)))
+}
+
+// bigAmountValue saturates the amounts which do not fit an int64, so that they exceed any cap
+func bigAmountValue(amount *big.Int) int64 {
+	if amount == nil || amount.Sign() <= 0 {
+		return 0
+	}
+	if !amount.IsInt64() {
+		return math.MaxInt64
+	}
+	return amount.Int64()
+}
+
 func (app *CrossStakeApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayFee int64) sdk.ExecuteResult {
 	if len(payload) == 0 {
 		app.stakeKeeper.Logger(ctx).Error("receive empty cross stake syn package")
@@ -28,7 +93,7 @@ func (app *CrossStakeApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, rel
 	}
 
 	app.stakeKeeper.Logger(ctx).Info("receive cross stake syn package")
-	pack, err := DeserializeCrossStakeSynPackage(payload)
+	pack, err := app.packageCodec.Decode(app.channelID, sdk.SynCrossChainPackageType, payload)
 	if err != nil {
 		app.stakeKeeper.Logger(ctx).Error("unmarshal cross stake sync claim error", "err", err.Error(), "claim", string(payload))
 		panic("unmarshal cross stake claim error")
@@ -73,11 +138,12 @@ func (app *CrossStakeApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk
 		return sdk.ExecuteResult{}
 	}
 
-	pack, err := DeserializeCrossStakeRefundPackage(payload)
+	decoded, err := app.packageCodec.Decode(app.channelID, sdk.AckCrossChainPackageType, payload)
 	if err != nil {
 		app.stakeKeeper.Logger(ctx).Error("unmarshal cross stake refund package error", "err", err.Error(), "package", string(payload))
 		return sdk.ExecuteResult{}
 	}
+	pack := decoded.(*types.CrossStakeRefundPackage)
 
 	var result sdk.ExecuteResult
 	switch pack.EventType {
@@ -98,14 +164,24 @@ func (app *CrossStakeApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk
 }
 
 func (app *CrossStakeApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return app.refundSynPackage(ctx, payload, "fail ack")
+}
+
+// ExecuteTimeoutPackage refunds a distribute package which is not confirmed in time the same way as a fail ack package
+func (app *CrossStakeApp) ExecuteTimeoutPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return app.refundSynPackage(ctx, payload, "timeout")
+}
+
+// refundSynPackage refunds a distribute package sent to the side chain, payload is the payload of the syn package
+func (app *CrossStakeApp) refundSynPackage(ctx sdk.Context, payload []byte, reason string) sdk.ExecuteResult {
 	if len(payload) == 0 {
-		app.stakeKeeper.Logger(ctx).Error("receive empty cross stake fail ack package")
+		app.stakeKeeper.Logger(ctx).Error("receive empty cross stake " + reason + " package")
 		return sdk.ExecuteResult{}
 	}
 
-	pack, err := DeserializeCrossStakeFailAckPackage(payload)
+	pack, err := app.packageCodec.Decode(app.channelID, sdk.FailAckCrossChainPackageType, payload)
 	if err != nil {
-		app.stakeKeeper.Logger(ctx).Error("unmarshal cross stake fail ack package error", "err", err.Error(), "package", string(payload))
+		app.stakeKeeper.Logger(ctx).Error("unmarshal cross stake "+reason+" package error", "err", err.Error(), "package", string(payload))
 		return sdk.ExecuteResult{}
 	}
 
@@ -128,11 +204,11 @@ func (app *CrossStakeApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte)
 		}
 		result, err = app.handleDistributeUndelegatedRefund(ctx, refundPackage)
 	default:
-		app.stakeKeeper.Logger(ctx).Error("unknown cross stake fail ack event type", "err", err.Error(), "package", string(payload))
+		app.stakeKeeper.Logger(ctx).Error("unknown cross stake "+reason+" event type", "package", string(payload))
 		return sdk.ExecuteResult{}
 	}
 	if err != nil {
-		app.stakeKeeper.Logger(ctx).Error("handle cross stake fail ack package error", "err", err.Error(), "package", string(payload))
+		app.stakeKeeper.Logger(ctx).Error("handle cross stake "+reason+" package error", "err", err.Error(), "package", string(payload))
 		return sdk.ExecuteResult{}
 	}
 
@@ -2,8 +2,14 @@ package cross_stake
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -25,3 +31,38 @@ func TestDeserializeSynPackage(t *testing.T) {
 		t.Error("wrong event type")
 	}
 }
+
+func TestPackageValue(t *testing.T) {
+	app := NewCrossStakeApp(Keeper{})
+
+	params, err := rlp.EncodeToBytes(types.CrossStakeDelegateSynPackage{
+		DelAddr:   sdk.SmartChainAddress{1},
+		Validator: sdk.ValAddress{2},
+		Amount:    big.NewInt(5e8),
+	})
+	require.NoError(t, err)
+	delegate, err := rlp.EncodeToBytes(CrossStakeSynPackageFromBSC{EventType: types.CrossStakeTypeDelegate, ParamsBytes: params})
+	require.NoError(t, err)
+	require.Equal(t, int64(5e8), app.PackageValue(sdk.SynCrossChainPackageType, delegate))
+	require.Equal(t, int64(0), app.PackageValue(sdk.AckCrossChainPackageType, delegate))
+
+	params, err = rlp.EncodeToBytes(types.CrossStakeUndelegateSynPackage{
+		DelAddr:   sdk.SmartChainAddress{1},
+		Validator: sdk.ValAddress{2},
+		Amount:    big.NewInt(5e8),
+	})
+	require.NoError(t, err)
+	undelegate, err := rlp.EncodeToBytes(CrossStakeSynPackageFromBSC{EventType: types.CrossStakeTypeUndelegate, ParamsBytes: params})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), app.PackageValue(sdk.SynCrossChainPackageType, undelegate))
+
+	reward, err := rlp.EncodeToBytes(types.CrossStakeDistributeRewardSynPackage{
+		EventType: types.CrossStakeTypeDistributeReward,
+		Recipient: sdk.SmartChainAddress{1},
+		Amount:    bsc.ConvertBCAmountToBSCAmount(3e8),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3e8), app.PackageValue(sdk.SynCrossChainPackageType, reward))
+
+	require.Equal(t, int64(0), app.PackageValue(sdk.SynCrossChainPackageType, []byte{0x01}))
+}
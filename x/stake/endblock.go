@@ -61,7 +61,13 @@ func EndBreatheBlock(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.
 		sideChainIds, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
 		for i := range storePrefixes {
 			sideChainCtx := ctx.WithSideChainKeyPrefix(storePrefixes[i])
+			// the commission changes take effect before the validators are elected
+			var ccEvents sdk.Events
+			if sdk.IsUpgrade(sdk.CommissionSchedule) {
+				ccEvents = k.ApplyPendingCommissionChanges(sideChainCtx)
+			}
 			newVals, _, completedUbds, completedREDs, scEvents := handleValidatorAndDelegations(sideChainCtx, k)
+			scEvents = ccEvents.AppendEvents(scEvents)
 			if k.ExistHeightValidators(sideChainCtx) { // will not send ibc package if no snapshot of validators stored ever
 				saveSideChainValidatorsToIBC(ctx, sideChainIds[i], newVals, k)
 			}
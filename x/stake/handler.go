@@ -48,6 +48,36 @@ func NewHandler(k keeper.Keeper, govKeeper gov.Keeper) sdk.Handler {
 			return handleMsgSideChainRedelegate(ctx, msg, k)
 		case types.MsgSideChainUndelegate:
 			return handleMsgSideChainUndelegate(ctx, msg, k)
+		case types.MsgSideChainLiquidDelegate:
+			if !sdk.IsUpgrade(sdk.LiquidStaking) {
+				return sdk.ErrMsgNotSupported("liquid staking not activated yet").Result()
+			}
+			return handleMsgSideChainLiquidDelegate(ctx, msg, k)
+		case types.MsgSideChainRedeemReceipt:
+			if !sdk.IsUpgrade(sdk.LiquidStaking) {
+				return sdk.ErrMsgNotSupported("liquid staking not activated yet").Result()
+			}
+			return handleMsgSideChainRedeemReceipt(ctx, msg, k)
+		case types.MsgSideChainSetAutoCompound:
+			if !sdk.IsUpgrade(sdk.AutoCompound) {
+				return sdk.ErrMsgNotSupported("auto compound not activated yet").Result()
+			}
+			return handleMsgSideChainSetAutoCompound(ctx, msg, k)
+		case types.MsgSideChainCancelCommissionChange:
+			if !sdk.IsUpgrade(sdk.CommissionSchedule) {
+				return sdk.ErrMsgNotSupported("commission schedule not activated yet").Result()
+			}
+			return handleMsgSideChainCancelCommissionChange(ctx, msg, k)
+		case types.MsgSetInsurancePool:
+			if !sdk.IsUpgrade(sdk.SlashInsurance) {
+				return sdk.ErrMsgNotSupported("slash insurance not activated yet").Result()
+			}
+			return handleMsgSetInsurancePool(ctx, msg, k)
+		case types.MsgDepositInsurancePool:
+			if !sdk.IsUpgrade(sdk.SlashInsurance) {
+				return sdk.ErrMsgNotSupported("slash insurance not activated yet").Result()
+			}
+			return handleMsgDepositInsurancePool(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
@@ -507,3 +537,50 @@ func handleMsgRedelegate(ctx sdk.Context, msg types.MsgRedelegate, k keeper.Keep
 	}
 	return sdk.Result{Data: finishTime, Tags: tags}
 }
+
+// the insurance pool msgs are for the validators of the Beacon Chain unless
+// they have a side chain id
+func prepareCtxForInsurancePool(ctx sdk.Context, sideChainId string, k keeper.Keeper) (sdk.Context, sdk.Error) {
+	if len(sideChainId) == 0 {
+		return ctx, nil
+	}
+	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+	if err != nil {
+		return ctx, ErrInvalidSideChainId(k.Codespace())
+	}
+	return scCtx, nil
+}
+
+func handleMsgSetInsurancePool(ctx sdk.Context, msg types.MsgSetInsurancePool, k keeper.Keeper) sdk.Result {
+	ctx, err := prepareCtxForInsurancePool(ctx, msg.SideChainId, k)
+	if err != nil {
+		return err.Result()
+	}
+
+	if err := k.SetInsurancePoolRules(ctx, msg.ValidatorAddr, msg.CommissionPledge, msg.CoverageRatio); err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		),
+	}
+}
+
+func handleMsgDepositInsurancePool(ctx sdk.Context, msg types.MsgDepositInsurancePool, k keeper.Keeper) sdk.Result {
+	ctx, err := prepareCtxForInsurancePool(ctx, msg.SideChainId, k)
+	if err != nil {
+		return err.Result()
+	}
+
+	if err := k.DepositInsurancePool(ctx, sdk.AccAddress(msg.ValidatorAddr), msg.ValidatorAddr, msg.Amount); err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		),
+	}
+}
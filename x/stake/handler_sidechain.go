@@ -93,12 +93,19 @@ func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValida
 	}
 
 	if msg.CommissionRate != nil {
-		commission, err := k.UpdateValidatorCommission(ctx, validator, *msg.CommissionRate)
-		if err != nil {
-			return err.Result()
+		if sdk.IsUpgrade(sdk.CommissionSchedule) {
+			// the new rate takes effect some breathe blocks later
+			if _, err := k.QueueCommissionChange(ctx, validator, *msg.CommissionRate); err != nil {
+				return err.Result()
+			}
+		} else {
+			commission, err := k.UpdateValidatorCommission(ctx, validator, *msg.CommissionRate)
+			if err != nil {
+				return err.Result()
+			}
+			validator.Commission = commission
+			k.OnValidatorModified(ctx, msg.ValidatorAddr)
 		}
-		validator.Commission = commission
-		k.OnValidatorModified(ctx, msg.ValidatorAddr)
 	}
 
 	if len(msg.SideFeeAddr) != 0 {
@@ -311,6 +318,131 @@ func handleMsgSideChainUndelegate(ctx sdk.Context, msg MsgSideChainUndelegate, k
 	return sdk.Result{Data: finishTime, Tags: tags}
 }
 
+func handleMsgSideChainLiquidDelegate(ctx sdk.Context, msg MsgSideChainLiquidDelegate, k keeper.Keeper) sdk.Result {
+	if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	} else {
+		ctx = scCtx
+	}
+
+	minDelegationChange := k.MinDelegationChange(ctx)
+	if msg.Delegation.Amount < minDelegationChange {
+		return ErrBadDelegationAmount(DefaultCodespace, fmt.Sprintf("delegation must not be less than %d", minDelegationChange)).Result()
+	}
+
+	validator, found := k.GetValidator(ctx, msg.ValidatorAddr)
+	if !found {
+		return ErrNoValidatorFound(k.Codespace()).Result()
+	}
+
+	if msg.Delegation.Denom != k.BondDenom(ctx) {
+		return ErrBadDenom(k.Codespace()).Result()
+	}
+
+	// the receipts are not self delegations, so they are not minted for the
+	// delegations to a jailed validator
+	if validator.Jailed {
+		return ErrValidatorJailed(k.Codespace()).Result()
+	}
+
+	minted, err := k.LiquidDelegate(ctx, msg.DelegatorAddr, msg.Delegation, validator)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, []byte(msg.DelegatorAddr.String()),
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+			tags.Receipt, []byte(minted.String()),
+		),
+	}
+}
+
+func handleMsgSideChainRedeemReceipt(ctx sdk.Context, msg MsgSideChainRedeemReceipt, k keeper.Keeper) sdk.Result {
+	if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	} else {
+		ctx = scCtx
+	}
+
+	receipt, found := k.GetLiquidStakeReceipt(ctx, msg.Amount.Denom)
+	if !found {
+		return ErrNoLiquidStakeReceipt(k.Codespace()).Result()
+	}
+
+	// we need this lower limit to prevent too many delegation records, but the
+	// whole balance of a holder can always be redeemed
+	minDelegationChange := k.MinDelegationChange(ctx)
+	if msg.Amount.Amount < minDelegationChange &&
+		msg.Amount.Amount != k.BankKeeper.GetCoins(ctx, msg.DelegatorAddr).AmountOf(msg.Amount.Denom) {
+		return ErrBadDelegationAmount(DefaultCodespace, fmt.Sprintf("redeem amount must not be less than %d", minDelegationChange)).Result()
+	}
+
+	validator, found := k.GetValidator(ctx, receipt.ValidatorAddr)
+	if !found {
+		return ErrNoValidatorFound(k.Codespace()).Result()
+	}
+
+	if err := checkOperatorAsDelegator(k, msg.DelegatorAddr, validator); err != nil {
+		return err.Result()
+	}
+
+	if _, err := k.RedeemReceipt(ctx, msg.DelegatorAddr, msg.Amount); err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, []byte(msg.DelegatorAddr.String()),
+			tags.DstValidator, []byte(receipt.ValidatorAddr.String()),
+		),
+	}
+}
+
+func handleMsgSideChainSetAutoCompound(ctx sdk.Context, msg MsgSideChainSetAutoCompound, k keeper.Keeper) sdk.Result {
+	if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	} else {
+		ctx = scCtx
+	}
+
+	var err sdk.Error
+	if msg.Enabled {
+		err = k.EnableAutoCompound(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	} else {
+		err = k.DisableAutoCompound(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	}
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, []byte(msg.DelegatorAddr.String()),
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		),
+	}
+}
+
+func handleMsgSideChainCancelCommissionChange(ctx sdk.Context, msg MsgSideChainCancelCommissionChange, k keeper.Keeper) sdk.Result {
+	if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	} else {
+		ctx = scCtx
+	}
+
+	if err := k.CancelCommissionChange(ctx, msg.ValidatorAddr); err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		),
+	}
+}
+
 // we allow the self-delegator delegating/redelegating to its validator.
 // but the operator is not allowed if it is not a self-delegator
 func checkOperatorAsDelegator(k Keeper, delegator sdk.AccAddress, validator Validator) sdk.Error {
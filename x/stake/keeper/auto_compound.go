@@ -0,0 +1,115 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the auto compound of a delegation
+func (k Keeper) GetAutoCompound(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (autoCompound types.AutoCompound, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(GetAutoCompoundKey(delAddr, valAddr))
+	if value == nil {
+		return autoCompound, false
+	}
+	return types.MustUnmarshalAutoCompound(k.cdc, value), true
+}
+
+// set the auto compound of a delegation
+func (k Keeper) SetAutoCompound(ctx sdk.Context, autoCompound types.AutoCompound) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetAutoCompoundKey(autoCompound.DelegatorAddr, autoCompound.ValidatorAddr), types.MustMarshalAutoCompound(k.cdc, autoCompound))
+}
+
+// remove the auto compound of a delegation
+func (k Keeper) RemoveAutoCompound(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetAutoCompoundKey(delAddr, valAddr))
+}
+
+// EnableAutoCompound makes the rewards of a delegation be delegated back to its
+// validator during the distribution
+func (k Keeper) EnableAutoCompound(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) sdk.Error {
+	if _, found := k.GetDelegation(ctx, delAddr, valAddr); !found {
+		return types.ErrNoDelegation(k.Codespace())
+	}
+	if _, found := k.GetAutoCompound(ctx, delAddr, valAddr); found {
+		return nil
+	}
+	k.SetAutoCompound(ctx, types.AutoCompound{DelegatorAddr: delAddr, ValidatorAddr: valAddr})
+	return nil
+}
+
+// DisableAutoCompound stops the auto compounding of the rewards of a delegation,
+// the pending rewards are paid to the delegator
+func (k Keeper) DisableAutoCompound(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) sdk.Error {
+	autoCompound, found := k.GetAutoCompound(ctx, delAddr, valAddr)
+	if !found {
+		return types.ErrNoAutoCompound(k.Codespace())
+	}
+	if err := k.payPendingRewards(ctx, autoCompound); err != nil {
+		return err
+	}
+	k.RemoveAutoCompound(ctx, delAddr, valAddr)
+	return nil
+}
+
+func (k Keeper) payPendingRewards(ctx sdk.Context, autoCompound types.AutoCompound) sdk.Error {
+	if autoCompound.Pending <= 0 {
+		return nil
+	}
+	pending := sdk.NewCoin(k.BondDenom(ctx), autoCompound.Pending)
+	if err := k.transferBondTokens(ctx, AutoCompoundAccAddr, autoCompound.DelegatorAddr, pending); err != nil {
+		return err
+	}
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{AutoCompoundAccAddr, autoCompound.DelegatorAddr})
+	}
+	return nil
+}
+
+// compoundReward delegates back the reward of a delegation which enabled auto
+// compound, once the pending rewards reach the min delegation change. It returns
+// false if the reward has to be paid to the delegator instead.
+func (k Keeper) compoundReward(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress, amount int64) bool {
+	if !sdk.IsUpgrade(sdk.AutoCompound) {
+		return false
+	}
+	autoCompound, found := k.GetAutoCompound(ctx, delAddr, valAddr)
+	if !found {
+		return false
+	}
+
+	bondDenom := k.BondDenom(ctx)
+	if _, _, err := k.BankKeeper.AddCoins(ctx, AutoCompoundAccAddr, sdk.Coins{sdk.NewCoin(bondDenom, amount)}); err != nil {
+		panic(err)
+	}
+	autoCompound.Pending += amount
+
+	// the rewards of a removed delegation are paid to the delegator
+	validator, found := k.GetValidator(ctx, valAddr)
+	if _, delFound := k.GetDelegation(ctx, delAddr, valAddr); !found || !delFound {
+		if err := k.payPendingRewards(ctx, autoCompound); err != nil {
+			panic(err)
+		}
+		k.RemoveAutoCompound(ctx, delAddr, valAddr)
+		return true
+	}
+
+	// the rewards are pending while the validator is jailed
+	if validator.Jailed || autoCompound.Pending < k.MinDelegationChange(ctx) {
+		k.SetAutoCompound(ctx, autoCompound)
+		return true
+	}
+
+	pending := sdk.NewCoin(bondDenom, autoCompound.Pending)
+	if err := k.transferBondTokens(ctx, AutoCompoundAccAddr, DelegationAccAddr, pending); err != nil {
+		panic(err)
+	}
+	if _, err := k.Delegate(ctx, delAddr, pending, validator, false); err != nil {
+		panic(err)
+	}
+	autoCompound.Pending = 0
+	k.SetAutoCompound(ctx, autoCompound)
+	return true
+}
@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestCompoundReward(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AutoCompound, 1)
+	params := keeper.GetParams(ctx)
+	params.MinDelegationChange = 1e8
+	keeper.SetParams(ctx, params)
+	bondDenom := keeper.BondDenom(ctx)
+
+	pool := keeper.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 10e8)
+	keeper.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+	_, err := keeper.Delegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, 10e8), validator, true)
+	require.Nil(t, err)
+
+	require.NotNil(t, keeper.EnableAutoCompound(ctx, addrDels[1], addrVals[0]))
+	require.Nil(t, keeper.EnableAutoCompound(ctx, addrDels[0], addrVals[0]))
+	require.False(t, keeper.compoundReward(ctx, addrVals[0], addrDels[1], 6e7))
+
+	// the rewards below the min delegation change are pending
+	require.True(t, keeper.compoundReward(ctx, addrVals[0], addrDels[0], 6e7))
+	autoCompound, found := keeper.GetAutoCompound(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, int64(6e7), autoCompound.Pending)
+	require.Equal(t, int64(6e7), keeper.BankKeeper.GetCoins(ctx, AutoCompoundAccAddr).AmountOf(bondDenom))
+	delegation, _ := keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.Equal(t, sdk.NewDec(10e8), delegation.Shares)
+
+	// the pending rewards are delegated once they reach it
+	require.True(t, keeper.compoundReward(ctx, addrVals[0], addrDels[0], 6e7))
+	autoCompound, _ = keeper.GetAutoCompound(ctx, addrDels[0], addrVals[0])
+	require.Equal(t, int64(0), autoCompound.Pending)
+	require.Equal(t, int64(0), keeper.BankKeeper.GetCoins(ctx, AutoCompoundAccAddr).AmountOf(bondDenom))
+	delegation, _ = keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.Equal(t, sdk.NewDec(112e7), delegation.Shares)
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	require.Equal(t, sdk.NewDec(212e7), validator.Tokens)
+
+	// disabling the auto compound pays the pending rewards
+	require.True(t, keeper.compoundReward(ctx, addrVals[0], addrDels[0], 3e7))
+	require.Nil(t, keeper.DisableAutoCompound(ctx, addrDels[0], addrVals[0]))
+	require.Equal(t, int64(90e8+3e7), keeper.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(bondDenom))
+	_, found = keeper.GetAutoCompound(ctx, addrDels[0], addrVals[0])
+	require.False(t, found)
+	require.NotNil(t, keeper.DisableAutoCompound(ctx, addrDels[0], addrVals[0]))
+}
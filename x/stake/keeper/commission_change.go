@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the pending commission change of a validator
+func (k Keeper) GetPendingCommissionChange(ctx sdk.Context, valAddr sdk.ValAddress) (change types.PendingCommissionChange, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(GetCommissionChangeKey(valAddr))
+	if value == nil {
+		return change, false
+	}
+	return types.MustUnmarshalPendingCommissionChange(k.cdc, value), true
+}
+
+// set the pending commission change of a validator
+func (k Keeper) SetPendingCommissionChange(ctx sdk.Context, change types.PendingCommissionChange) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetCommissionChangeKey(change.ValidatorAddr), types.MustMarshalPendingCommissionChange(k.cdc, change))
+}
+
+// remove the pending commission change of a validator
+func (k Keeper) RemovePendingCommissionChange(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetCommissionChangeKey(valAddr))
+}
+
+// get all the pending commission changes
+func (k Keeper) GetAllPendingCommissionChanges(ctx sdk.Context) (changes []types.PendingCommissionChange) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, CommissionChangeKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		changes = append(changes, types.MustUnmarshalPendingCommissionChange(k.cdc, iterator.Value()))
+	}
+	return changes
+}
+
+// QueueCommissionChange queues a commission rate change of the validator, which
+// replaces the pending one if any. The rate is checked against the current
+// commission of the validator.
+func (k Keeper) QueueCommissionChange(ctx sdk.Context, validator types.Validator, newRate sdk.Dec) (types.PendingCommissionChange, sdk.Error) {
+	if err := validator.Commission.ValidateNewRate(newRate, ctx.BlockHeader().Time); err != nil {
+		return types.PendingCommissionChange{}, err
+	}
+	change := types.NewPendingCommissionChange(validator.OperatorAddr, newRate, ctx.BlockHeight(), k.CommissionChangeNoticeBreatheBlocks(ctx))
+	k.SetPendingCommissionChange(ctx, change)
+	return change, nil
+}
+
+// CancelCommissionChange removes the pending commission change of a validator
+func (k Keeper) CancelCommissionChange(ctx sdk.Context, valAddr sdk.ValAddress) sdk.Error {
+	if _, found := k.GetPendingCommissionChange(ctx, valAddr); !found {
+		return types.ErrNoPendingCommissionChange(k.Codespace())
+	}
+	k.RemovePendingCommissionChange(ctx, valAddr)
+	return nil
+}
+
+// ApplyPendingCommissionChanges counts down the pending commission changes in a
+// breathe block, and applies the ones whose notice is over
+func (k Keeper) ApplyPendingCommissionChanges(ctx sdk.Context) (events sdk.Events) {
+	for _, change := range k.GetAllPendingCommissionChanges(ctx) {
+		change.BreatheBlocksLeft--
+		if change.BreatheBlocksLeft > 0 {
+			k.SetPendingCommissionChange(ctx, change)
+			continue
+		}
+		k.RemovePendingCommissionChange(ctx, change.ValidatorAddr)
+
+		validator, found := k.GetValidator(ctx, change.ValidatorAddr)
+		if !found {
+			continue
+		}
+		k.OnValidatorModified(ctx, change.ValidatorAddr)
+		validator.Commission.Rate = change.Rate
+		validator.Commission.UpdateTime = ctx.BlockHeader().Time
+		k.SetValidator(ctx, validator)
+
+		events = events.AppendEvent(sdk.NewEvent(
+			types.EventTypeCommissionChange,
+			sdk.NewAttribute(types.AttributeKeyValidator, change.ValidatorAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyCommissionRate, change.Rate.String()),
+		))
+	}
+	return events
+}
@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestPendingCommissionChange(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	now := time.Now().UTC()
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 10, Time: now}).WithBlockHeight(10)
+
+	commission := types.NewCommission(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(3, 1), sdk.NewDecWithPrec(1, 1))
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, _ = validator.SetInitialCommission(commission)
+	keeper.SetValidator(ctx, validator)
+
+	// the rate is checked against the current commission when it is queued
+	_, err := keeper.QueueCommissionChange(ctx, validator, sdk.NewDecWithPrec(3, 1))
+	require.NotNil(t, err)
+	change, err := keeper.QueueCommissionChange(ctx, validator, sdk.NewDecWithPrec(2, 1))
+	require.Nil(t, err)
+	require.Equal(t, types.PendingCommissionChange{
+		ValidatorAddr:     addrVals[0],
+		Rate:              sdk.NewDecWithPrec(2, 1),
+		RequestHeight:     10,
+		BreatheBlocksLeft: types.DefaultCommissionChangeNoticeBreatheBlocks,
+	}, change)
+	require.Equal(t, []types.PendingCommissionChange{change}, keeper.GetAllPendingCommissionChanges(ctx))
+
+	// the rate does not change until the notice is over
+	for i := int64(1); i < types.DefaultCommissionChangeNoticeBreatheBlocks; i++ {
+		require.Len(t, keeper.ApplyPendingCommissionChanges(ctx), 0)
+		validator, _ = keeper.GetValidator(ctx, addrVals[0])
+		require.Equal(t, sdk.NewDecWithPrec(1, 1), validator.Commission.Rate)
+		change, _ = keeper.GetPendingCommissionChange(ctx, addrVals[0])
+		require.Equal(t, types.DefaultCommissionChangeNoticeBreatheBlocks-i, change.BreatheBlocksLeft)
+	}
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 20, Time: now.Add(48 * time.Hour)}).WithBlockHeight(20)
+	require.Len(t, keeper.ApplyPendingCommissionChanges(ctx), 1)
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), validator.Commission.Rate)
+	require.Equal(t, now.Add(48*time.Hour), validator.Commission.UpdateTime)
+	_, found := keeper.GetPendingCommissionChange(ctx, addrVals[0])
+	require.False(t, found)
+
+	// a cancelled change never takes effect
+	require.NotNil(t, keeper.CancelCommissionChange(ctx, addrVals[0]))
+	_, err = keeper.QueueCommissionChange(ctx, validator, sdk.NewDecWithPrec(3, 1))
+	require.NotNil(t, err)
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 30, Time: now.Add(96 * time.Hour)}).WithBlockHeight(30)
+	_, err = keeper.QueueCommissionChange(ctx, validator, sdk.NewDecWithPrec(3, 1))
+	require.Nil(t, err)
+	require.Nil(t, keeper.CancelCommissionChange(ctx, addrVals[0]))
+	for i := int64(0); i < types.DefaultCommissionChangeNoticeBreatheBlocks; i++ {
+		require.Len(t, keeper.ApplyPendingCommissionChanges(ctx), 0)
+	}
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), validator.Commission.Rate)
+}
+
+func TestCommissionChangeNoticeParam(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CommissionSchedule, 100)
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 100, Time: time.Now().UTC()}).WithBlockHeight(100)
+
+	// the default notice applies until the param is set
+	require.Equal(t, types.DefaultCommissionChangeNoticeBreatheBlocks, keeper.CommissionChangeNoticeBreatheBlocks(ctx))
+
+	params := types.DefaultParams()
+	params.BondDenom = sdk.NativeTokenSymbol
+	params.CommissionChangeNoticeBreatheBlocks = 5
+	require.Nil(t, params.UpdateCheck())
+	keeper.SetParams(ctx, params)
+	require.Equal(t, int64(5), keeper.GetParams(ctx).CommissionChangeNoticeBreatheBlocks)
+
+	commission := types.NewCommission(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(3, 1), sdk.NewDecWithPrec(1, 1))
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, _ = validator.SetInitialCommission(commission)
+	keeper.SetValidator(ctx, validator)
+	change, err := keeper.QueueCommissionChange(ctx, validator, sdk.NewDecWithPrec(2, 1))
+	require.Nil(t, err)
+	require.Equal(t, int64(5), change.BreatheBlocksLeft)
+
+	params.CommissionChangeNoticeBreatheBlocks = 0
+	require.NotNil(t, params.UpdateCheck())
+}
@@ -849,8 +849,7 @@ func (k Keeper) crossDistributeUndelegated(ctx sdk.Context, delAddr sdk.AccAddre
 		return sdk.Events{}, sdk.ErrInternal(err.Error())
 	}
 
-	sendSeq, sdkErr := k.ibcKeeper.CreateRawIBCPackageByIdWithFee(ctx.DepriveSideChainKeyPrefix(), k.DestChainId, types.CrossStakeChannelID,
-		sdk.SynCrossChainPackageType, encodedPackage, *bscRelayFee)
+	sendSeq, sdkErr := k.sendCrossStakePackage(ctx.DepriveSideChainKeyPrefix(), encodedPackage, bscRelayFee)
 	if sdkErr != nil {
 		return sdk.Events{}, sdkErr
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -66,6 +67,7 @@ func (k Keeper) Distribute(ctx sdk.Context, sideChainId string) {
 				if _, _, err := k.BankKeeper.AddCoins(ctx, rewards[i].AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, rewards[i].Amount)}); err != nil {
 					panic(err)
 				}
+				k.compoundLiquidStakeReward(ctx, sideChainId, validator.OperatorAddr, rewards[i].AccAddr)
 				changedAddrs[i] = rewards[i].AccAddr
 			}
 
@@ -205,8 +207,11 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) sdk
 			//distribute commission
 			commission = totalRewardDec.Mul(validator.Commission.Rate)
 			if commission.RawInt() > 0 {
-				if _, _, err := k.BankKeeper.AddCoins(ctx, validator.GetFeeAddr(), sdk.Coins{sdk.NewCoin(bondDenom, commission.RawInt())}); err != nil {
-					panic(err)
+				// the pledged share of the commission goes to the insurance pool of the validator
+				if feeCommission := k.pledgeCommission(ctx, validator.OperatorAddr, commission.RawInt()); feeCommission > 0 {
+					if _, _, err := k.BankKeeper.AddCoins(ctx, validator.GetFeeAddr(), sdk.Coins{sdk.NewCoin(bondDenom, feeCommission)}); err != nil {
+						panic(err)
+					}
 				}
 				if _, _, err := k.BankKeeper.SubtractCoins(ctx, validator.DistributionAddr, sdk.Coins{sdk.NewCoin(bondDenom, commission.RawInt())}); err != nil {
 					panic(err)
@@ -346,8 +351,13 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 			reward.AccAddr = rewardCAoB
 		}
 
-		if _, _, err := k.BankKeeper.AddCoins(ctx, reward.AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, reward.Amount)}); err != nil {
-			panic(err)
+		if !reward.CrossStake && k.compoundReward(ctx, reward.ValAddr, reward.AccAddr, reward.Amount) {
+			changedAddrs = append(changedAddrs, AutoCompoundAccAddr, DelegationAccAddr)
+		} else {
+			if _, _, err := k.BankKeeper.AddCoins(ctx, reward.AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, reward.Amount)}); err != nil {
+				panic(err)
+			}
+			k.compoundLiquidStakeReward(ctx, sideChainId, reward.ValAddr, reward.AccAddr)
 		}
 
 		toPublishRewards = append(toPublishRewards, reward)
@@ -467,8 +477,7 @@ func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress,
 		return sdk.Events{}, err
 	}
 
-	sendSeq, sdkErr := k.ibcKeeper.CreateRawIBCPackageByIdWithFee(ctx.DepriveSideChainKeyPrefix(), k.DestChainId, types.CrossStakeChannelID, sdk.SynCrossChainPackageType,
-		encodedPackage, *bscRelayFee)
+	sendSeq, sdkErr := k.sendCrossStakePackage(ctx.DepriveSideChainKeyPrefix(), encodedPackage, bscRelayFee)
 	if sdkErr != nil {
 		return sdk.Events{}, sdkErr
 	}
@@ -504,6 +513,18 @@ func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress,
 	return events, nil
 }
 
+// sendCrossStakePackage writes a distribute package to the side chain. After the IBCPackageTimeout upgrade
+// the package is refunded by the cross stake app if it is not confirmed within types.CrossStakePackageTimeout.
+func (k Keeper) sendCrossStakePackage(ctx sdk.Context, encodedPackage []byte, bscRelayFee *big.Int) (uint64, sdk.Error) {
+	if sdk.IsUpgrade(sdk.IBCPackageTimeout) {
+		timeout := ibc.PackageTimeout{Time: ctx.BlockHeader().Time.Unix() + types.CrossStakePackageTimeout}
+		return k.ibcKeeper.CreateRawIBCPackageByIdWithFeeAndTimeout(ctx, k.DestChainId, types.CrossStakeChannelID,
+			sdk.SynCrossChainPackageType, encodedPackage, *bscRelayFee, timeout)
+	}
+	return k.ibcKeeper.CreateRawIBCPackageByIdWithFee(ctx, k.DestChainId, types.CrossStakeChannelID,
+		sdk.SynCrossChainPackageType, encodedPackage, *bscRelayFee)
+}
+
 func (k Keeper) GetPrevProposerDistributionAddr(ctx sdk.Context) sdk.AccAddress {
 	store := ctx.KVStore(k.storeKey)
 	bz := store.Get(PrevProposerDistributionAddrKey)
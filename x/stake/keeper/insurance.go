@@ -0,0 +1,195 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the insurance pool of a validator
+func (k Keeper) GetInsurancePool(ctx sdk.Context, valAddr sdk.ValAddress) (pool types.InsurancePool, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(GetInsurancePoolKey(valAddr))
+	if value == nil {
+		return pool, false
+	}
+	return types.MustUnmarshalInsurancePool(k.cdc, value), true
+}
+
+// set the insurance pool of a validator
+func (k Keeper) SetInsurancePool(ctx sdk.Context, pool types.InsurancePool) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetInsurancePoolKey(pool.ValidatorAddr), types.MustMarshalInsurancePool(k.cdc, pool))
+}
+
+// get the insurance claim of a validator at a height
+func (k Keeper) GetInsuranceClaim(ctx sdk.Context, valAddr sdk.ValAddress, height int64) (claim types.InsuranceClaim, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(GetInsuranceClaimKey(valAddr, height))
+	if value == nil {
+		return claim, false
+	}
+	return types.MustUnmarshalInsuranceClaim(k.cdc, value), true
+}
+
+// set the insurance claim of a validator at a height
+func (k Keeper) SetInsuranceClaim(ctx sdk.Context, claim types.InsuranceClaim) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetInsuranceClaimKey(claim.ValidatorAddr, claim.Height), types.MustMarshalInsuranceClaim(k.cdc, claim))
+}
+
+// get all the insurance claims of a validator, by height
+func (k Keeper) GetInsuranceClaims(ctx sdk.Context, valAddr sdk.ValAddress) (claims []types.InsuranceClaim) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetInsuranceClaimsKey(valAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		claims = append(claims, types.MustUnmarshalInsuranceClaim(k.cdc, iterator.Value()))
+	}
+	return claims
+}
+
+// SetInsurancePoolRules opens the insurance pool of a validator, or updates the
+// share of the commission pledged to it and the share of the slash losses of
+// the delegators it covers
+func (k Keeper) SetInsurancePoolRules(ctx sdk.Context, valAddr sdk.ValAddress, commissionPledge, coverageRatio sdk.Dec) sdk.Error {
+	if _, found := k.GetValidator(ctx, valAddr); !found {
+		return types.ErrNoValidatorFound(k.Codespace())
+	}
+	pool, found := k.GetInsurancePool(ctx, valAddr)
+	if !found {
+		pool = types.InsurancePool{ValidatorAddr: valAddr}
+	}
+	pool.CommissionPledge = commissionPledge
+	pool.CoverageRatio = coverageRatio
+	k.SetInsurancePool(ctx, pool)
+	return nil
+}
+
+// DepositInsurancePool pledges tokens of from to the insurance pool of a validator
+func (k Keeper) DepositInsurancePool(ctx sdk.Context, from sdk.AccAddress, valAddr sdk.ValAddress, amount sdk.Coin) sdk.Error {
+	pool, found := k.GetInsurancePool(ctx, valAddr)
+	if !found {
+		return types.ErrNoInsurancePool(k.Codespace())
+	}
+	if amount.Denom != k.BondDenom(ctx) {
+		return types.ErrBadDenom(k.Codespace())
+	}
+	if err := k.transferBondTokens(ctx, from, InsurancePoolAccAddr, amount); err != nil {
+		return err
+	}
+	pool.Balance += amount.Amount
+	k.SetInsurancePool(ctx, pool)
+
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{from, InsurancePoolAccAddr})
+	}
+	return nil
+}
+
+// pledgeCommission pays the pledged share of the commission of a validator to
+// its insurance pool, and returns the rest of the commission
+func (k Keeper) pledgeCommission(ctx sdk.Context, valAddr sdk.ValAddress, commission int64) int64 {
+	if !sdk.IsUpgrade(sdk.SlashInsurance) {
+		return commission
+	}
+	pool, found := k.GetInsurancePool(ctx, valAddr)
+	if !found {
+		return commission
+	}
+	pledged := sdk.NewDec(commission).Mul(pool.CommissionPledge).RawInt()
+	if pledged <= 0 {
+		return commission
+	}
+	if _, _, err := k.BankKeeper.AddCoins(ctx, InsurancePoolAccAddr, sdk.Coins{sdk.NewCoin(k.BondDenom(ctx), pledged)}); err != nil {
+		panic(err)
+	}
+	pool.Balance += pledged
+	k.SetInsurancePool(ctx, pool)
+
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{InsurancePoolAccAddr})
+	}
+	return commission - pledged
+}
+
+// reimburseSlashLoss pays back from the insurance pool of a slashed validator
+// the covered share of the tokens each delegator lost, the self-delegation is
+// not covered. The payouts are cut pro rata when the pool cannot afford them.
+func (k Keeper) reimburseSlashLoss(ctx sdk.Context, validator types.Validator, slashedTokens sdk.Dec) {
+	pool, found := k.GetInsurancePool(ctx, validator.OperatorAddr)
+	if !found || pool.Balance <= 0 || pool.CoverageRatio.IsZero() ||
+		slashedTokens.RawInt() <= 0 || validator.DelegatorShares.IsZero() {
+		return
+	}
+
+	claim, found := k.GetInsuranceClaim(ctx, validator.OperatorAddr, ctx.BlockHeight())
+	if !found {
+		claim = types.InsuranceClaim{ValidatorAddr: validator.OperatorAddr, Height: ctx.BlockHeight()}
+	}
+	var payouts []types.InsurancePayout
+	var loss, covered int64
+	for _, del := range k.GetSimplifiedDelegationsByValidator(ctx, validator.OperatorAddr) {
+		if del.DelegatorAddr.Equals(validator.FeeAddr) {
+			continue
+		}
+		delLoss, err := sdk.MulQuoDec(slashedTokens, del.Shares, validator.DelegatorShares)
+		if err != nil {
+			panic(err)
+		}
+		if delLoss.RawInt() <= 0 {
+			continue
+		}
+		payout := types.InsurancePayout{
+			DelegatorAddr: del.DelegatorAddr,
+			Loss:          delLoss.RawInt(),
+			Amount:        delLoss.Mul(pool.CoverageRatio).RawInt(),
+		}
+		payouts = append(payouts, payout)
+		loss += payout.Loss
+		covered += payout.Amount
+	}
+	if covered <= 0 {
+		return
+	}
+
+	reimbursed := int64(0)
+	bondDenom := k.BondDenom(ctx)
+	changedAddrs := make([]sdk.AccAddress, 0, len(payouts)+1)
+	for i := range payouts {
+		if covered > pool.Balance {
+			amount, err := sdk.MulQuoDec(sdk.NewDec(payouts[i].Amount), sdk.NewDec(pool.Balance), sdk.NewDec(covered))
+			if err != nil {
+				panic(err)
+			}
+			payouts[i].Amount = amount.RawInt()
+		}
+		if payouts[i].Amount <= 0 {
+			continue
+		}
+		if _, _, err := k.BankKeeper.AddCoins(ctx, payouts[i].DelegatorAddr, sdk.Coins{sdk.NewCoin(bondDenom, payouts[i].Amount)}); err != nil {
+			panic(err)
+		}
+		reimbursed += payouts[i].Amount
+		changedAddrs = append(changedAddrs, payouts[i].DelegatorAddr)
+	}
+	if _, _, err := k.BankKeeper.SubtractCoins(ctx, InsurancePoolAccAddr, sdk.Coins{sdk.NewCoin(bondDenom, reimbursed)}); err != nil {
+		panic(err)
+	}
+	pool.Balance -= reimbursed
+	pool.Reimbursed += reimbursed
+	k.SetInsurancePool(ctx, pool)
+
+	claim.Loss += loss
+	claim.Reimbursed += reimbursed
+	claim.Payouts = append(claim.Payouts, payouts...)
+	k.SetInsuranceClaim(ctx, claim)
+
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs(append(changedAddrs, InsurancePoolAccAddr))
+	}
+	k.Logger(ctx).Info(fmt.Sprintf("insurance pool of validator %s reimbursed %d of %d tokens lost by delegators",
+		validator.OperatorAddr, reimbursed, loss))
+}
@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestInsurancePool(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BEP159, 1)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SlashInsurance, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	bondDenom := keeper.BondDenom(ctx)
+	operator := sdk.AccAddress(addrVals[0])
+
+	require.NotNil(t, keeper.SetInsurancePoolRules(ctx, addrVals[0], sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1)))
+
+	pool := keeper.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 10e8)
+	keeper.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+	_, err := keeper.Delegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, 10e8), validator, true)
+	require.Nil(t, err)
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	_, err = keeper.Delegate(ctx, addrDels[1], sdk.NewCoin(bondDenom, 30e8), validator, true)
+	require.Nil(t, err)
+
+	// deposits need an open pool and the bond denom
+	require.NotNil(t, keeper.DepositInsurancePool(ctx, operator, addrVals[0], sdk.NewCoin(bondDenom, 1e8)))
+	require.Nil(t, keeper.SetInsurancePoolRules(ctx, addrVals[0], sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1)))
+	require.NotNil(t, keeper.DepositInsurancePool(ctx, operator, addrVals[0], sdk.NewCoin("btc", 1e8)))
+	require.Nil(t, keeper.DepositInsurancePool(ctx, operator, addrVals[0], sdk.NewCoin(bondDenom, 1e8)))
+	require.Equal(t, int64(99e8), keeper.BankKeeper.GetCoins(ctx, operator).AmountOf(bondDenom))
+
+	// the pledged share of the commission goes to the pool
+	require.Equal(t, int64(5e7), keeper.pledgeCommission(ctx, addrVals[0], 1e8))
+	require.Equal(t, int64(1e8), keeper.pledgeCommission(ctx, addrVals[1], 1e8))
+	insurancePool, found := keeper.GetInsurancePool(ctx, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, int64(15e7), insurancePool.Balance)
+	require.Equal(t, int64(15e7), keeper.BankKeeper.GetCoins(ctx, InsurancePoolAccAddr).AmountOf(bondDenom))
+
+	// the covered share of the losses of the delegators is reimbursed
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	keeper.reimburseSlashLoss(ctx, validator, sdk.NewDec(1e8))
+	require.Equal(t, int64(90e8+1e7), keeper.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(bondDenom))
+	require.Equal(t, int64(70e8+3e7), keeper.BankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(bondDenom))
+	insurancePool, _ = keeper.GetInsurancePool(ctx, addrVals[0])
+	require.Equal(t, int64(11e7), insurancePool.Balance)
+	require.Equal(t, int64(4e7), insurancePool.Reimbursed)
+
+	// the payouts are cut pro rata when the pool cannot afford them
+	keeper.reimburseSlashLoss(ctx, validator, sdk.NewDec(10e8))
+	require.Equal(t, int64(90e8+1e7+275e5), keeper.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(bondDenom))
+	require.Equal(t, int64(70e8+3e7+825e5), keeper.BankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(bondDenom))
+	insurancePool, _ = keeper.GetInsurancePool(ctx, addrVals[0])
+	require.Equal(t, int64(0), insurancePool.Balance)
+	require.Equal(t, int64(15e7), insurancePool.Reimbursed)
+	require.Equal(t, int64(0), keeper.BankKeeper.GetCoins(ctx, InsurancePoolAccAddr).AmountOf(bondDenom))
+
+	claims := keeper.GetInsuranceClaims(ctx, addrVals[0])
+	require.Len(t, claims, 1)
+	require.Equal(t, int64(88e7), claims[0].Loss)
+	require.Equal(t, int64(15e7), claims[0].Reimbursed)
+	require.Len(t, claims[0].Payouts, 4)
+}
+
+func TestSideChainInsurancePool(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BEP159, 1)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SlashInsurance, 1)
+	bondDenom := keeper.BondDenom(ctx)
+	operator := sdk.AccAddress(addrVals[0])
+	sideConsAddr := []byte("side_cons_addr_of_validator_0")
+
+	keeper.ScKeeper.SetSideChainIdAndStorePrefix(ctx, "bsc", []byte{0x99})
+	sideCtx, err := keeper.ScKeeper.PrepareCtxForSideChain(ctx, "bsc")
+	require.Nil(t, err)
+	keeper.SetParams(sideCtx, keeper.GetParams(ctx))
+	keeper.SetPool(sideCtx, types.InitialPool())
+
+	validator := types.NewSideChainValidator(operator, addrVals[0], types.Description{}, "bsc", sideConsAddr, []byte("side_fee_addr"))
+	keeper.SetValidator(sideCtx, validator)
+	keeper.SetValidatorByConsAddr(sideCtx, validator)
+	_, err = keeper.Delegate(sideCtx, operator, sdk.NewCoin(bondDenom, 10e8), validator, true)
+	require.Nil(t, err)
+	validator, _ = keeper.GetValidator(sideCtx, addrVals[0])
+	_, err = keeper.Delegate(sideCtx, addrDels[0], sdk.NewCoin(bondDenom, 10e8), validator, true)
+	require.Nil(t, err)
+	validator, _ = keeper.GetValidator(sideCtx, addrVals[0])
+	_, err = keeper.Delegate(sideCtx, addrDels[1], sdk.NewCoin(bondDenom, 30e8), validator, true)
+	require.Nil(t, err)
+	validator, _ = keeper.GetValidator(sideCtx, addrVals[0])
+	validator.Status = sdk.Unbonding
+	keeper.SetValidator(sideCtx, validator)
+
+	// the pool of a side chain validator lives in the store of its side chain
+	require.Nil(t, keeper.SetInsurancePoolRules(sideCtx, addrVals[0], sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1)))
+	require.Nil(t, keeper.DepositInsurancePool(sideCtx, operator, addrVals[0], sdk.NewCoin(bondDenom, 1e8)))
+	require.Equal(t, int64(5e7), keeper.pledgeCommission(sideCtx, addrVals[0], 1e8))
+	_, found := keeper.GetInsurancePool(ctx, addrVals[0])
+	require.False(t, found)
+	insurancePool, found := keeper.GetInsurancePool(sideCtx, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, int64(15e7), insurancePool.Balance)
+
+	// the self-delegation is slashed and the delegators get the covered share
+	// of their loss back
+	_, slashedAmt, err := keeper.SlashSideChain(ctx, "bsc", sideConsAddr, sdk.NewDec(2e8))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(2e8), slashedAmt)
+	require.Equal(t, int64(90e8+2e7), keeper.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(bondDenom))
+	require.Equal(t, int64(70e8+6e7), keeper.BankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(bondDenom))
+	insurancePool, _ = keeper.GetInsurancePool(sideCtx, addrVals[0])
+	require.Equal(t, int64(7e7), insurancePool.Balance)
+	require.Equal(t, int64(8e7), insurancePool.Reimbursed)
+	require.Equal(t, int64(7e7), keeper.BankKeeper.GetCoins(ctx, InsurancePoolAccAddr).AmountOf(bondDenom))
+
+	claims := keeper.GetInsuranceClaims(sideCtx, addrVals[0])
+	require.Len(t, claims, 1)
+	require.Equal(t, int64(16e7), claims[0].Loss)
+	require.Equal(t, int64(8e7), claims[0].Reimbursed)
+	require.Len(t, keeper.GetInsuranceClaims(ctx, addrVals[0]), 0)
+}
@@ -3,7 +3,6 @@ package keeper
 import (
 	"fmt"
 
-	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -40,6 +39,9 @@ type Keeper struct {
 	DestChainName string
 
 	PbsbServer *pubsub.Server
+
+	// optional, keeps the receipt denoms of the liquid stake in the token store
+	receiptTokens types.ReceiptTokenRegistry
 }
 
 func NewKeeper(cdc *codec.Codec, key, rewardKey, tkey sdk.StoreKey, ck bank.Keeper, addrPool *sdk.Pool,
@@ -241,6 +243,10 @@ func (k *Keeper) SubscribeBCParamChange(hub pTypes.BCParamChangePublisher) {
 	)
 }
 
+func (k *Keeper) RegisterPackageTypes(codec *sdk.CrossChainPackageCodec, channelID sdk.ChannelID) error {
+	return codec.RegisterPackageType(channelID, sdk.AckCrossChainPackageType, sTypes.CommonAckPackage{})
+}
+
 // cross chain app implement
 func (k *Keeper) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
 	panic("receive unexpected syn package")
@@ -248,12 +254,12 @@ func (k *Keeper) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk
 
 func (k *Keeper) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
 	logger := ctx.Logger().With("module", "stake")
-	var ackPackage sTypes.CommonAckPackage
-	err := rlp.DecodeBytes(payload, &ackPackage)
+	decoded, err := k.ScKeeper.DecodePackagePayload(ChannelId, sdk.AckCrossChainPackageType, payload)
 	if err != nil {
 		logger.Error("fail to decode ack package", "payload", payload)
 		return sdk.ExecuteResult{Err: types.ErrInvalidCrosschainPackage(k.codespace)}
 	}
+	ackPackage := decoded.(*sTypes.CommonAckPackage)
 	if !ackPackage.IsOk() {
 		logger.Error("side chain failed to process staking package", "code", ackPackage.Code)
 	}
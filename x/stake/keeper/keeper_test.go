@@ -18,6 +18,7 @@ func TestParams(t *testing.T) {
 	expParams.BonusProposerRewardRatio = sdk.ZeroDec()
 	expParams.MaxStakeSnapshots = uint16(0)
 	expParams.FeeFromBscToBcRatio = sdk.ZeroDec()
+	expParams.CommissionChangeNoticeBreatheBlocks = int64(0)
 
 	//check that the empty keeper loads the default
 	resParams := keeper.GetParams(ctx)
@@ -46,6 +46,12 @@ var (
 
 	SideChainStorePrefixByIdKey = []byte{0x51} // prefix for each key to a side chain store prefix, by side chain id
 
+	LiquidStakeReceiptKey = []byte{0x61} // prefix for each key to a liquid stake receipt, by denom
+	AutoCompoundKey       = []byte{0x62} // prefix for each key to an auto compound delegation, by delegator and validator
+	CommissionChangeKey   = []byte{0x63} // prefix for each key to a pending commission change, by validator operator
+	InsurancePoolKey      = []byte{0x64} // prefix for each key to an insurance pool, by validator operator
+	InsuranceClaimKey     = []byte{0x65} // prefix for each key to an insurance claim, by validator operator and height
+
 	// Keys for reward store prefix
 	RewardBatchKey       = []byte{0x01} // key for batch of rewards
 	RewardValDistAddrKey = []byte{0x02} // key for rewards' validator <-> distribution address mapping
@@ -335,3 +341,40 @@ func GetREDsByDelToValDstIndexKey(delAddr sdk.AccAddress, valDstAddr sdk.ValAddr
 func GetValLatestUpdateConsAddrTimeKey(valAddr sdk.ValAddress) []byte {
 	return append(ValLatestUpdateConsAddrTimeKey, valAddr.Bytes()...)
 }
+
+// gets the key for the liquid stake receipt of a denom
+// VALUE: stake/types.LiquidStakeReceipt
+func GetLiquidStakeReceiptKey(denom string) []byte {
+	return append(LiquidStakeReceiptKey, []byte(denom)...)
+}
+
+// gets the key for the auto compound of a delegation
+// VALUE: stake/types.AutoCompound
+func GetAutoCompoundKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(append(AutoCompoundKey, delAddr.Bytes()...), valAddr.Bytes()...)
+}
+
+// gets the key for the pending commission change of a validator
+// VALUE: stake/types.PendingCommissionChange
+func GetCommissionChangeKey(valAddr sdk.ValAddress) []byte {
+	return append(CommissionChangeKey, valAddr.Bytes()...)
+}
+
+// gets the key for the insurance pool of a validator
+// VALUE: stake/types.InsurancePool
+func GetInsurancePoolKey(valAddr sdk.ValAddress) []byte {
+	return append(InsurancePoolKey, valAddr.Bytes()...)
+}
+
+// gets the prefix for the insurance claims of a validator
+func GetInsuranceClaimsKey(valAddr sdk.ValAddress) []byte {
+	return append(InsuranceClaimKey, valAddr.Bytes()...)
+}
+
+// gets the key for the insurance claim of a validator at a height
+// VALUE: stake/types.InsuranceClaim
+func GetInsuranceClaimKey(valAddr sdk.ValAddress, height int64) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, uint64(height))
+	return append(GetInsuranceClaimsKey(valAddr), heightBytes...)
+}
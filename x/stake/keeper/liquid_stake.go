@@ -0,0 +1,230 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the liquid stake receipt of a denom
+func (k Keeper) GetLiquidStakeReceipt(ctx sdk.Context, denom string) (receipt types.LiquidStakeReceipt, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(GetLiquidStakeReceiptKey(denom))
+	if value == nil {
+		return receipt, false
+	}
+	return types.MustUnmarshalLiquidStakeReceipt(k.cdc, value), true
+}
+
+// set the liquid stake receipt of a denom
+func (k Keeper) SetLiquidStakeReceipt(ctx sdk.Context, receipt types.LiquidStakeReceipt) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetLiquidStakeReceiptKey(receipt.Denom), types.MustMarshalLiquidStakeReceipt(k.cdc, receipt))
+}
+
+// SetReceiptTokenRegistry sets the registry keeping the receipt denoms in the
+// token store of the chain
+func (k *Keeper) SetReceiptTokenRegistry(registry types.ReceiptTokenRegistry) {
+	k.receiptTokens = registry
+}
+
+// updateReceiptSupply sets the receipt and the total supply of the token of its
+// denom
+func (k Keeper) updateReceiptSupply(ctx sdk.Context, receipt types.LiquidStakeReceipt) sdk.Error {
+	k.SetLiquidStakeReceipt(ctx, receipt)
+	if k.receiptTokens == nil {
+		return nil
+	}
+	if err := k.receiptTokens.SetReceiptToken(ctx, receipt); err != nil {
+		return sdk.ErrInternal(err.Error())
+	}
+	return nil
+}
+
+// burnLiquidStakeLeftover burns the shares of the liquid stake delegation of a
+// validator while none of its receipts is in circulation, e.g. the rewards
+// compounded after the last redemption. Their tokens leave the delegation
+// account as the slashed tokens of the side chains do.
+func (k Keeper) burnLiquidStakeLeftover(ctx sdk.Context, delegation types.Delegation) sdk.Error {
+	amount, err := k.unbond(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr, delegation.Shares)
+	if err != nil {
+		return err
+	}
+	burned := sdk.NewCoin(k.BondDenom(ctx), amount.RawInt())
+	if _, _, err = k.BankKeeper.SubtractCoins(ctx, DelegationAccAddr, sdk.Coins{burned}); err != nil {
+		return err
+	}
+	k.Logger(ctx).Info("burned the liquid stake leftover", "validator", delegation.ValidatorAddr, "amount", burned)
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{DelegationAccAddr})
+	}
+	return nil
+}
+
+// LiquidDelegate delegates the tokens of delAddr to the validator of the side
+// chain of ctx through the liquid stake address of the validator, and mints the
+// receipts of the new shares to delAddr. The receipts minted are returned.
+func (k Keeper) LiquidDelegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Coin,
+	validator types.Validator) (minted sdk.Coin, err sdk.Error) {
+
+	denom := types.GetReceiptDenom(ctx.SideChainId(), validator.OperatorAddr)
+	receipt, found := k.GetLiquidStakeReceipt(ctx, denom)
+	if !found {
+		// the denom must not be the symbol of an issued token
+		if k.receiptTokens != nil && k.receiptTokens.HasToken(ctx, denom) {
+			return minted, types.ErrReceiptDenomConflict(k.Codespace(), denom)
+		}
+		receipt = types.LiquidStakeReceipt{
+			Denom:         denom,
+			ValidatorAddr: validator.OperatorAddr,
+		}
+	} else if !bytes.Equal(receipt.ValidatorAddr, validator.OperatorAddr) {
+		return minted, types.ErrReceiptDenomConflict(k.Codespace(), denom)
+	}
+
+	liquidStakeAddr := types.GetLiquidStakeAddr(ctx.SideChainId(), validator.OperatorAddr)
+	sharesBefore := sdk.ZeroDec()
+	if delegation, found := k.GetDelegation(ctx, liquidStakeAddr, validator.OperatorAddr); found {
+		sharesBefore = delegation.Shares
+		if receipt.Supply == 0 {
+			// the shares left while no receipt is in circulation are not claimed
+			// by the receipts minted now
+			if err = k.burnLiquidStakeLeftover(ctx, delegation); err != nil {
+				return
+			}
+			sharesBefore = sdk.ZeroDec()
+			if validator, found = k.GetValidator(ctx, validator.OperatorAddr); !found {
+				return minted, types.ErrNoValidatorFound(k.Codespace())
+			}
+		}
+	}
+	if receipt.Supply > 0 && sharesBefore.IsZero() {
+		return minted, types.ErrReceiptWithoutShares(k.Codespace(), denom)
+	}
+
+	if err = k.transferBondTokens(ctx, delAddr, DelegationAccAddr, bondAmt); err != nil {
+		return
+	}
+	newShares, err := k.Delegate(ctx, liquidStakeAddr, bondAmt, validator, false)
+	if err != nil {
+		return
+	}
+
+	// the receipts are claims on the shares of the liquid stake address pro rata
+	// of the supply, the rewards compounded to the address raise their value
+	amount := sharesBefore.Add(newShares).RawInt()
+	if receipt.Supply > 0 {
+		mintedShares, errRes := sdk.MulQuoDec(newShares, sdk.NewDec(receipt.Supply), sharesBefore)
+		if errRes != nil {
+			return minted, sdk.ErrInternal(errRes.Error())
+		}
+		amount = mintedShares.RawInt()
+	}
+	minted = sdk.NewCoin(denom, amount)
+	if _, _, err = k.BankKeeper.AddCoins(ctx, delAddr, sdk.Coins{minted}); err != nil {
+		return
+	}
+	receipt.Supply += amount
+	if err = k.updateReceiptSupply(ctx, receipt); err != nil {
+		return
+	}
+
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{delAddr, DelegationAccAddr})
+	}
+	return minted, nil
+}
+
+// RedeemReceipt burns the receipts of holder and moves the shares they are a
+// claim on from the liquid stake address to a delegation of holder. The shares
+// moved are returned, the totals of the validator do not change.
+func (k Keeper) RedeemReceipt(ctx sdk.Context, holder sdk.AccAddress, amount sdk.Coin) (shares sdk.Dec, err sdk.Error) {
+	receipt, found := k.GetLiquidStakeReceipt(ctx, amount.Denom)
+	if !found {
+		return shares, types.ErrNoLiquidStakeReceipt(k.Codespace())
+	}
+	valAddr := receipt.ValidatorAddr
+	liquidStakeAddr := types.GetLiquidStakeAddr(ctx.SideChainId(), valAddr)
+	liquidDelegation, found := k.GetDelegation(ctx, liquidStakeAddr, valAddr)
+	if !found {
+		return shares, types.ErrNoDelegatorForAddress(k.Codespace())
+	}
+	if amount.Amount > receipt.Supply {
+		return shares, types.ErrNotEnoughDelegationShares(k.Codespace(), sdk.NewDec(receipt.Supply).String())
+	}
+
+	shares = liquidDelegation.Shares
+	if amount.Amount < receipt.Supply {
+		var errRes error
+		shares, errRes = sdk.MulQuoDec(liquidDelegation.Shares, sdk.NewDec(amount.Amount), sdk.NewDec(receipt.Supply))
+		if errRes != nil {
+			return shares, sdk.ErrInternal(errRes.Error())
+		}
+	}
+	if shares.IsZero() {
+		return shares, types.ErrInsufficientShares(k.Codespace())
+	}
+
+	if _, _, err = k.BankKeeper.SubtractCoins(ctx, holder, sdk.Coins{amount}); err != nil {
+		return
+	}
+	receipt.Supply -= amount.Amount
+	if err = k.updateReceiptSupply(ctx, receipt); err != nil {
+		return
+	}
+
+	k.OnDelegationSharesModified(ctx, liquidStakeAddr, valAddr)
+	liquidDelegation.Shares = liquidDelegation.Shares.Sub(shares)
+	if liquidDelegation.Shares.IsZero() {
+		k.RemoveDelegation(ctx, liquidDelegation)
+	} else {
+		liquidDelegation.Height = ctx.BlockHeight()
+		k.SetDelegation(ctx, liquidDelegation)
+	}
+
+	delegation, found := k.GetDelegation(ctx, holder, valAddr)
+	if found {
+		k.OnDelegationSharesModified(ctx, holder, valAddr)
+	} else {
+		delegation = types.Delegation{
+			DelegatorAddr: holder,
+			ValidatorAddr: valAddr,
+			Shares:        sdk.ZeroDec(),
+		}
+		k.OnDelegationCreated(ctx, holder, valAddr)
+	}
+	delegation.Shares = delegation.Shares.Add(shares)
+	delegation.Height = ctx.BlockHeight()
+	k.SetDelegation(ctx, delegation)
+
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{holder})
+	}
+	return shares, nil
+}
+
+// compoundLiquidStakeReward delegates back to a validator the rewards paid to
+// its liquid stake address, which raises the value of its receipts. The rewards
+// that can't be delegated are left to the address, and compounded with the
+// next ones.
+func (k Keeper) compoundLiquidStakeReward(ctx sdk.Context, sideChainId string, valAddr sdk.ValAddress, accAddr sdk.AccAddress) {
+	if !sdk.IsUpgrade(sdk.LiquidStaking) || !accAddr.Equals(types.GetLiquidStakeAddr(sideChainId, valAddr)) {
+		return
+	}
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return
+	}
+	bondDenom := k.BondDenom(ctx)
+	balance := k.BankKeeper.GetCoins(ctx, accAddr).AmountOf(bondDenom)
+	if balance <= 0 {
+		return
+	}
+	cacheCtx, write := ctx.CacheContext()
+	if _, err := k.Delegate(cacheCtx, accAddr, sdk.NewCoin(bondDenom, balance), validator, true); err != nil {
+		k.Logger(ctx).Error("failed to compound the liquid stake reward", "validator", valAddr, "amount", balance, "err", err)
+		return
+	}
+	write()
+}
@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestLiquidDelegateAndRedeemReceipt(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.LiquidStaking, 1)
+	ctx = ctx.WithSideChainId("bsc")
+	bondDenom := keeper.BondDenom(ctx)
+
+	pool := keeper.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 10e8)
+	keeper.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+
+	// the receipts are minted 1:1 with the shares of the first delegation
+	denom := types.GetReceiptDenom("bsc", addrVals[0])
+	liquidStakeAddr := types.GetLiquidStakeAddr("bsc", addrVals[0])
+	minted, err := keeper.LiquidDelegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, 10e8), validator)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewCoin(denom, 10e8), minted)
+	require.Equal(t, int64(90e8), keeper.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(bondDenom))
+	require.Equal(t, int64(10e8), keeper.BankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(denom))
+	delegation, found := keeper.GetDelegation(ctx, liquidStakeAddr, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(10e8), delegation.Shares)
+
+	// the rewards of the liquid stake address are compounded, a receipt is now
+	// worth 2 shares
+	_, _, err = keeper.BankKeeper.AddCoins(ctx, liquidStakeAddr, sdk.Coins{sdk.NewCoin(bondDenom, 10e8)})
+	require.Nil(t, err)
+	keeper.compoundLiquidStakeReward(ctx, "bsc", addrVals[0], liquidStakeAddr)
+	require.Equal(t, int64(0), keeper.BankKeeper.GetCoins(ctx, liquidStakeAddr).AmountOf(bondDenom))
+	delegation, _ = keeper.GetDelegation(ctx, liquidStakeAddr, addrVals[0])
+	require.Equal(t, sdk.NewDec(20e8), delegation.Shares)
+
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	minted, err = keeper.LiquidDelegate(ctx, addrDels[1], sdk.NewCoin(bondDenom, 10e8), validator)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewCoin(denom, 5e8), minted)
+	receipt, found := keeper.GetLiquidStakeReceipt(ctx, denom)
+	require.True(t, found)
+	require.Equal(t, int64(15e8), receipt.Supply)
+
+	// the receipts are transferable, and redeemed for the shares they are worth
+	_, err = keeper.BankKeeper.SendCoins(ctx, addrDels[0], Addrs[10], sdk.Coins{sdk.NewCoin(denom, 5e8)})
+	require.Nil(t, err)
+	shares, err := keeper.RedeemReceipt(ctx, Addrs[10], sdk.NewCoin(denom, 5e8))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10e8), shares)
+	delegation, found = keeper.GetDelegation(ctx, Addrs[10], addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(10e8), delegation.Shares)
+	require.Equal(t, int64(0), keeper.BankKeeper.GetCoins(ctx, Addrs[10]).AmountOf(denom))
+
+	// the redemptions do not change the totals of the validator
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	require.Equal(t, sdk.NewDec(40e8), validator.DelegatorShares)
+	require.Equal(t, sdk.NewDec(40e8), validator.Tokens)
+
+	_, err = keeper.RedeemReceipt(ctx, Addrs[10], sdk.NewCoin(denom, 1e8))
+	require.NotNil(t, err)
+	_, err = keeper.RedeemReceipt(ctx, addrDels[0], sdk.NewCoin("LST00000-000000", 1e8))
+	require.NotNil(t, err)
+
+	// the delegation of the liquid stake address is removed with the last receipt
+	_, err = keeper.RedeemReceipt(ctx, addrDels[0], sdk.NewCoin(denom, 5e8))
+	require.Nil(t, err)
+	shares, err = keeper.RedeemReceipt(ctx, addrDels[1], sdk.NewCoin(denom, 5e8))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10e8), shares)
+	_, found = keeper.GetDelegation(ctx, liquidStakeAddr, addrVals[0])
+	require.False(t, found)
+	receipt, _ = keeper.GetLiquidStakeReceipt(ctx, denom)
+	require.Equal(t, int64(0), receipt.Supply)
+}
+
+// testReceiptTokens is a ReceiptTokenRegistry keeping the supply of the tokens
+type testReceiptTokens map[string]int64
+
+func (r testReceiptTokens) HasToken(_ sdk.Context, symbol string) bool {
+	_, ok := r[symbol]
+	return ok
+}
+
+func (r testReceiptTokens) SetReceiptToken(_ sdk.Context, receipt types.LiquidStakeReceipt) error {
+	r[receipt.Denom] = receipt.Supply
+	return nil
+}
+
+func TestLiquidStakeShareMath(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.LiquidStaking, 1)
+	ctx = ctx.WithSideChainId("bsc")
+	bondDenom := keeper.BondDenom(ctx)
+	tokens := testReceiptTokens{}
+	keeper.SetReceiptTokenRegistry(tokens)
+
+	pool := keeper.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 10e8)
+	keeper.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+	denom := types.GetReceiptDenom("bsc", addrVals[0])
+	liquidStakeAddr := types.GetLiquidStakeAddr("bsc", addrVals[0])
+
+	// the receipts are registered as tokens, their denom can't be an issued token
+	tokens["LST-TAKEN"] = 1
+	_, err := keeper.LiquidDelegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, 10e8), validator)
+	require.Nil(t, err)
+	require.Equal(t, int64(10e8), tokens[denom])
+	validator2 := types.NewValidator(addrVals[1], PKs[1], types.Description{})
+	validator2, pool, _ = validator2.AddTokensFromDel(keeper.GetPool(ctx), 10e8)
+	keeper.SetPool(ctx, pool)
+	validator2 = TestingUpdateValidator(keeper, ctx, validator2)
+	tokens[types.GetReceiptDenom("bsc", addrVals[1])] = 1
+	_, err = keeper.LiquidDelegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, 10e8), validator2)
+	require.NotNil(t, err)
+
+	// the rewards compounded after the last redemption are burned before new
+	// receipts are minted
+	_, err = keeper.RedeemReceipt(ctx, addrDels[0], sdk.NewCoin(denom, 10e8))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), tokens[denom])
+	_, _, err = keeper.BankKeeper.AddCoins(ctx, liquidStakeAddr, sdk.Coins{sdk.NewCoin(bondDenom, 4e8)})
+	require.Nil(t, err)
+	keeper.compoundLiquidStakeReward(ctx, "bsc", addrVals[0], liquidStakeAddr)
+	delegation, found := keeper.GetDelegation(ctx, liquidStakeAddr, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(4e8), delegation.Shares)
+	delegationBalance := keeper.BankKeeper.GetCoins(ctx, DelegationAccAddr).AmountOf(bondDenom)
+
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	minted, err := keeper.LiquidDelegate(ctx, addrDels[1], sdk.NewCoin(bondDenom, 10e8), validator)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewCoin(denom, 10e8), minted)
+	delegation, _ = keeper.GetDelegation(ctx, liquidStakeAddr, addrVals[0])
+	require.Equal(t, sdk.NewDec(10e8), delegation.Shares)
+	require.Equal(t, delegationBalance-4e8+10e8, keeper.BankKeeper.GetCoins(ctx, DelegationAccAddr).AmountOf(bondDenom))
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	require.Equal(t, sdk.NewDec(30e8), validator.Tokens)
+
+	// receipts in circulation without shares to claim can't be diluted
+	_, err = keeper.RedeemReceipt(ctx, addrDels[1], sdk.NewCoin(denom, 10e8))
+	require.Nil(t, err)
+	keeper.SetLiquidStakeReceipt(ctx, types.LiquidStakeReceipt{Denom: denom, ValidatorAddr: addrVals[0], Supply: 1e8})
+	balance := keeper.BankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(bondDenom)
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	_, err = keeper.LiquidDelegate(ctx, addrDels[1], sdk.NewCoin(bondDenom, 10e8), validator)
+	require.NotNil(t, err)
+	require.Equal(t, balance, keeper.BankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(bondDenom))
+}
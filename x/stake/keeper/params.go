@@ -19,11 +19,26 @@ var (
 	FeeCollectorAddr       = sdk.AccAddress(crypto.AddressHash([]byte("FeeCollector")))
 	DelegationAccAddr      = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeDelegation")))
 	FeeForAllBcValsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeFeeForAllBcVals")))
+	AutoCompoundAccAddr    = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeAutoCompound")))
+	InsurancePoolAccAddr   = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeInsurancePool")))
 )
 
-// ParamTable for stake module
+// ParamTypeTable for stake module, the params added by upgrades are registered
+// whether their upgrade is active or not
 func ParamTypeTable() params.TypeTable {
-	return params.NewTypeTable().RegisterParamSet(&types.Params{})
+	return params.NewTypeTable(
+		types.KeyUnbondingTime, time.Duration(0),
+		types.KeyMaxValidators, uint16(0),
+		types.KeyBondDenom, "",
+		types.KeyMinSelfDelegation, int64(0),
+		types.KeyMinDelegationChange, int64(0),
+		types.KeyRewardDistributionBatchSize, int64(0),
+		types.KeyMaxStakeSnapshots, uint16(0),
+		types.KeyBaseProposerRewardRatio, sdk.Dec{},
+		types.KeyBonusProposerRewardRatio, sdk.Dec{},
+		types.KeyFeeFromBscToBcRatio, sdk.Dec{},
+		types.KeyCommissionChangeNoticeBreatheBlocks, int64(0),
+	)
 }
 
 // UnbondingTime
@@ -79,6 +94,16 @@ func (k Keeper) FeeFromBscToBcRatio(ctx sdk.Context) (res sdk.Dec) {
 	return
 }
 
+// CommissionChangeNoticeBreatheBlocks - the breathe blocks before a commission
+// rate change takes effect, the default one until the param is set
+func (k Keeper) CommissionChangeNoticeBreatheBlocks(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyCommissionChangeNoticeBreatheBlocks, &res)
+	if res <= 0 {
+		return types.DefaultCommissionChangeNoticeBreatheBlocks
+	}
+	return
+}
+
 // Get all parameters as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.UnbondingTime = k.UnbondingTime(ctx)
@@ -91,6 +116,9 @@ func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.BonusProposerRewardRatio = k.BonusProposerRewardRatio(ctx)
 	res.MaxStakeSnapshots = k.MaxStakeSnapshots(ctx)
 	res.FeeFromBscToBcRatio = k.FeeFromBscToBcRatio(ctx)
+	if sdk.IsUpgrade(sdk.CommissionSchedule) {
+		res.CommissionChangeNoticeBreatheBlocks = k.CommissionChangeNoticeBreatheBlocks(ctx)
+	}
 	return
 }
 
@@ -180,4 +208,7 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 		k.paramstore.Set(ctx, types.KeyBonusProposerRewardRatio, params.BonusProposerRewardRatio)
 		k.paramstore.Set(ctx, types.KeyFeeFromBscToBcRatio, params.FeeFromBscToBcRatio)
 	}
+	if sdk.IsUpgrade(sdk.CommissionSchedule) {
+		k.paramstore.Set(ctx, types.KeyCommissionChangeNoticeBreatheBlocks, params.CommissionChangeNoticeBreatheBlocks)
+	}
 }
@@ -107,6 +107,11 @@ func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeigh
 	pool.LooseTokens = pool.LooseTokens.Sub(tokensToBurn)
 	k.SetPool(ctx, pool)
 
+	// the delegators are reimbursed by the insurance pool of the validator if any
+	if sdk.IsUpgrade(sdk.SlashInsurance) {
+		k.reimburseSlashLoss(ctx, validator, tokensToBurn)
+	}
+
 	// remove validator if it has no more tokens
 	if validator.DelegatorShares.IsZero() && validator.Status == sdk.Unbonded {
 		// if not unbonded, we must instead remove validator in EndBlocker once it finishes its unbonding period
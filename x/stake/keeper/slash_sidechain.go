@@ -74,6 +74,14 @@ func (k Keeper) SlashSideChain(ctx sdk.Context, sideChainId string, sideConsAddr
 	if ctx.IsDeliverTx() && k.AddrPool != nil {
 		k.AddrPool.AddAddrs([]sdk.AccAddress{DelegationAccAddr})
 	}
+
+	// the slash is taken from the self-delegation, the delegators lose their
+	// share of it with the rewards of the jailed validator, which is reimbursed
+	// by the insurance pool of the validator if any
+	if sdk.IsUpgrade(sdk.SlashInsurance) {
+		k.reimburseSlashLoss(sideCtx, validator, slashedAmt)
+	}
+
 	if validator.IsBonded() {
 		ibcPackage := types.IbcValidatorSetPackage{
 			Type: types.JailPackageType,
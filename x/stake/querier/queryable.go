@@ -31,6 +31,11 @@ const (
 	QueryAllValidatorsCount            = "allValidatorsCount"
 	QueryAllUnJailValidatorsCount      = "allUnJailValidatorsCount"
 	QueryCrossStakeInfoByBscAddress    = "crossStakeInfoByBscAddress"
+	QueryLiquidStakeReceipt            = "liquidStakeReceipt"
+	QueryAutoCompound                  = "autoCompound"
+	QueryPendingCommissionChanges      = "pendingCommissionChanges"
+	QueryInsurancePool                 = "insurancePool"
+	QueryInsuranceClaims               = "insuranceClaims"
 )
 
 // creates a querier for staking REST endpoints
@@ -163,6 +168,41 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryCrossStakeInfoByBscAddress(ctx, cdc, p, k)
+		case QueryLiquidStakeReceipt:
+			p := new(QueryLiquidStakeReceiptParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryLiquidStakeReceipt(ctx, cdc, p, k)
+		case QueryAutoCompound:
+			p := new(QueryBondsParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryAutoCompound(ctx, cdc, p, k)
+		case QueryPendingCommissionChanges:
+			p := new(BaseParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryPendingCommissionChanges(ctx, cdc, k)
+		case QueryInsurancePool:
+			p := new(QueryValidatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryInsurancePool(ctx, cdc, p, k)
+		case QueryInsuranceClaims:
+			p := new(QueryValidatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryInsuranceClaims(ctx, cdc, p, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown stake query endpoint")
 		}
@@ -217,6 +257,8 @@ type QueryDelegatorParams struct {
 // - 'custom/stake/validator'
 // - 'custom/stake/validatorUnbondingDelegations'
 // - 'custom/stake/validatorRedelegations'
+// - 'custom/stake/insurancePool'
+// - 'custom/stake/insuranceClaims'
 type QueryValidatorParams struct {
 	BaseParams
 	ValidatorAddr sdk.ValAddress
@@ -226,6 +268,7 @@ type QueryValidatorParams struct {
 // - 'custom/stake/delegation'
 // - 'custom/stake/unbondingDelegation'
 // - 'custom/stake/delegatorValidator'
+// - 'custom/stake/autoCompound'
 type QueryBondsParams struct {
 	BaseParams
 	DelegatorAddr sdk.AccAddress
@@ -251,6 +294,12 @@ type QueryCrossStakeInfoParams struct {
 	BscAddress sdk.SmartChainAddress
 }
 
+// defines the params for 'custom/stake/liquidStakeReceipt'
+type QueryLiquidStakeReceiptParams struct {
+	BaseParams
+	Denom string
+}
+
 func queryValidators(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
 	stakeParams := k.GetParams(ctx)
 	validators := k.GetValidators(ctx, stakeParams.MaxValidators)
@@ -268,6 +317,9 @@ func queryValidator(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorPar
 	if !found {
 		return []byte{}, types.ErrNoValidatorFound(types.DefaultCodespace)
 	}
+	if change, found := k.GetPendingCommissionChange(ctx, params.ValidatorAddr); found {
+		validator.PendingCommissionChange = &change
+	}
 
 	res, errRes := codec.MarshalJSONIndent(cdc, validator)
 	if errRes != nil {
@@ -477,6 +529,74 @@ func queryCrossStakeInfoByBscAddress(ctx sdk.Context, cdc *codec.Codec, params *
 	return res, nil
 }
 
+func queryLiquidStakeReceipt(ctx sdk.Context, cdc *codec.Codec, params *QueryLiquidStakeReceiptParams, k keep.Keeper) ([]byte, sdk.Error) {
+	receipt, found := k.GetLiquidStakeReceipt(ctx, params.Denom)
+	if !found {
+		return nil, types.ErrNoLiquidStakeReceipt(k.Codespace())
+	}
+	response := types.LiquidStakeReceiptResponse{
+		LiquidStakeReceipt: receipt,
+		LiquidStakeAddr:    types.GetLiquidStakeAddr(ctx.SideChainId(), receipt.ValidatorAddr),
+		Shares:             sdk.ZeroDec(),
+		Tokens:             sdk.ZeroDec(),
+	}
+	if delegation, found := k.GetDelegation(ctx, response.LiquidStakeAddr, receipt.ValidatorAddr); found {
+		validator, found := k.GetValidator(ctx, receipt.ValidatorAddr)
+		if !found {
+			return nil, types.ErrNoValidatorFound(k.Codespace())
+		}
+		response.Shares = delegation.Shares
+		response.Tokens = validator.TokensFromShares(delegation.Shares)
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, response)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryAutoCompound(ctx sdk.Context, cdc *codec.Codec, params *QueryBondsParams, k keep.Keeper) ([]byte, sdk.Error) {
+	autoCompound, found := k.GetAutoCompound(ctx, params.DelegatorAddr, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoAutoCompound(k.Codespace())
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, autoCompound)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryPendingCommissionChanges(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) ([]byte, sdk.Error) {
+	changes := k.GetAllPendingCommissionChanges(ctx)
+	res, errRes := codec.MarshalJSONIndent(cdc, changes)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryInsurancePool(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) ([]byte, sdk.Error) {
+	pool, found := k.GetInsurancePool(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoInsurancePool(k.Codespace())
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, pool)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryInsuranceClaims(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) ([]byte, sdk.Error) {
+	claims := k.GetInsuranceClaims(ctx, params.ValidatorAddr)
+	res, errRes := codec.MarshalJSONIndent(cdc, claims)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func prepareSideChainCtx(ctx sdk.Context, k keep.Keeper, sideChainId string) (sdk.Context, sdk.Error) {
 	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
 	if err != nil {
@@ -8,38 +8,53 @@ import (
 )
 
 type (
-	Keeper                     = keeper.Keeper
-	Validator                  = types.Validator
-	Description                = types.Description
-	Commission                 = types.Commission
-	Delegation                 = types.Delegation
-	UnbondingDelegation        = types.UnbondingDelegation
-	Redelegation               = types.Redelegation
-	Params                     = types.Params
-	Pool                       = types.Pool
-	MsgCreateValidator         = types.MsgCreateValidator
-	MsgCreateValidatorOpen     = types.MsgCreateValidatorOpen
-	MsgRemoveValidator         = types.MsgRemoveValidator
-	MsgCreateValidatorProposal = types.MsgCreateValidatorProposal
-	MsgEditValidator           = types.MsgEditValidator
-	MsgDelegate                = types.MsgDelegate
-	MsgBeginUnbonding          = types.MsgBeginUnbonding
-	MsgRedelegate              = types.MsgRedelegate
-	MsgUndelegate              = types.MsgUndelegate
-	GenesisState               = types.GenesisState
-	QueryDelegatorParams       = querier.QueryDelegatorParams
-	QueryValidatorParams       = querier.QueryValidatorParams
-	QueryBondsParams           = querier.QueryBondsParams
-	QueryCrossStakeInfoParams  = querier.QueryCrossStakeInfoParams
-	CreateValidatorJsonMsg     = types.CreateValidatorJsonMsg
-	QueryTopValidatorsParams   = querier.QueryTopValidatorsParams
-	BaseParams                 = querier.BaseParams
+	Keeper                        = keeper.Keeper
+	Validator                     = types.Validator
+	Description                   = types.Description
+	Commission                    = types.Commission
+	Delegation                    = types.Delegation
+	UnbondingDelegation           = types.UnbondingDelegation
+	Redelegation                  = types.Redelegation
+	Params                        = types.Params
+	Pool                          = types.Pool
+	MsgCreateValidator            = types.MsgCreateValidator
+	MsgCreateValidatorOpen        = types.MsgCreateValidatorOpen
+	MsgRemoveValidator            = types.MsgRemoveValidator
+	MsgCreateValidatorProposal    = types.MsgCreateValidatorProposal
+	MsgEditValidator              = types.MsgEditValidator
+	MsgDelegate                   = types.MsgDelegate
+	MsgBeginUnbonding             = types.MsgBeginUnbonding
+	MsgRedelegate                 = types.MsgRedelegate
+	MsgUndelegate                 = types.MsgUndelegate
+	GenesisState                  = types.GenesisState
+	QueryDelegatorParams          = querier.QueryDelegatorParams
+	QueryValidatorParams          = querier.QueryValidatorParams
+	QueryBondsParams              = querier.QueryBondsParams
+	QueryCrossStakeInfoParams     = querier.QueryCrossStakeInfoParams
+	QueryLiquidStakeReceiptParams = querier.QueryLiquidStakeReceiptParams
+	CreateValidatorJsonMsg        = types.CreateValidatorJsonMsg
+	QueryTopValidatorsParams      = querier.QueryTopValidatorsParams
+	BaseParams                    = querier.BaseParams
 
 	MsgCreateSideChainValidator = types.MsgCreateSideChainValidator
 	MsgEditSideChainValidator   = types.MsgEditSideChainValidator
 	MsgSideChainDelegate        = types.MsgSideChainDelegate
 	MsgSideChainRedelegate      = types.MsgSideChainRedelegate
 	MsgSideChainUndelegate      = types.MsgSideChainUndelegate
+	MsgSideChainLiquidDelegate  = types.MsgSideChainLiquidDelegate
+	MsgSideChainRedeemReceipt   = types.MsgSideChainRedeemReceipt
+	LiquidStakeReceipt          = types.LiquidStakeReceipt
+	ReceiptTokenRegistry        = types.ReceiptTokenRegistry
+	MsgSideChainSetAutoCompound = types.MsgSideChainSetAutoCompound
+	AutoCompound                = types.AutoCompound
+
+	MsgSideChainCancelCommissionChange = types.MsgSideChainCancelCommissionChange
+	PendingCommissionChange            = types.PendingCommissionChange
+
+	MsgSetInsurancePool     = types.MsgSetInsurancePool
+	MsgDepositInsurancePool = types.MsgDepositInsurancePool
+	InsurancePool           = types.InsurancePool
+	InsuranceClaim          = types.InsuranceClaim
 
 	DistributionEvent      = types.DistributionEvent
 	DistributionData       = types.DistributionData
@@ -89,6 +104,7 @@ var (
 	GetREDsFromValSrcIndexKey        = keeper.GetREDsFromValSrcIndexKey
 	GetREDsToValDstIndexKey          = keeper.GetREDsToValDstIndexKey
 	GetREDsByDelToValDstIndexKey     = keeper.GetREDsByDelToValDstIndexKey
+	GetCommissionChangeKey           = keeper.GetCommissionChangeKey
 	TestingUpdateValidator           = keeper.TestingUpdateValidator
 	MigratePowerRankKey              = keeper.MigratePowerRankKey
 	MigrateValidatorDistributionAddr = keeper.MigrateValidators
@@ -127,13 +143,23 @@ var (
 	NewMsgSideChainDelegate                  = types.NewMsgSideChainDelegate
 	NewMsgSideChainRedelegate                = types.NewMsgSideChainRedelegate
 	NewMsgSideChainUndelegate                = types.NewMsgSideChainUndelegate
+	NewMsgSideChainLiquidDelegate            = types.NewMsgSideChainLiquidDelegate
+	NewMsgSideChainRedeemReceipt             = types.NewMsgSideChainRedeemReceipt
+	GetLiquidStakeAddr                       = types.GetLiquidStakeAddr
+	GetReceiptDenom                          = types.GetReceiptDenom
+	NewMsgSideChainSetAutoCompound           = types.NewMsgSideChainSetAutoCompound
+	NewMsgSideChainCancelCommissionChange    = types.NewMsgSideChainCancelCommissionChange
+	NewMsgSetInsurancePool                   = types.NewMsgSetInsurancePool
+	NewMsgDepositInsurancePool               = types.NewMsgDepositInsurancePool
 
 	NewQuerier    = querier.NewQuerier
 	NewBaseParams = querier.NewBaseParams
 
-	FeeCollectorAddr  = keeper.FeeCollectorAddr
-	DelegationAccAddr = keeper.DelegationAccAddr
-	FeeForAllAccAddr  = keeper.FeeForAllBcValsAccAddr
+	FeeCollectorAddr     = keeper.FeeCollectorAddr
+	DelegationAccAddr    = keeper.DelegationAccAddr
+	AutoCompoundAccAddr  = keeper.AutoCompoundAccAddr
+	FeeForAllAccAddr     = keeper.FeeForAllBcValsAccAddr
+	InsurancePoolAccAddr = keeper.InsurancePoolAccAddr
 )
 
 const (
@@ -151,6 +177,11 @@ const (
 	QueryPool                          = querier.QueryPool
 	QueryParameters                    = querier.QueryParameters
 	QueryCrossStakeInfo                = querier.QueryCrossStakeInfoByBscAddress
+	QueryLiquidStakeReceipt            = querier.QueryLiquidStakeReceipt
+	QueryAutoCompound                  = querier.QueryAutoCompound
+	QueryPendingCommissionChanges      = querier.QueryPendingCommissionChanges
+	QueryInsurancePool                 = querier.QueryInsurancePool
+	QueryInsuranceClaims               = querier.QueryInsuranceClaims
 
 	Topic = types.Topic
 )
@@ -195,6 +226,14 @@ var (
 
 	ErrInvalidSideChainId = types.ErrInvalidSideChainId
 
+	ErrNoLiquidStakeReceipt = types.ErrNoLiquidStakeReceipt
+	ErrReceiptDenomConflict = types.ErrReceiptDenomConflict
+	ErrReceiptWithoutShares = types.ErrReceiptWithoutShares
+	ErrNoAutoCompound       = types.ErrNoAutoCompound
+
+	ErrNoPendingCommissionChange = types.ErrNoPendingCommissionChange
+	ErrNoInsurancePool           = types.ErrNoInsurancePool
+
 	ErrNotMature             = types.ErrNotMature
 	ErrNoUnbondingDelegation = types.ErrNoUnbondingDelegation
 	ErrNoRedelegation        = types.ErrNoRedelegation
@@ -13,4 +13,5 @@ var (
 	Moniker      = "moniker"
 	Identity     = "identity"
 	EndTime      = "end-time"
+	Receipt      = "receipt"
 )
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AutoCompound marks a delegation whose rewards are delegated back to its
+// validator during the distribution. The rewards are pending until they reach
+// the min delegation change.
+type AutoCompound struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Pending       int64          `json:"pending"`
+}
+
+func MustMarshalAutoCompound(cdc *codec.Codec, autoCompound AutoCompound) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(autoCompound)
+}
+
+func MustUnmarshalAutoCompound(cdc *codec.Codec, value []byte) AutoCompound {
+	var autoCompound AutoCompound
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &autoCompound)
+	return autoCompound
+}
+
+func (ac AutoCompound) HumanReadableString() (string, error) {
+	resp := "Auto Compound \n"
+	resp += fmt.Sprintf("Delegator: %s\n", ac.DelegatorAddr.String())
+	resp += fmt.Sprintf("Validator: %s\n", ac.ValidatorAddr.String())
+	resp += fmt.Sprintf("Pending rewards: %d", ac.Pending)
+
+	return resp, nil
+}
@@ -21,6 +21,12 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSideChainDelegate{}, "cosmos-sdk/MsgSideChainDelegate", nil)
 	cdc.RegisterConcrete(MsgSideChainRedelegate{}, "cosmos-sdk/MsgSideChainRedelegate", nil)
 	cdc.RegisterConcrete(MsgSideChainUndelegate{}, "cosmos-sdk/MsgSideChainUndelegate", nil)
+	cdc.RegisterConcrete(MsgSideChainLiquidDelegate{}, "cosmos-sdk/MsgSideChainLiquidDelegate", nil)
+	cdc.RegisterConcrete(MsgSideChainRedeemReceipt{}, "cosmos-sdk/MsgSideChainRedeemReceipt", nil)
+	cdc.RegisterConcrete(MsgSideChainSetAutoCompound{}, "cosmos-sdk/MsgSideChainSetAutoCompound", nil)
+	cdc.RegisterConcrete(MsgSideChainCancelCommissionChange{}, "cosmos-sdk/MsgSideChainCancelCommissionChange", nil)
+	cdc.RegisterConcrete(MsgSetInsurancePool{}, "cosmos-sdk/MsgSetInsurancePool", nil)
+	cdc.RegisterConcrete(MsgDepositInsurancePool{}, "cosmos-sdk/MsgDepositInsurancePool", nil)
 
 	cdc.RegisterConcrete(&Params{}, "params/StakeParamSet", nil)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -23,6 +24,15 @@ type (
 		MaxRate       sdk.Dec `json:"max_rate"`        // maximum commission rate which validator can ever charge
 		MaxChangeRate sdk.Dec `json:"max_change_rate"` // maximum daily increase of the validator commission
 	}
+
+	// PendingCommissionChange defines a commission rate change of a validator
+	// which is queued until it takes effect in a breathe block.
+	PendingCommissionChange struct {
+		ValidatorAddr     sdk.ValAddress `json:"validator_addr"`      // the operator of the validator
+		Rate              sdk.Dec        `json:"rate"`                // the new commission rate
+		RequestHeight     int64          `json:"request_height"`      // the height the change was requested at
+		BreatheBlocksLeft int64          `json:"breathe_blocks_left"` // the number of breathe blocks until the change takes effect
+	}
 )
 
 // NewCommissionMsg returns an initialized validator commission message.
@@ -132,3 +142,31 @@ func (c Commission) ValidateNewRate(newRate sdk.Dec, blockTime time.Time) sdk.Er
 
 	return nil
 }
+
+// NewPendingCommissionChange returns a commission rate change which takes
+// effect after noticeBreatheBlocks breathe blocks.
+func NewPendingCommissionChange(valAddr sdk.ValAddress, rate sdk.Dec, requestHeight int64, noticeBreatheBlocks int64) PendingCommissionChange {
+	return PendingCommissionChange{
+		ValidatorAddr:     valAddr,
+		Rate:              rate,
+		RequestHeight:     requestHeight,
+		BreatheBlocksLeft: noticeBreatheBlocks,
+	}
+}
+
+func MustMarshalPendingCommissionChange(cdc *codec.Codec, change PendingCommissionChange) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(change)
+}
+
+func MustUnmarshalPendingCommissionChange(cdc *codec.Codec, value []byte) PendingCommissionChange {
+	var change PendingCommissionChange
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &change)
+	return change
+}
+
+// String implements the Stringer interface for a PendingCommissionChange.
+func (c PendingCommissionChange) String() string {
+	return fmt.Sprintf("rate: %s, requestHeight: %d, breatheBlocksLeft: %d",
+		c.Rate, c.RequestHeight, c.BreatheBlocksLeft,
+	)
+}
@@ -23,6 +23,10 @@ const (
 	CrossDistributeRewardRelayFee      = "crossDistributeRewardRelayFee"
 	CrossDistributeUndelegatedRelayFee = "crossDistributeUndelegatedRelayFee"
 
+	// CrossStakePackageTimeout is how long, in seconds, the side chain has to confirm a distribute package
+	// sent after the IBCPackageTimeout upgrade before it is refunded
+	CrossStakePackageTimeout int64 = 7 * 24 * 60 * 60
+
 	CrossStakeFailed  CrossStakeStatus = 0
 	CrossStakeSuccess CrossStakeStatus = 1
 
@@ -5,6 +5,7 @@ const (
 	EventTypeCompleteRedelegation = "complete_redelegation"
 	EventTypeCreateValidator      = "create_validator"
 	EventTypeEditValidator        = "edit_validator"
+	EventTypeCommissionChange     = "commission_change"
 	EventTypeDelegate             = "delegate"
 	EventTypeUnbond               = "unbond"
 	EventTypeRedelegate           = "redelegate"
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InsurancePool holds the tokens a validator pledges to reimburse its
+// delegators when they lose tokens in a slash of the validator. It is funded by
+// deposits of the operator and by a share of the commission.
+type InsurancePool struct {
+	ValidatorAddr    sdk.ValAddress `json:"validator_addr"`
+	CommissionPledge sdk.Dec        `json:"commission_pledge"` // the share of the commission paid to the pool
+	CoverageRatio    sdk.Dec        `json:"coverage_ratio"`    // the share of the slash loss of a delegator reimbursed
+	Balance          int64          `json:"balance"`
+	Reimbursed       int64          `json:"reimbursed"` // the total amount paid to the delegators so far
+}
+
+func MustMarshalInsurancePool(cdc *codec.Codec, pool InsurancePool) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(pool)
+}
+
+func MustUnmarshalInsurancePool(cdc *codec.Codec, value []byte) InsurancePool {
+	var pool InsurancePool
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &pool)
+	return pool
+}
+
+func (p InsurancePool) HumanReadableString() (string, error) {
+	resp := "Insurance Pool \n"
+	resp += fmt.Sprintf("Validator: %s\n", p.ValidatorAddr.String())
+	resp += fmt.Sprintf("Commission pledge: %s\n", p.CommissionPledge.String())
+	resp += fmt.Sprintf("Coverage ratio: %s\n", p.CoverageRatio.String())
+	resp += fmt.Sprintf("Balance: %d\n", p.Balance)
+	resp += fmt.Sprintf("Reimbursed: %d", p.Reimbursed)
+
+	return resp, nil
+}
+
+// InsurancePayout is the reimbursement of a delegator for its loss in a slash
+type InsurancePayout struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	Loss          int64          `json:"loss"`
+	Amount        int64          `json:"amount"`
+}
+
+// InsuranceClaim records the reimbursements paid by the insurance pool of a
+// validator for the slashes of a height
+type InsuranceClaim struct {
+	ValidatorAddr sdk.ValAddress    `json:"validator_addr"`
+	Height        int64             `json:"height"`
+	Loss          int64             `json:"loss"`
+	Reimbursed    int64             `json:"reimbursed"`
+	Payouts       []InsurancePayout `json:"payouts"`
+}
+
+func MustMarshalInsuranceClaim(cdc *codec.Codec, claim InsuranceClaim) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(claim)
+}
+
+func MustUnmarshalInsuranceClaim(cdc *codec.Codec, value []byte) InsuranceClaim {
+	var claim InsuranceClaim
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &claim)
+	return claim
+}
+
+func (c InsuranceClaim) HumanReadableString() (string, error) {
+	resp := "Insurance Claim \n"
+	resp += fmt.Sprintf("Validator: %s\n", c.ValidatorAddr.String())
+	resp += fmt.Sprintf("Height: %d\n", c.Height)
+	resp += fmt.Sprintf("Loss: %d\n", c.Loss)
+	resp += fmt.Sprintf("Reimbursed: %d", c.Reimbursed)
+	for _, payout := range c.Payouts {
+		resp += fmt.Sprintf("\n  Delegator: %s, Loss: %d, Reimbursed: %d", payout.DelegatorAddr.String(), payout.Loss, payout.Amount)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	LiquidStakeAddrSalt   string = "BinanceChainLiquidStake"
+	ReceiptDenomPrefix    string = "LST"
+	receiptDenomHashLen          = 5
+	receiptDenomSuffixLen        = 6
+)
+
+// GetLiquidStakeAddr returns the address holding the delegation to a side chain
+// validator which backs the receipts of the validator. Nobody owns its key.
+func GetLiquidStakeAddr(sideChainId string, valAddr sdk.ValAddress) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash(append([]byte(LiquidStakeAddrSalt+sideChainId), valAddr.Bytes()...)))
+}
+
+// GetReceiptDenom returns the denom of the receipts of the delegations to a side
+// chain validator, e.g. LST1A2B3-C4D5E6.
+func GetReceiptDenom(sideChainId string, valAddr sdk.ValAddress) string {
+	h := strings.ToUpper(hex.EncodeToString(tmhash.Sum(append([]byte(sideChainId+"|"), valAddr.Bytes()...))))
+	return fmt.Sprintf("%s%s-%s", ReceiptDenomPrefix, h[:receiptDenomHashLen],
+		h[receiptDenomHashLen:receiptDenomHashLen+receiptDenomSuffixLen])
+}
+
+// ReceiptTokenRegistry keeps the receipt denoms in the token store of the chain,
+// so that the receipts are listed and transferred like the issued tokens
+type ReceiptTokenRegistry interface {
+	// HasToken returns true if a token of the symbol exists
+	HasToken(ctx sdk.Context, symbol string) bool
+	// SetReceiptToken creates the token of the denom of a receipt, or updates
+	// its total supply to the supply of the receipt
+	SetReceiptToken(ctx sdk.Context, receipt LiquidStakeReceipt) error
+}
+
+// LiquidStakeReceipt records the validator of a receipt denom and the amount of
+// receipts in circulation. The receipts are claims on the delegation shares of
+// the liquid stake address of the validator, pro rata of the supply.
+type LiquidStakeReceipt struct {
+	Denom         string         `json:"denom"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Supply        int64          `json:"supply"`
+}
+
+func MustMarshalLiquidStakeReceipt(cdc *codec.Codec, receipt LiquidStakeReceipt) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(receipt)
+}
+
+func MustUnmarshalLiquidStakeReceipt(cdc *codec.Codec, value []byte) LiquidStakeReceipt {
+	var receipt LiquidStakeReceipt
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &receipt)
+	return receipt
+}
+
+// ----------------------------------------------------------------------------
+// Client Types
+
+// LiquidStakeReceiptResponse is the state of the receipts of a validator: the
+// shares and tokens of the backing delegation and their value per receipt.
+type LiquidStakeReceiptResponse struct {
+	LiquidStakeReceipt
+	LiquidStakeAddr sdk.AccAddress `json:"liquid_stake_addr"`
+	Shares          sdk.Dec        `json:"shares"`
+	Tokens          sdk.Dec        `json:"tokens"`
+}
+
+func (r LiquidStakeReceiptResponse) HumanReadableString() (string, error) {
+	resp := "Liquid Stake Receipt \n"
+	resp += fmt.Sprintf("Denom: %s\n", r.Denom)
+	resp += fmt.Sprintf("Validator: %s\n", r.ValidatorAddr.String())
+	resp += fmt.Sprintf("Supply: %d\n", r.Supply)
+	resp += fmt.Sprintf("Liquid stake address: %s\n", r.LiquidStakeAddr.String())
+	resp += fmt.Sprintf("Shares: %s\n", r.Shares.String())
+	resp += fmt.Sprintf("Tokens: %s", r.Tokens.String())
+
+	return resp, nil
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const (
+	MsgTypeSetInsurancePool     = "set_insurance_pool"
+	MsgTypeDepositInsurancePool = "deposit_insurance_pool"
+)
+
+//______________________________________________________________________
+// MsgSetInsurancePool opens the insurance pool of a validator or updates its
+// rules. The validator is on the Beacon Chain if SideChainId is empty.
+type MsgSetInsurancePool struct {
+	ValidatorAddr    sdk.ValAddress `json:"validator_addr"`
+	CommissionPledge sdk.Dec        `json:"commission_pledge"`
+	CoverageRatio    sdk.Dec        `json:"coverage_ratio"`
+	SideChainId      string         `json:"side_chain_id,omitempty"`
+}
+
+func NewMsgSetInsurancePool(sideChainId string, valAddr sdk.ValAddress, commissionPledge, coverageRatio sdk.Dec) MsgSetInsurancePool {
+	return MsgSetInsurancePool{
+		ValidatorAddr:    valAddr,
+		CommissionPledge: commissionPledge,
+		CoverageRatio:    coverageRatio,
+		SideChainId:      sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSetInsurancePool) Route() string { return MsgRoute }
+func (msg MsgSetInsurancePool) Type() string  { return MsgTypeSetInsurancePool }
+func (msg MsgSetInsurancePool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSetInsurancePool) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSetInsurancePool) ValidateBasic() sdk.Error {
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if msg.CommissionPledge.LT(sdk.ZeroDec()) || msg.CommissionPledge.GT(sdk.OneDec()) {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "commission pledge must be between 0 and 1 (inclusive)")
+	}
+	if msg.CoverageRatio.LT(sdk.ZeroDec()) || msg.CoverageRatio.GT(sdk.OneDec()) {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "coverage ratio must be between 0 and 1 (inclusive)")
+	}
+	if len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSetInsurancePool) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func (msg MsgSetInsurancePool) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+//______________________________________________________________________
+// MsgDepositInsurancePool pledges tokens of the operator of a validator to its
+// insurance pool. The tokens cannot be withdrawn.
+type MsgDepositInsurancePool struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Amount        sdk.Coin       `json:"amount"`
+	SideChainId   string         `json:"side_chain_id,omitempty"`
+}
+
+func NewMsgDepositInsurancePool(sideChainId string, valAddr sdk.ValAddress, amount sdk.Coin) MsgDepositInsurancePool {
+	return MsgDepositInsurancePool{
+		ValidatorAddr: valAddr,
+		Amount:        amount,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgDepositInsurancePool) Route() string { return MsgRoute }
+func (msg MsgDepositInsurancePool) Type() string  { return MsgTypeDepositInsurancePool }
+func (msg MsgDepositInsurancePool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgDepositInsurancePool) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgDepositInsurancePool) ValidateBasic() sdk.Error {
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if msg.Amount.Amount <= 0 {
+		return ErrBadDelegationAmount(DefaultCodespace, "amount must be positive")
+	}
+	if len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgDepositInsurancePool) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func (msg MsgDepositInsurancePool) GetSideChainId() string {
+	return msg.SideChainId
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
@@ -14,6 +15,11 @@ const (
 	MsgTypeSideChainDelegate        = "side_delegate"
 	MsgTypeSideChainRedelegate      = "side_redelegate"
 	MsgTypeSideChainUndelegate      = "side_undelegate"
+	MsgTypeSideChainLiquidDelegate  = "side_liquid_delegate"
+	MsgTypeSideChainRedeemReceipt   = "side_redeem_receipt"
+	MsgTypeSideChainSetAutoCompound = "side_set_auto_compound"
+
+	MsgTypeSideChainCancelCommissionChange = "side_cancel_commission_change"
 )
 
 type SideChainIder interface {
@@ -379,3 +385,220 @@ func (msg MsgSideChainUndelegate) GetInvolvedAddresses() []sdk.AccAddress {
 func (msg MsgSideChainUndelegate) GetSideChainId() string {
 	return msg.SideChainId
 }
+
+//______________________________________________________________________
+// MsgSideChainLiquidDelegate delegates to a side chain validator through its
+// liquid stake address, and mints to the delegator the transferable receipts of
+// the delegation shares.
+type MsgSideChainLiquidDelegate struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Delegation    sdk.Coin       `json:"delegation"`
+
+	SideChainId string `json:"side_chain_id"`
+}
+
+func NewMsgSideChainLiquidDelegate(sideChainId string, delAddr sdk.AccAddress, valAddr sdk.ValAddress, delegation sdk.Coin) MsgSideChainLiquidDelegate {
+	return MsgSideChainLiquidDelegate{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Delegation:    delegation,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSideChainLiquidDelegate) Route() string { return MsgRoute }
+func (msg MsgSideChainLiquidDelegate) Type() string  { return MsgTypeSideChainLiquidDelegate }
+func (msg MsgSideChainLiquidDelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSideChainLiquidDelegate) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSideChainLiquidDelegate) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if msg.Delegation.Amount <= 0 {
+		return ErrBadDelegationAmount(DefaultCodespace, "delegation amount must be positive")
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSideChainLiquidDelegate) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSideChainLiquidDelegate) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+//______________________________________________________________________
+// MsgSideChainRedeemReceipt burns liquid stake receipts and moves the delegation
+// shares they are a claim on to a delegation of the holder.
+type MsgSideChainRedeemReceipt struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	Amount        sdk.Coin       `json:"amount"`
+	SideChainId   string         `json:"side_chain_id"`
+}
+
+func NewMsgSideChainRedeemReceipt(sideChainId string, delAddr sdk.AccAddress, amount sdk.Coin) MsgSideChainRedeemReceipt {
+	return MsgSideChainRedeemReceipt{
+		DelegatorAddr: delAddr,
+		Amount:        amount,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSideChainRedeemReceipt) Route() string { return MsgRoute }
+func (msg MsgSideChainRedeemReceipt) Type() string  { return MsgTypeSideChainRedeemReceipt }
+func (msg MsgSideChainRedeemReceipt) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSideChainRedeemReceipt) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSideChainRedeemReceipt) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if msg.Amount.Amount <= 0 {
+		return ErrBadDelegationAmount(DefaultCodespace, "redeem amount must be positive")
+	}
+	if !strings.HasPrefix(msg.Amount.Denom, ReceiptDenomPrefix) {
+		return ErrBadDenom(DefaultCodespace)
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSideChainRedeemReceipt) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func (msg MsgSideChainRedeemReceipt) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+//______________________________________________________________________
+// MsgSideChainSetAutoCompound enables or disables the auto compounding of the
+// rewards of a delegation to a side chain validator.
+type MsgSideChainSetAutoCompound struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Enabled       bool           `json:"enabled"`
+	SideChainId   string         `json:"side_chain_id"`
+}
+
+func NewMsgSideChainSetAutoCompound(sideChainId string, delAddr sdk.AccAddress, valAddr sdk.ValAddress, enabled bool) MsgSideChainSetAutoCompound {
+	return MsgSideChainSetAutoCompound{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Enabled:       enabled,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSideChainSetAutoCompound) Route() string { return MsgRoute }
+func (msg MsgSideChainSetAutoCompound) Type() string  { return MsgTypeSideChainSetAutoCompound }
+func (msg MsgSideChainSetAutoCompound) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSideChainSetAutoCompound) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSideChainSetAutoCompound) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSideChainSetAutoCompound) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSideChainSetAutoCompound) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+//______________________________________________________________________
+// MsgSideChainCancelCommissionChange cancels the pending commission rate change
+// of a side chain validator.
+type MsgSideChainCancelCommissionChange struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	SideChainId   string         `json:"side_chain_id"`
+}
+
+func NewMsgSideChainCancelCommissionChange(sideChainId string, valAddr sdk.ValAddress) MsgSideChainCancelCommissionChange {
+	return MsgSideChainCancelCommissionChange{
+		ValidatorAddr: valAddr,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSideChainCancelCommissionChange) Route() string { return MsgRoute }
+func (msg MsgSideChainCancelCommissionChange) Type() string {
+	return MsgTypeSideChainCancelCommissionChange
+}
+func (msg MsgSideChainCancelCommissionChange) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSideChainCancelCommissionChange) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSideChainCancelCommissionChange) ValidateBasic() sdk.Error {
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSideChainCancelCommissionChange) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func (msg MsgSideChainCancelCommissionChange) GetSideChainId() string {
+	return msg.SideChainId
+}
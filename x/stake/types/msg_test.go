@@ -216,3 +216,162 @@ func TestMsgSideChainDelegate_Type(t *testing.T) {
 	require.NoError(t, err)
 	t.Log(string(bz2))
 }
+
+func TestMsgSideChainLiquidDelegate(t *testing.T) {
+	tests := []struct {
+		name          string
+		sideChainId   string
+		delegatorAddr sdk.AccAddress
+		validatorAddr sdk.ValAddress
+		bond          sdk.Coin
+		expectPass    bool
+	}{
+		{"basic good", "bsc", sdk.AccAddress(addr1), addr2, coinPos, true},
+		{"empty side chain id", "", sdk.AccAddress(addr1), addr2, coinPos, false},
+		{"empty delegator", "bsc", sdk.AccAddress(emptyAddr), addr2, coinPos, false},
+		{"empty validator", "bsc", sdk.AccAddress(addr1), emptyAddr, coinPos, false},
+		{"empty bond", "bsc", sdk.AccAddress(addr1), addr2, coinZero, false},
+		{"negative bond", "bsc", sdk.AccAddress(addr1), addr2, coinNeg, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSideChainLiquidDelegate(tc.sideChainId, tc.delegatorAddr, tc.validatorAddr, tc.bond)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestMsgSideChainRedeemReceipt(t *testing.T) {
+	receipt := sdk.NewCoin(GetReceiptDenom("bsc", addr2), 10000e8)
+	tests := []struct {
+		name          string
+		sideChainId   string
+		delegatorAddr sdk.AccAddress
+		amount        sdk.Coin
+		expectPass    bool
+	}{
+		{"basic good", "bsc", sdk.AccAddress(addr1), receipt, true},
+		{"empty side chain id", "", sdk.AccAddress(addr1), receipt, false},
+		{"empty delegator", "bsc", sdk.AccAddress(emptyAddr), receipt, false},
+		{"not a receipt", "bsc", sdk.AccAddress(addr1), coinPos, false},
+		{"empty amount", "bsc", sdk.AccAddress(addr1), sdk.NewCoin(receipt.Denom, 0), false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSideChainRedeemReceipt(tc.sideChainId, tc.delegatorAddr, tc.amount)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestGetReceiptDenom(t *testing.T) {
+	denom := GetReceiptDenom("bsc", addr1)
+	_, err := sdk.ParseCoin("1:" + denom)
+	require.NoError(t, err)
+	require.Equal(t, denom, GetReceiptDenom("bsc", addr1))
+	require.NotEqual(t, denom, GetReceiptDenom("bsc", addr2))
+	require.NotEqual(t, denom, GetReceiptDenom("chapel", addr1))
+}
+
+func TestMsgSideChainSetAutoCompound(t *testing.T) {
+	tests := []struct {
+		name          string
+		sideChainId   string
+		delegatorAddr sdk.AccAddress
+		validatorAddr sdk.ValAddress
+		expectPass    bool
+	}{
+		{"basic good", "bsc", sdk.AccAddress(addr1), addr2, true},
+		{"empty side chain id", "", sdk.AccAddress(addr1), addr2, false},
+		{"empty delegator", "bsc", sdk.AccAddress(emptyAddr), addr2, false},
+		{"empty validator", "bsc", sdk.AccAddress(addr1), emptyAddr, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSideChainSetAutoCompound(tc.sideChainId, tc.delegatorAddr, tc.validatorAddr, true)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestMsgSideChainCancelCommissionChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		sideChainId   string
+		validatorAddr sdk.ValAddress
+		expectPass    bool
+	}{
+		{"basic good", "bsc", addr1, true},
+		{"empty side chain id", "", addr1, false},
+		{"empty validator", "bsc", emptyAddr, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSideChainCancelCommissionChange(tc.sideChainId, tc.validatorAddr)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestMsgSetInsurancePool(t *testing.T) {
+	tests := []struct {
+		name             string
+		sideChainId      string
+		validatorAddr    sdk.ValAddress
+		commissionPledge sdk.Dec
+		coverageRatio    sdk.Dec
+		expectPass       bool
+	}{
+		{"basic good", "", addr1, sdk.NewDecWithPrec(1, 1), sdk.OneDec(), true},
+		{"side chain", "bsc", addr1, sdk.ZeroDec(), sdk.NewDecWithPrec(5, 1), true},
+		{"empty validator", "", emptyAddr, sdk.NewDecWithPrec(1, 1), sdk.OneDec(), false},
+		{"negative pledge", "", addr1, sdk.NewDecWithPrec(-1, 1), sdk.OneDec(), false},
+		{"huge coverage", "", addr1, sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(11, 1), false},
+		{"long side chain id", "side-chain-id-too-long", addr1, sdk.NewDecWithPrec(1, 1), sdk.OneDec(), false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSetInsurancePool(tc.sideChainId, tc.validatorAddr, tc.commissionPledge, tc.coverageRatio)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+func TestMsgDepositInsurancePool(t *testing.T) {
+	tests := []struct {
+		name          string
+		sideChainId   string
+		validatorAddr sdk.ValAddress
+		amount        sdk.Coin
+		expectPass    bool
+	}{
+		{"basic good", "", addr1, coinPos, true},
+		{"side chain", "bsc", addr1, coinPos, true},
+		{"empty validator", "", emptyAddr, coinPos, false},
+		{"zero amount", "", addr1, coinZero, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgDepositInsurancePool(tc.sideChainId, tc.validatorAddr, tc.amount)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
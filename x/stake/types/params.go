@@ -32,6 +32,12 @@ const (
 	defaultRewardDistributionBatchSize = 1000
 
 	ConsAddrUpdateIntervalInHours = 24 * 30
+
+	// DefaultCommissionChangeNoticeBreatheBlocks is the default number of breathe
+	// blocks between the request of a commission rate change of a side chain
+	// validator and the breathe block it takes effect in, which leaves the
+	// delegators the time to react
+	DefaultCommissionChangeNoticeBreatheBlocks int64 = 2
 )
 
 // nolint - Keys for parameter access
@@ -46,6 +52,8 @@ var (
 	KeyBaseProposerRewardRatio     = []byte("BaseProposerRewardRatio")
 	KeyBonusProposerRewardRatio    = []byte("BonusProposerRewardRatio")
 	KeyFeeFromBscToBcRatio         = []byte("FeeFromBscToBcRatio")
+
+	KeyCommissionChangeNoticeBreatheBlocks = []byte("CommissionChangeNoticeBreatheBlocks")
 )
 
 var _ params.ParamSet = (*Params)(nil)
@@ -64,6 +72,8 @@ type Params struct {
 	BaseProposerRewardRatio  types.Dec `json:"base_proposer_reward_ratio"`  // the base proposer reward ratio
 	BonusProposerRewardRatio types.Dec `json:"bonus_proposer_reward_ratio"` // the bonus proposer reward ratio
 	FeeFromBscToBcRatio      types.Dec `json:"fee_from_bsc_to_bc_ratio"`    // the fee from bsc to bc ratio
+	// added in CommissionSchedule
+	CommissionChangeNoticeBreatheBlocks int64 `json:"commission_change_notice_breathe_blocks"` // the breathe blocks before a commission rate change takes effect
 }
 
 func (p *Params) GetBCParamAttribute() string {
@@ -110,13 +120,17 @@ func (p *Params) UpdateCheck() error {
 	if p.FeeFromBscToBcRatio.LT(types.ZeroDec()) {
 		return fmt.Errorf("the fee_from_bsc_to_bc_ratio should be no less than 0")
 	}
+	if types.IsUpgrade(types.CommissionSchedule) &&
+		(p.CommissionChangeNoticeBreatheBlocks < 1 || p.CommissionChangeNoticeBreatheBlocks > 30) {
+		return fmt.Errorf("the commission_change_notice_breathe_blocks should be in range 1 to 30")
+	}
 
 	return nil
 }
 
 // Implements params.ParamSet
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
-	return params.KeyValuePairs{
+	pairs := params.KeyValuePairs{
 		{KeyUnbondingTime, &p.UnbondingTime},
 		{KeyMaxValidators, &p.MaxValidators},
 		{KeyBondDenom, &p.BondDenom},
@@ -128,6 +142,10 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 		{KeyBonusProposerRewardRatio, &p.BonusProposerRewardRatio},
 		{KeyFeeFromBscToBcRatio, &p.FeeFromBscToBcRatio},
 	}
+	if types.IsUpgrade(types.CommissionSchedule) {
+		pairs = append(pairs, params.KeyValuePairs{{KeyCommissionChangeNoticeBreatheBlocks, &p.CommissionChangeNoticeBreatheBlocks}}...)
+	}
+	return pairs
 }
 
 // Equal returns a boolean determining if two Param types are identical.
@@ -150,6 +168,8 @@ func DefaultParams() Params {
 		BaseProposerRewardRatio:     types.NewDec(1e6),
 		BonusProposerRewardRatio:    types.NewDec(4e6),
 		FeeFromBscToBcRatio:         types.NewDec(1e7),
+
+		CommissionChangeNoticeBreatheBlocks: DefaultCommissionChangeNoticeBreatheBlocks,
 	}
 }
 
@@ -168,6 +188,7 @@ func (p Params) HumanReadableString() string {
 	resp += fmt.Sprintf("Base proposer reward ratio: %s\n", p.BaseProposerRewardRatio)
 	resp += fmt.Sprintf("Bonus proposer reward ratio: %s\n", p.BonusProposerRewardRatio)
 	resp += fmt.Sprintf("Fee from BSC to BC ratio: %s\n", p.FeeFromBscToBcRatio)
+	resp += fmt.Sprintf("Commission change notice breathe blocks: %d\n", p.CommissionChangeNoticeBreatheBlocks)
 	return resp
 }
 
@@ -48,6 +48,8 @@ type Validator struct {
 
 	StakeSnapshots   []sdk.Dec `json:"stake_snapshots,omitempty"`   // staked tokens snapshot over a period of time, e.g. 30 days
 	AccumulatedStake sdk.Dec   `json:"accumulated_stake,omitempty"` // accumulated stake, sum of StakeSnapshots
+
+	PendingCommissionChange *PendingCommissionChange `json:"-"` // the queued commission rate change, not stored with the validator but filled by the queries
 }
 
 // NewValidator - initialize a new validator
@@ -168,6 +170,9 @@ func (v Validator) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Unbonding Height: %d\n", v.UnbondingHeight)
 	resp += fmt.Sprintf("Minimum Unbonding Time: %v\n", v.UnbondingMinTime)
 	resp += fmt.Sprintf("Commission: {%s}\n", v.Commission)
+	if v.PendingCommissionChange != nil {
+		resp += fmt.Sprintf("Pending Commission Change: {%s}\n", v.PendingCommissionChange)
+	}
 	if len(v.SideChainId) != 0 {
 		resp += fmt.Sprintf("Distribution Addr: %s\n", v.DistributionAddr)
 		resp += fmt.Sprintf("Side Chain Id: %s\n", v.SideChainId)
@@ -209,6 +214,8 @@ type bechValidator struct {
 
 	StakeSnapshots   []sdk.Dec `json:"stake_snapshots,omitempty"`   // staked tokens snapshot over a period of time, e.g. 30 days
 	AccumulatedStake sdk.Dec   `json:"accumulated_stake,omitempty"` // accumulated stake, sum of StakeSnapshots
+
+	PendingCommissionChange *PendingCommissionChange `json:"pending_commission_change,omitempty"` // the queued commission rate change
 }
 
 // MarshalJSON marshals the validator to JSON using Bech32
@@ -242,6 +249,8 @@ func (v Validator) MarshalJSON() ([]byte, error) {
 		SideFeeAddr:        sdk.HexAddress(v.SideFeeAddr),
 		StakeSnapshots:     v.StakeSnapshots,
 		AccumulatedStake:   v.AccumulatedStake,
+
+		PendingCommissionChange: v.PendingCommissionChange,
 	})
 }
 
@@ -276,6 +285,8 @@ func (v *Validator) UnmarshalJSON(data []byte) error {
 		Commission:         bv.Commission,
 		StakeSnapshots:     bv.StakeSnapshots,
 		AccumulatedStake:   bv.AccumulatedStake,
+
+		PendingCommissionChange: bv.PendingCommissionChange,
 	}
 	if len(bv.SideChainId) != 0 {
 		v.DistributionAddr = bv.DistributionAddr